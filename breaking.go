@@ -0,0 +1,54 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/lusingander/topi/internal/breaking"
+)
+
+func runBreaking(args []string) error {
+	fs := flag.NewFlagSet("breaking", flag.ExitOnError)
+	base := fs.String("base", "", "base (old) OpenAPI spec json/yaml filepath")
+	head := fs.String("head", "", "head (new) OpenAPI spec json/yaml filepath")
+	format := fs.String("format", "text", "output format (text, json, junit)")
+	remoteFlags := addRemoteFlags(fs)
+	fs.Parse(args)
+
+	if *base == "" || *head == "" {
+		return errors.New("must set both --base and --head")
+	}
+	loader, err := remoteFlags.defaultLoader(*base, *head)
+	if err != nil {
+		return err
+	}
+	baseDoc, err := loader.Load(*base)
+	if err != nil {
+		return err
+	}
+	headDoc, err := loader.Load(*head)
+	if err != nil {
+		return err
+	}
+
+	vs := breaking.Check(baseDoc, headDoc)
+	switch *format {
+	case "text":
+		err = breaking.WriteText(os.Stdout, vs)
+	case "json":
+		err = breaking.WriteJSON(os.Stdout, vs)
+	case "junit":
+		err = breaking.WriteJUnit(os.Stdout, vs)
+	default:
+		err = fmt.Errorf("unknown format: %s", *format)
+	}
+	if err != nil {
+		return err
+	}
+	if len(vs) > 0 {
+		return errCheckFailed
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/lusingander/topi/internal/cache"
+)
+
+func runCache(args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: topi cache list|clear")
+	}
+	dir, err := cache.DefaultDir()
+	if err != nil {
+		return err
+	}
+	c, err := cache.Open(dir)
+	if err != nil && args[0] != "clear" {
+		return err
+	}
+	switch args[0] {
+	case "list":
+		return writeCacheEntries(c.Entries())
+	case "clear":
+		if c == nil {
+			// the index is broken
+			return os.RemoveAll(dir)
+		}
+		n := len(c.Entries())
+		if err := c.Clear(); err != nil {
+			return err
+		}
+		fmt.Printf("removed %d cached documents\n", n)
+		return nil
+	default:
+		return errors.New("usage: topi cache list|clear")
+	}
+}
+
+func writeCacheEntries(es []*cache.Entry) error {
+	if len(es) == 0 {
+		fmt.Println("no cached documents")
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "URL\tSIZE\tFETCHED AT\tETAG")
+	for _, e := range es {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", e.Url, e.Size, e.FetchedAt.Local().Format("2006-01-02 15:04:05"), e.ETag)
+	}
+	return w.Flush()
+}
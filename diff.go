@@ -0,0 +1,33 @@
+package main
+
+import (
+	"errors"
+	"flag"
+
+	"github.com/lusingander/topi/internal/diff"
+	"github.com/lusingander/topi/internal/ui"
+)
+
+func runDiff(args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	remoteFlags := addRemoteFlags(fs)
+	fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		return errors.New("usage: topi diff <old> <new>")
+	}
+	loader, err := remoteFlags.defaultLoader(fs.Arg(0), fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	old, err := loader.Load(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	new, err := loader.Load(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	result := diff.Diff(old, new)
+	return ui.StartDiff(result)
+}
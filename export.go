@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/lusingander/topi/internal/export"
+)
+
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	format := fs.String("format", "markdown", "output format (markdown, html)")
+	output := fs.String("output", "", "output filepath, or directory if --split is set or the format is html (default: stdout)")
+	split := fs.Bool("split", false, "write a directory tree with an index page and one file per tag")
+	remoteFlags := addRemoteFlags(fs)
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return errors.New("usage: topi export [--format markdown|html] [--output <path>] [--split] <path>")
+	}
+	if (*split || *format == "html") && *output == "" {
+		return errors.New("--output must be set if --split is set or the format is html")
+	}
+	loader, err := remoteFlags.defaultLoader(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	doc, err := loader.Load(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	switch *format {
+	case "markdown":
+		if *split {
+			return writeFiles(*output, export.MarkdownFiles(doc))
+		}
+		return writeFile(*output, export.Markdown(doc))
+	case "html":
+		files, err := export.HTMLFiles(doc)
+		if err != nil {
+			return err
+		}
+		return writeFiles(*output, files)
+	default:
+		return fmt.Errorf("unknown format: %s", *format)
+	}
+}
+
+func writeFile(path string, content []byte) error {
+	if path == "" {
+		_, err := os.Stdout.Write(content)
+		return err
+	}
+	return os.WriteFile(path, content, 0644)
+}
+
+func writeFiles(dir string, files map[string][]byte) error {
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package bookmark
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/lusingander/topi/internal/config"
+	"github.com/lusingander/topi/internal/topi"
+)
+
+const fileName = "bookmarks.json"
+
+// Bookmark identifies an operation by method and path, not by operationId,
+// so that it remains after the spec is changed.
+type Bookmark struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+}
+
+func (b *Bookmark) matches(p *topi.Path) bool {
+	return b.Method == p.Method && b.Path == p.UriPath
+}
+
+// Store keeps the bookmarks of all specs in a file.
+type Store struct {
+	path  string
+	specs map[string][]*Bookmark
+}
+
+// DefaultPath returns the path of the bookmarks file in the data directory.
+func DefaultPath() (string, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fileName), nil
+}
+
+// Load reads the bookmarks file. If the file does not exist, returns an empty store.
+// If the file can not be read or is broken, returns an empty store with the error, which can still be used.
+func Load(path string) (*Store, error) {
+	s := &Store{
+		path:  path,
+		specs: make(map[string][]*Bookmark),
+	}
+	specs, err := read(path)
+	if err != nil {
+		return s, err
+	}
+	s.specs = specs
+	return s, nil
+}
+
+func read(path string) (map[string][]*Bookmark, error) {
+	specs := make(map[string][]*Bookmark)
+	bs, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return specs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(bs, &specs); err != nil {
+		return nil, fmt.Errorf("broken bookmarks file: %w", err)
+	}
+	return specs, nil
+}
+
+// SpecKey returns the key to store the bookmarks of the document, the title or the file path if the title is empty.
+func SpecKey(doc *topi.Document) string {
+	if doc.Info.Title != "" {
+		return doc.Info.Title
+	}
+	return doc.Meta.FullPath
+}
+
+// List returns the bookmarks of the spec in the order they were added.
+func (s *Store) List(spec string) []*Bookmark {
+	return s.specs[spec]
+}
+
+func (s *Store) Contains(spec string, p *topi.Path) bool {
+	for _, b := range s.specs[spec] {
+		if b.matches(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Toggle adds the operation to the bookmarks, or removes it if it is already bookmarked, and saves the file.
+// The file is read again before the change not to drop the bookmarks saved by other sessions.
+// Returns true if the operation is added.
+func (s *Store) Toggle(spec string, p *topi.Path) (bool, error) {
+	if specs, err := read(s.path); err == nil {
+		s.specs = specs
+	}
+	bs := s.specs[spec]
+	added := true
+	for i, b := range bs {
+		if b.matches(p) {
+			bs = append(bs[:i:i], bs[i+1:]...)
+			added = false
+			break
+		}
+	}
+	if added {
+		bs = append(bs, &Bookmark{Method: p.Method, Path: p.UriPath})
+	}
+	if len(bs) == 0 {
+		delete(s.specs, spec)
+	} else {
+		s.specs[spec] = bs
+	}
+	return added, s.save()
+}
+
+// save writes the file by renaming a temporary file, so other sessions never read a partially written file.
+func (s *Store) save() error {
+	bs, err := json.MarshalIndent(s.specs, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, fileName+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name()) // no-op after renamed
+	if _, err := f.Write(bs); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(f.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.path)
+}
+
+// Resolve returns the operations of the bookmarks found in the document, and the bookmarks not found.
+func Resolve(doc *topi.Document, bs []*Bookmark) ([]*topi.Path, []*Bookmark) {
+	found := make([]*topi.Path, 0)
+	notFound := make([]*Bookmark, 0)
+	for _, b := range bs {
+		if p := doc.FindPath(b.Method, b.Path); p != nil {
+			found = append(found, p)
+		} else {
+			notFound = append(notFound, b)
+		}
+	}
+	return found, notFound
+}
@@ -0,0 +1,105 @@
+package bookmark
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/lusingander/topi/internal/topi"
+)
+
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "bookmarks.json")
+	s, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	get := &topi.Path{Method: "GET", UriPath: "/pets"}
+	post := &topi.Path{Method: "POST", UriPath: "/pets"}
+
+	tests := []struct {
+		path  *topi.Path
+		added bool
+		want  []*Bookmark
+	}{
+		{get, true, []*Bookmark{{"GET", "/pets"}}},
+		{post, true, []*Bookmark{{"GET", "/pets"}, {"POST", "/pets"}}},
+		{get, false, []*Bookmark{{"POST", "/pets"}}},
+	}
+	for _, test := range tests {
+		added, err := s.Toggle("Pet API", test.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if added != test.added {
+			t.Errorf("got=%v, want=%v", added, test.added)
+		}
+		// reload to check the saved file
+		s, err = Load(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := s.List("Pet API"); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+	if s.Contains("Pet API", get) || !s.Contains("Pet API", post) || s.Contains("other", post) {
+		t.Errorf("unexpected bookmarks: %v", s.specs)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	get := &topi.Path{Method: "GET", UriPath: "/pets", OperationId: "listPets"}
+	doc := topi.NewDocument(&topi.Meta{}, &topi.Info{}, map[string][]*topi.Path{"pets": {get}}, nil, nil)
+	bs := []*Bookmark{{"GET", "/pets"}, {"DELETE", "/pets"}}
+
+	found, notFound := Resolve(doc, bs)
+	if want := []*topi.Path{get}; !reflect.DeepEqual(found, want) {
+		t.Errorf("got=%v, want=%v", found, want)
+	}
+	if want := []*Bookmark{{"DELETE", "/pets"}}; !reflect.DeepEqual(notFound, want) {
+		t.Errorf("got=%v, want=%v", notFound, want)
+	}
+}
+
+func TestStoreSessions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bookmarks.json")
+	s1, _ := Load(path)
+	s2, _ := Load(path)
+	if _, err := s1.Toggle("Pet API", &topi.Path{Method: "GET", UriPath: "/pets"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s2.Toggle("Pet API", &topi.Path{Method: "POST", UriPath: "/pets"}); err != nil {
+		t.Fatal(err)
+	}
+	s, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*Bookmark{{"GET", "/pets"}, {"POST", "/pets"}}
+	if got := s.List("Pet API"); !reflect.DeepEqual(got, want) {
+		t.Errorf("got=%v, want=%v", got, want)
+	}
+}
+
+func TestLoadBroken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bookmarks.json")
+	if err := os.WriteFile(path, []byte("{broken"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := Load(path)
+	if err == nil {
+		t.Errorf("error is expected")
+	}
+	if s == nil || len(s.List("Pet API")) != 0 {
+		t.Fatalf("empty store is expected: %v", s)
+	}
+	// the store can still be used, and replaces the broken file
+	if _, err := s.Toggle("Pet API", &topi.Path{Method: "GET", UriPath: "/pets"}); err != nil {
+		t.Fatal(err)
+	}
+	if s, err = Load(path); err != nil || len(s.List("Pet API")) != 1 {
+		t.Errorf("got=(%v, %v), want one bookmark", s.List("Pet API"), err)
+	}
+}
@@ -0,0 +1,118 @@
+package breaking
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"github.com/lusingander/topi/internal/diff"
+	"github.com/lusingander/topi/internal/topi"
+)
+
+type Violation struct {
+	Rule      string `json:"rule"`
+	Operation string `json:"operation"`
+	Location  string `json:"location"`
+	Message   string `json:"message"`
+}
+
+func Check(base, head *topi.Document) []*Violation {
+	result := diff.Diff(base, head)
+	return violations(result)
+}
+
+func violations(result *diff.Result) []*Violation {
+	ret := make([]*Violation, 0)
+	for _, op := range result.Operations {
+		for _, c := range op.Changes {
+			if !c.Breaking() {
+				continue
+			}
+			v := &Violation{
+				Rule:      c.Rule,
+				Operation: op.Key(),
+				Location:  c.Location,
+				Message:   c.String(),
+			}
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+func WriteText(w io.Writer, vs []*Violation) error {
+	if len(vs) == 0 {
+		_, err := fmt.Fprintln(w, "no breaking changes")
+		return err
+	}
+	for _, v := range vs {
+		if _, err := fmt.Fprintf(w, "[%s] %s: %s\n", v.Rule, v.Operation, v.Message); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "\n%d breaking changes\n", len(vs))
+	return err
+}
+
+func WriteJSON(w io.Writer, vs []*Violation) error {
+	out := struct {
+		Violations []*Violation `json:"violations"`
+	}{
+		Violations: vs,
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(out)
+}
+
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func WriteJUnit(w io.Writer, vs []*Violation) error {
+	suite := junitTestSuite{
+		Name:      "topi breaking",
+		Tests:     len(vs),
+		Failures:  len(vs),
+		TestCases: make([]junitTestCase, len(vs)),
+	}
+	for i, v := range vs {
+		suite.TestCases[i] = junitTestCase{
+			Name:      fmt.Sprintf("%s: %s", v.Rule, v.Location),
+			ClassName: v.Operation,
+			Failure: &junitFailure{
+				Message: v.Message,
+				Type:    v.Rule,
+				Text:    fmt.Sprintf("%s: %s", v.Operation, v.Message),
+			},
+		}
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suite); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,74 @@
+package breaking
+
+import (
+	"bytes"
+	"testing"
+)
+
+var testViolations = []*Violation{
+	{
+		Rule:      "operation-removed",
+		Operation: "DELETE /pets/{petId}",
+		Location:  "operation",
+		Message:   "- operation: DELETE /pets/{petId}",
+	},
+	{
+		Rule:      "max-length-decreased",
+		Operation: "GET /pets/{petId}",
+		Location:  "path parameter petId maxLength",
+		Message:   "~ path parameter petId maxLength: 20 -> 10",
+	},
+}
+
+func TestWriteText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteText(&buf, testViolations); err != nil {
+		t.Fatal(err)
+	}
+	want := `[operation-removed] DELETE /pets/{petId}: - operation: DELETE /pets/{petId}
+[max-length-decreased] GET /pets/{petId}: ~ path parameter petId maxLength: 20 -> 10
+
+2 breaking changes
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got=%v, want=%v", got, want)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, testViolations[1:]); err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+  "violations": [
+    {
+      "rule": "max-length-decreased",
+      "operation": "GET /pets/{petId}",
+      "location": "path parameter petId maxLength",
+      "message": "~ path parameter petId maxLength: 20 -> 10"
+    }
+  ]
+}
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got=%v, want=%v", got, want)
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJUnit(&buf, testViolations[:1]); err != nil {
+		t.Fatal(err)
+	}
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuite name="topi breaking" tests="1" failures="1">
+  <testcase name="operation-removed: operation" classname="DELETE /pets/{petId}">
+    <failure message="- operation: DELETE /pets/{petId}" type="operation-removed">DELETE /pets/{petId}: - operation: DELETE /pets/{petId}</failure>
+  </testcase>
+</testsuite>
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got=%v, want=%v", got, want)
+	}
+}
@@ -0,0 +1,247 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/lusingander/topi/internal/config"
+)
+
+const (
+	indexFileName  = "index.json"
+	objectsDirName = "objects"
+
+	// the cached documents may be fetched with the credentials, so they are readable only by the user
+	dirPerm  = 0700
+	filePerm = 0600
+)
+
+// Entry is a cached remote document.
+type Entry struct {
+	Url          string    `json:"url"`
+	Hash         string    `json:"hash"` // sha256 of the content, the name of the object file
+	Size         int       `json:"size"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	FetchedAt    time.Time `json:"fetched_at"` // last time the content was fetched or validated
+}
+
+// Cache keeps the remote documents in a directory.
+// The contents are stored by their hashes, so the same contents of different URLs are stored once.
+type Cache struct {
+	dir   string
+	index map[string]*Entry // by url
+}
+
+// DefaultDir returns the cache directory for the remote documents.
+func DefaultDir() (string, error) {
+	dir, err := config.CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "remote"), nil
+}
+
+// Open reads the index of the cache. If the directory does not exist, returns an empty cache.
+func Open(dir string) (*Cache, error) {
+	c := &Cache{
+		dir:   dir,
+		index: make(map[string]*Entry),
+	}
+	bs, err := os.ReadFile(filepath.Join(dir, indexFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(bs, &c.index); err != nil {
+		return nil, fmt.Errorf("broken cache index (run `topi cache clear`): %w", err)
+	}
+	return c, nil
+}
+
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// Entries returns the cached documents sorted by url.
+func (c *Cache) Entries() []*Entry {
+	ret := make([]*Entry, 0, len(c.index))
+	for _, e := range c.index {
+		ret = append(ret, e)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Url < ret[j].Url })
+	return ret
+}
+
+// Clear removes all cached documents.
+func (c *Cache) Clear() error {
+	c.index = make(map[string]*Entry)
+	return os.RemoveAll(c.dir)
+}
+
+// Result is the document returned by Fetch.
+type Result struct {
+	Data      []byte
+	Stale     bool      // true if the server could not be reached and the cached content is returned
+	FetchedAt time.Time // time the content was fetched or validated
+}
+
+// Fetch gets the document of the url with the client.
+// If the document is cached, it is validated by ETag and Last-Modified,
+// and the cached content is returned as stale if the server can not be reached or returns a server error.
+func (c *Cache) Fetch(client *http.Client, url string) (*Result, error) {
+	return c.fetch(client, url, true)
+}
+
+// fetch sends the validators of the cached entry only if conditional is true.
+func (c *Cache) fetch(client *http.Client, url string, conditional bool) (*Result, error) {
+	entry := c.index[url]
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if entry != nil && conditional {
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		if entry != nil {
+			return c.stale(entry)
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && entry != nil && conditional:
+		data, err := c.read(entry)
+		if err != nil {
+			// the cached object is missing or broken, get the content again to rewrite the entry
+			resp.Body.Close()
+			return c.fetch(client, url, false)
+		}
+		entry.FetchedAt = time.Now()
+		return &Result{Data: data, FetchedAt: entry.FetchedAt}, c.saveIndex()
+	case resp.StatusCode >= 500 && entry != nil:
+		return c.stale(entry)
+	case resp.StatusCode > 399:
+		return nil, fmt.Errorf("error loading %q: request returned status code %d", url, resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		if entry != nil {
+			return c.stale(entry)
+		}
+		return nil, err
+	}
+	entry = &Entry{
+		Url:          url,
+		Hash:         hash(data),
+		Size:         len(data),
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		FetchedAt:    time.Now(),
+	}
+	if err := c.store(entry, data); err != nil {
+		return nil, err
+	}
+	return &Result{Data: data, FetchedAt: entry.FetchedAt}, nil
+}
+
+func (c *Cache) stale(entry *Entry) (*Result, error) {
+	data, err := c.read(entry)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Data: data, Stale: true, FetchedAt: entry.FetchedAt}, nil
+}
+
+func hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *Cache) objectPath(hash string) string {
+	return filepath.Join(c.dir, objectsDirName, hash)
+}
+
+func (c *Cache) read(entry *Entry) ([]byte, error) {
+	data, err := os.ReadFile(c.objectPath(entry.Hash))
+	if err != nil {
+		return nil, err
+	}
+	if hash(data) != entry.Hash {
+		return nil, fmt.Errorf("broken cache of %s (run `topi cache clear`)", entry.Url)
+	}
+	return data, nil
+}
+
+func (c *Cache) store(entry *Entry, data []byte) error {
+	if err := mkdir(filepath.Join(c.dir, objectsDirName)); err != nil {
+		return err
+	}
+	if err := writeFile(c.objectPath(entry.Hash), data); err != nil {
+		return err
+	}
+	old := c.index[entry.Url]
+	c.index[entry.Url] = entry
+	if old != nil && old.Hash != entry.Hash && !c.referred(old.Hash) {
+		if err := os.Remove(c.objectPath(old.Hash)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return c.saveIndex()
+}
+
+func (c *Cache) referred(hash string) bool {
+	for _, e := range c.index {
+		if e.Hash == hash {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Cache) saveIndex() error {
+	bs, err := json.MarshalIndent(c.index, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := mkdir(c.dir); err != nil {
+		return err
+	}
+	return writeFile(filepath.Join(c.dir, indexFileName), bs)
+}
+
+// mkdir creates the directory, or restricts the permission of the existing one created by the older versions.
+func mkdir(dir string) error {
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return err
+	}
+	return os.Chmod(dir, dirPerm)
+}
+
+// writeFile writes the file, or restricts the permission of the existing one created by the older versions.
+func writeFile(path string, data []byte) error {
+	if err := os.WriteFile(path, data, filePerm); err != nil {
+		return err
+	}
+	return os.Chmod(path, filePerm)
+}
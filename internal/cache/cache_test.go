@@ -0,0 +1,142 @@
+package cache
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFetch(t *testing.T) {
+	content := "openapi: 3.0.3"
+	etag := `"v1"`
+	status := 0 // forced status if not 0
+	conditional := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+		if r.Header.Get("If-None-Match") == etag {
+			conditional++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Write([]byte(content))
+	}))
+	defer ts.Close()
+	dir := filepath.Join(t.TempDir(), "cache")
+	url := ts.URL + "/openapi.yaml"
+
+	fetch := func(wantData string, wantStale bool) {
+		t.Helper()
+		// reopen to check the saved index
+		c, err := Open(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := c.Fetch(ts.Client(), url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(r.Data) != wantData || r.Stale != wantStale {
+			t.Errorf("got=(%s, %v), want=(%s, %v)", r.Data, r.Stale, wantData, wantStale)
+		}
+	}
+
+	fetch("openapi: 3.0.3", false)
+	fetch("openapi: 3.0.3", false)
+	if conditional != 1 {
+		t.Errorf("got=%v, want=%v", conditional, 1)
+	}
+
+	status = http.StatusBadGateway
+	fetch("openapi: 3.0.3", true)
+
+	status = 0
+	content, etag = "openapi: 3.1.0", `"v2"`
+	fetch("openapi: 3.1.0", false)
+	objects, _ := os.ReadDir(filepath.Join(dir, objectsDirName))
+	if len(objects) != 1 {
+		t.Errorf("old object is not removed: %v", objects)
+	}
+
+	// missing or broken object is fetched again even if not modified
+	object := filepath.Join(dir, objectsDirName, hash([]byte(content)))
+	os.Remove(object)
+	fetch("openapi: 3.1.0", false)
+	os.WriteFile(object, []byte("broken"), 0644)
+	fetch("openapi: 3.1.0", false)
+	fetch("openapi: 3.1.0", false)
+	if conditional != 4 {
+		t.Errorf("got=%v, want=%v", conditional, 4)
+	}
+
+	// client errors are not hidden by the cache
+	status = http.StatusUnauthorized
+	c, _ := Open(dir)
+	if _, err := c.Fetch(ts.Client(), url); err == nil {
+		t.Errorf("error is expected")
+	}
+
+	// offline
+	ts.Close()
+	fetch("openapi: 3.1.0", true)
+
+	c, _ = Open(dir)
+	if n := len(c.Entries()); n != 1 {
+		t.Errorf("got=%v, want=%v", n, 1)
+	}
+	if err := c.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	c, _ = Open(dir)
+	if _, err := c.Fetch(ts.Client(), url); err == nil {
+		t.Errorf("error is expected after clear")
+	}
+}
+
+func TestStorePermission(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("openapi: 3.0.3"))
+	}))
+	defer ts.Close()
+	dir := filepath.Join(t.TempDir(), "cache")
+	// created by the older versions
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, indexFileName), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := c.Fetch(ts.Client(), ts.URL+"/openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path string
+		want os.FileMode
+	}{
+		{dir, 0700},
+		{filepath.Join(dir, objectsDirName), 0700},
+		{filepath.Join(dir, indexFileName), 0600},
+		{c.objectPath(hash(r.Data)), 0600},
+	}
+	for _, test := range tests {
+		fi, err := os.Stat(test.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := fi.Mode().Perm(); got != test.want {
+			t.Errorf("got=%v, want=%v (%s)", got, test.want, test.path)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package color
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// ANSI 256 color codes of HTTP methods
+const (
+	HttpMethodGet    = "33"
+	HttpMethodPost   = "35"
+	HttpMethodPut    = "148"
+	HttpMethodPatch  = "218"
+	HttpMethodDelete = "172"
+
+	HttpMethodSelectedGet    = "31"
+	HttpMethodSelectedPost   = "29"
+	HttpMethodSelectedPut    = "112"
+	HttpMethodSelectedPatch  = "181"
+	HttpMethodSelectedDelete = "136"
+
+	HttpMethodDeprecated         = "246"
+	HttpMethodSelectedDeprecated = "243"
+)
+
+// HttpMethod returns ANSI 256 color code of the method, or empty if the method has no color.
+func HttpMethod(method string) string {
+	switch method {
+	case http.MethodGet:
+		return HttpMethodGet
+	case http.MethodPost:
+		return HttpMethodPost
+	case http.MethodPut:
+		return HttpMethodPut
+	case http.MethodPatch:
+		return HttpMethodPatch
+	case http.MethodDelete:
+		return HttpMethodDelete
+	default:
+		return ""
+	}
+}
+
+var ansiSystemColors = []string{
+	"#000000", "#800000", "#008000", "#808000", "#000080", "#800080", "#008080", "#c0c0c0",
+	"#808080", "#ff0000", "#00ff00", "#ffff00", "#0000ff", "#ff00ff", "#00ffff", "#ffffff",
+}
+
+var ansiCubeLevels = []int{0, 95, 135, 175, 215, 255}
+
+// Hex converts ANSI 256 color code to hex color code (#rrggbb).
+func Hex(code string) (string, error) {
+	n, err := strconv.Atoi(code)
+	if err != nil || n < 0 || n > 255 {
+		return "", fmt.Errorf("invalid ANSI 256 color code: %s", code)
+	}
+	switch {
+	case n < 16:
+		return ansiSystemColors[n], nil
+	case n < 232:
+		n -= 16
+		r, g, b := ansiCubeLevels[n/36], ansiCubeLevels[(n/6)%6], ansiCubeLevels[n%6]
+		return fmt.Sprintf("#%02x%02x%02x", r, g, b), nil
+	default:
+		v := 8 + (n-232)*10
+		return fmt.Sprintf("#%02x%02x%02x", v, v, v), nil
+	}
+}
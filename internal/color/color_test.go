@@ -0,0 +1,33 @@
+package color
+
+import "testing"
+
+func TestHex(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"0", "#000000"},
+		{"15", "#ffffff"},
+		{"16", "#000000"},
+		{"33", "#0087ff"},
+		{"148", "#afd700"},
+		{"231", "#ffffff"},
+		{"232", "#080808"},
+		{"246", "#949494"},
+	}
+	for _, test := range tests {
+		got, err := Hex(test.code)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if got != test.want {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+	for _, code := range []string{"", "256", "-1", "abc"} {
+		if _, err := Hex(code); err == nil {
+			t.Errorf("error expected: %v", code)
+		}
+	}
+}
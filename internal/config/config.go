@@ -0,0 +1,256 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/lusingander/topi/internal/oauth"
+	"github.com/lusingander/topi/internal/remote"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	StartPageMenu  = "menu"
+	StartPageInfo  = "info"
+	StartPageTags  = "tags"
+	StartPagePaths = "paths"
+)
+
+type Config struct {
+	Theme             ThemeConfig   `yaml:"theme"`
+	Keymap            KeymapConfig  `yaml:"keymap"`
+	StartPage         string        `yaml:"start_page"`
+	MarkdownWrapWidth int           `yaml:"markdown_wrap_width"`
+	DefaultServer     string        `yaml:"default_server"`
+	HideDeprecated    bool          `yaml:"hide_deprecated"`
+	RecentLimit       int           `yaml:"recent_limit"`
+	Remote            remote.Config `yaml:"remote"`
+
+	// OAuth is the clients to acquire the tokens by the key of the security scheme.
+	OAuth map[string]oauth.Config `yaml:"oauth"`
+}
+
+// ThemeConfig overrides the colors of the UI.
+// Each value is an ANSI 256 color code ("0" to "255") or a hex color ("#rrggbb").
+// Empty values are not overridden.
+type ThemeConfig struct {
+	Selected        string `yaml:"selected"`
+	Primary         string `yaml:"primary"`
+	Link            string `yaml:"link"`
+	SelectedLinkFg  string `yaml:"selected_link_fg"`
+	SelectedLinkBg  string `yaml:"selected_link_bg"`
+	Muted           string `yaml:"muted"`
+	Separator       string `yaml:"separator"`
+	Indent          string `yaml:"indent"`
+	Header          string `yaml:"header"`
+	StatusbarBg     string `yaml:"statusbar_bg"`
+	StatusbarInfoFg string `yaml:"statusbar_info_fg"`
+	StatusbarInfoBg string `yaml:"statusbar_info_bg"`
+	ListTitle       string `yaml:"list_title"`
+	ListDesc        string `yaml:"list_desc"`
+	Key             string `yaml:"key"`
+	Value           string `yaml:"value"`
+	Success         string `yaml:"success"`
+	Error           string `yaml:"error"`
+	Warning         string `yaml:"warning"`
+	Info            string `yaml:"info"`
+	Alert           string `yaml:"alert"`
+	CodeFg          string `yaml:"code_fg"`
+	CodeBg          string `yaml:"code_bg"`
+
+	MethodGet                string `yaml:"method_get"`
+	MethodPost               string `yaml:"method_post"`
+	MethodPut                string `yaml:"method_put"`
+	MethodPatch              string `yaml:"method_patch"`
+	MethodDelete             string `yaml:"method_delete"`
+	MethodSelectedGet        string `yaml:"method_selected_get"`
+	MethodSelectedPost       string `yaml:"method_selected_post"`
+	MethodSelectedPut        string `yaml:"method_selected_put"`
+	MethodSelectedPatch      string `yaml:"method_selected_patch"`
+	MethodSelectedDelete     string `yaml:"method_selected_delete"`
+	MethodDeprecated         string `yaml:"method_deprecated"`
+	MethodSelectedDeprecated string `yaml:"method_selected_deprecated"`
+
+	MarkdownLinkText  string `yaml:"markdown_link_text"`
+	MarkdownCodeBlock string `yaml:"markdown_code_block"`
+	MarkdownRule      string `yaml:"markdown_rule"`
+}
+
+// KeymapConfig overrides the keys bound to the actions.
+// Each value is a list of keys in the format of bubbletea (e.g. "ctrl+c", "enter", "x").
+// Empty values are not overridden.
+type KeymapConfig struct {
+	Quit        []string `yaml:"quit"`
+	Help        []string `yaml:"help"`
+	SpecMenu    []string `yaml:"spec_menu"`
+	CycleTheme  []string `yaml:"cycle_theme"`
+	Back        []string `yaml:"back"`
+	Forward     []string `yaml:"forward"`
+	JumpBack    []string `yaml:"jump_back"`
+	JumpForward []string `yaml:"jump_forward"`
+	Select      []string `yaml:"select"`
+	NextItem    []string `yaml:"next_item"`
+	PrevItem    []string `yaml:"prev_item"`
+	OpenBrowser []string `yaml:"open_browser"`
+	Toggle      []string `yaml:"toggle"`
+	Bookmark    []string `yaml:"bookmark"`
+	Edit        []string `yaml:"edit"`
+	Raw         []string `yaml:"raw"`
+	Token       []string `yaml:"token"`
+}
+
+// DefaultPath returns $XDG_CONFIG_HOME/topi/config.yaml, or ~/.config/topi/config.yaml if XDG_CONFIG_HOME is not set.
+func DefaultPath() (string, error) {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "topi", "config.yaml"), nil
+}
+
+// DataDir returns $XDG_DATA_HOME/topi, or ~/.local/share/topi if XDG_DATA_HOME is not set.
+func DataDir() (string, error) {
+	dir := os.Getenv("XDG_DATA_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dir, "topi"), nil
+}
+
+// CacheDir returns $XDG_CACHE_HOME/topi, or ~/.cache/topi if XDG_CACHE_HOME is not set.
+func CacheDir() (string, error) {
+	dir := os.Getenv("XDG_CACHE_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".cache")
+	}
+	return filepath.Join(dir, "topi"), nil
+}
+
+// Load reads the config file. If path is empty, the file at DefaultPath is read if it exists.
+func Load(path string) (*Config, error) {
+	if path == "" {
+		p, err := DefaultPath()
+		if err != nil {
+			return &Config{}, nil
+		}
+		cfg, err := load(p)
+		if errors.Is(err, fs.ErrNotExist) {
+			return &Config{}, nil
+		}
+		return cfg, err
+	}
+	return load(path)
+}
+
+func load(path string) (*Config, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := parse(bs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+func parse(bs []byte) (*Config, error) {
+	cfg := &Config{}
+	dec := yaml.NewDecoder(bytes.NewReader(bs))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+var hexColorRegexp = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+func validColor(s string) bool {
+	if hexColorRegexp.MatchString(s) {
+		return true
+	}
+	n, err := strconv.Atoi(s)
+	return err == nil && 0 <= n && n <= 255
+}
+
+func (c *Config) validate() error {
+	for _, f := range yamlFields(c.Theme) {
+		if color := f.value.String(); color != "" && !validColor(color) {
+			return fmt.Errorf("invalid color for theme.%s: %s", f.name, color)
+		}
+	}
+	for _, f := range yamlFields(c.Keymap) {
+		for _, key := range f.value.Interface().([]string) {
+			if key == "" {
+				return fmt.Errorf("empty key for keymap.%s", f.name)
+			}
+		}
+	}
+	switch c.StartPage {
+	case "", StartPageMenu, StartPageInfo, StartPageTags, StartPagePaths:
+	default:
+		return fmt.Errorf("invalid start_page: %s", c.StartPage)
+	}
+	if c.MarkdownWrapWidth < 0 {
+		return fmt.Errorf("invalid markdown_wrap_width: %d", c.MarkdownWrapWidth)
+	}
+	if c.RecentLimit < 0 {
+		return fmt.Errorf("invalid recent_limit: %d", c.RecentLimit)
+	}
+	if err := c.Remote.Validate(); err != nil {
+		return fmt.Errorf("remote: %w", err)
+	}
+	keys := make([]string, 0, len(c.OAuth))
+	for k := range c.OAuth {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		oc := c.OAuth[k]
+		if err := oc.Validate(); err != nil {
+			return fmt.Errorf("oauth.%s: %w", k, err)
+		}
+	}
+	return nil
+}
+
+type yamlField struct {
+	name  string
+	value reflect.Value
+}
+
+// yamlFields returns the fields of the struct with the yaml field names in declaration order.
+func yamlFields(v interface{}) []yamlField {
+	rv := reflect.ValueOf(v)
+	rt := rv.Type()
+	ret := make([]yamlField, rt.NumField())
+	for i := range ret {
+		ret[i] = yamlField{rt.Field(i).Tag.Get("yaml"), rv.Field(i)}
+	}
+	return ret
+}
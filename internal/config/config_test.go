@@ -0,0 +1,159 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lusingander/topi/internal/oauth"
+	"github.com/lusingander/topi/internal/remote"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		yaml string
+		want *Config
+	}{
+		{
+			yaml: "",
+			want: &Config{},
+		},
+		{
+			yaml: `
+theme:
+  primary: "33"
+  statusbar_bg: "#eeeeee"
+keymap:
+  quit: [q, ctrl+c]
+start_page: paths
+markdown_wrap_width: 100
+default_server: https://staging.example.com
+hide_deprecated: true
+`,
+			want: &Config{
+				Theme:             ThemeConfig{Primary: "33", StatusbarBg: "#eeeeee"},
+				Keymap:            KeymapConfig{Quit: []string{"q", "ctrl+c"}},
+				StartPage:         StartPagePaths,
+				MarkdownWrapWidth: 100,
+				DefaultServer:     "https://staging.example.com",
+				HideDeprecated:    true,
+			},
+		},
+		{
+			yaml: `
+remote:
+  headers:
+    X-Api-Key: ${API_KEY}
+  bearer_token: token
+  ca_cert: /etc/ssl/internal-ca.pem
+  timeout: 30s
+`,
+			want: &Config{
+				Remote: remote.Config{
+					Headers:     map[string]string{"X-Api-Key": "${API_KEY}"},
+					BearerToken: "token",
+					CACert:      "/etc/ssl/internal-ca.pem",
+					Timeout:     30 * time.Second,
+				},
+			},
+		},
+		{
+			yaml: `
+oauth:
+  petstore_auth:
+    grant: client_credentials
+    client_id: topi
+    client_secret: ${CLIENT_SECRET}
+    scopes: [read:pets]
+  oidc:
+    client_id: topi
+    redirect_port: 8080
+`,
+			want: &Config{
+				OAuth: map[string]oauth.Config{
+					"petstore_auth": {
+						Grant:        oauth.GrantClientCredentials,
+						ClientID:     "topi",
+						ClientSecret: "${CLIENT_SECRET}",
+						Scopes:       []string{"read:pets"},
+					},
+					"oidc": {
+						ClientID:     "topi",
+						RedirectPort: 8080,
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		got, err := parse([]byte(test.yaml))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+}
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		yaml string
+		want string
+	}{
+		{
+			yaml: "unknown: true",
+			want: "yaml: unmarshal errors:\n  line 1: field unknown not found in type config.Config",
+		},
+		{
+			yaml: "theme:\n  primery: \"33\"",
+			want: "yaml: unmarshal errors:\n  line 2: field primery not found in type config.ThemeConfig",
+		},
+		{
+			yaml: "keymap:\n  exit: [q]",
+			want: "yaml: unmarshal errors:\n  line 2: field exit not found in type config.KeymapConfig",
+		},
+		{
+			yaml: "theme:\n  primary: green",
+			want: "invalid color for theme.primary: green",
+		},
+		{
+			yaml: "theme:\n  muted: \"256\"",
+			want: "invalid color for theme.muted: 256",
+		},
+		{
+			yaml: "keymap:\n  quit: [\"\"]",
+			want: "empty key for keymap.quit",
+		},
+		{
+			yaml: "start_page: operations",
+			want: "invalid start_page: operations",
+		},
+		{
+			yaml: "markdown_wrap_width: -1",
+			want: "invalid markdown_wrap_width: -1",
+		},
+		{
+			yaml: "recent_limit: -1",
+			want: "invalid recent_limit: -1",
+		},
+		{
+			yaml: "remote:\n  basic_auth: user",
+			want: "remote: invalid basic_auth (must be \"username:password\")",
+		},
+		{
+			yaml: "oauth:\n  petstore_auth:\n    grant: implicit\n    client_id: topi",
+			want: "oauth.petstore_auth: invalid grant: implicit",
+		},
+	}
+	for _, test := range tests {
+		_, err := parse([]byte(test.yaml))
+		if err == nil {
+			t.Errorf("error is expected: %s", test.yaml)
+			continue
+		}
+		if got := err.Error(); got != test.want {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+}
@@ -0,0 +1,627 @@
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lusingander/topi/internal/topi"
+)
+
+type ChangeType int
+
+const (
+	Added ChangeType = iota
+	Removed
+	Changed
+)
+
+func (t ChangeType) String() string {
+	switch t {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Changed:
+		return "changed"
+	default:
+		return ""
+	}
+}
+
+func (t ChangeType) Symbol() string {
+	switch t {
+	case Added:
+		return "+"
+	case Removed:
+		return "-"
+	case Changed:
+		return "~"
+	default:
+		return ""
+	}
+}
+
+// Rule IDs of the backward-incompatible changes.
+// These IDs are part of the machine-readable output, so do not change them.
+const (
+	RuleOperationRemoved           = "operation-removed"
+	RuleParameterBecameRequired    = "parameter-became-required"
+	RuleRequiredParameterAdded     = "required-parameter-added"
+	RuleRequestBodyBecameRequired  = "request-body-became-required"
+	RuleRequiredRequestBodyAdded   = "required-request-body-added"
+	RulePropertyBecameRequired     = "property-became-required"
+	RuleRequiredPropertyAdded      = "required-property-added"
+	RulePropertyRemoved            = "property-removed"
+	RuleTypeChanged                = "type-changed"
+	RuleEnumNarrowed               = "enum-narrowed"
+	RuleEnumWidened                = "enum-widened"
+	RuleMediaTypeRemoved           = "media-type-removed"
+	RuleSuccessResponseRemoved     = "success-response-removed"
+	RuleSecurityRequirementAdded   = "security-requirement-added"
+	RuleSecurityAlternativeRemoved = "security-alternative-removed"
+	RuleMaxLengthDecreased         = "max-length-decreased"
+	RuleMinLengthIncreased         = "min-length-increased"
+	RuleMaxDecreased               = "max-decreased"
+	RuleMinIncreased               = "min-increased"
+	RuleMaxItemsDecreased          = "max-items-decreased"
+	RuleMinItemsIncreased          = "min-items-increased"
+)
+
+type Change struct {
+	Type     ChangeType
+	Location string
+	Before   string
+	After    string
+	Rule     string // empty if the change is not breaking
+}
+
+func (c *Change) Breaking() bool {
+	return c.Rule != ""
+}
+
+func (c *Change) String() string {
+	var s strings.Builder
+	s.WriteString(fmt.Sprintf("%s %s", c.Type.Symbol(), c.Location))
+	switch c.Type {
+	case Added:
+		if c.After != "" {
+			s.WriteString(fmt.Sprintf(": %s", c.After))
+		}
+	case Removed:
+		if c.Before != "" {
+			s.WriteString(fmt.Sprintf(": %s", c.Before))
+		}
+	case Changed:
+		s.WriteString(fmt.Sprintf(": %s -> %s", c.Before, c.After))
+	}
+	return s.String()
+}
+
+type OperationDiff struct {
+	Method  string
+	UriPath string
+	Type    ChangeType
+	Old     *topi.Path
+	New     *topi.Path
+	Changes []*Change
+}
+
+func (d *OperationDiff) Key() string {
+	return operationKey(d.Method, d.UriPath)
+}
+
+func (d *OperationDiff) Breaking() bool {
+	for _, c := range d.Changes {
+		if c.Breaking() {
+			return true
+		}
+	}
+	return false
+}
+
+type Result struct {
+	Old        *topi.Document
+	New        *topi.Document
+	Operations []*OperationDiff
+}
+
+func (r *Result) Breaking() bool {
+	for _, op := range r.Operations {
+		if op.Breaking() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Result) FindOperation(key string) *OperationDiff {
+	for _, op := range r.Operations {
+		if op.Key() == key {
+			return op
+		}
+	}
+	return nil
+}
+
+func Diff(old, new *topi.Document) *Result {
+	oldPaths := collectPaths(old)
+	newPaths := collectPaths(new)
+
+	ops := make([]*OperationDiff, 0)
+	for k, o := range oldPaths {
+		n, ok := newPaths[k]
+		if !ok {
+			d := &OperationDiff{
+				Method:  o.Method,
+				UriPath: o.UriPath,
+				Type:    Removed,
+				Old:     o,
+				Changes: []*Change{
+					{Type: Removed, Location: "operation", Before: k, Rule: RuleOperationRemoved},
+				},
+			}
+			ops = append(ops, d)
+			continue
+		}
+		changes := compareOperation(o, n)
+		if len(changes) > 0 {
+			d := &OperationDiff{
+				Method:  o.Method,
+				UriPath: o.UriPath,
+				Type:    Changed,
+				Old:     o,
+				New:     n,
+				Changes: changes,
+			}
+			ops = append(ops, d)
+		}
+	}
+	for k, n := range newPaths {
+		if _, ok := oldPaths[k]; !ok {
+			d := &OperationDiff{
+				Method:  n.Method,
+				UriPath: n.UriPath,
+				Type:    Added,
+				New:     n,
+				Changes: []*Change{
+					{Type: Added, Location: "operation", After: k},
+				},
+			}
+			ops = append(ops, d)
+		}
+	}
+	sortOperations(ops)
+	return &Result{
+		Old:        old,
+		New:        new,
+		Operations: ops,
+	}
+}
+
+func operationKey(method, uriPath string) string {
+	return fmt.Sprintf("%s %s", method, uriPath)
+}
+
+func collectPaths(doc *topi.Document) map[string]*topi.Path {
+	ret := make(map[string]*topi.Path)
+	for _, paths := range doc.TagPathMap {
+		for _, path := range paths {
+			ret[operationKey(path.Method, path.UriPath)] = path
+		}
+	}
+	return ret
+}
+
+func sortOperations(ops []*OperationDiff) {
+	sort.Slice(ops, func(i, j int) bool {
+		if ops[i].UriPath != ops[j].UriPath {
+			return ops[i].UriPath < ops[j].UriPath
+		}
+		return ops[i].Method < ops[j].Method
+	})
+}
+
+func compareOperation(o, n *topi.Path) []*Change {
+	changes := make([]*Change, 0)
+	if !o.Deprecated && n.Deprecated {
+		changes = append(changes, &Change{Type: Changed, Location: "deprecated", Before: "false", After: "true"})
+	}
+	changes = append(changes, compareParameters(allParameters(o), allParameters(n))...)
+	changes = append(changes, compareRequestBody(o.RequestBody, n.RequestBody)...)
+	changes = append(changes, compareResponses(o.Responses, n.Responses)...)
+	changes = append(changes, compareSecurity(o.Security, n.Security)...)
+	return changes
+}
+
+func allParameters(p *topi.Path) []*topi.Parameter {
+	ret := make([]*topi.Parameter, 0)
+	ret = append(ret, p.PathParameters...)
+	ret = append(ret, p.QueryParameters...)
+	ret = append(ret, p.HeaderParameters...)
+	ret = append(ret, p.CookieParameters...)
+	return ret
+}
+
+func parameterLocation(p *topi.Parameter) string {
+	return fmt.Sprintf("%s parameter %s", p.In, p.Name)
+}
+
+func compareParameters(olds, news []*topi.Parameter) []*Change {
+	changes := make([]*Change, 0)
+	newMap := make(map[string]*topi.Parameter)
+	for _, p := range news {
+		newMap[parameterLocation(p)] = p
+	}
+	oldMap := make(map[string]*topi.Parameter)
+	for _, o := range olds {
+		loc := parameterLocation(o)
+		oldMap[loc] = o
+		n, ok := newMap[loc]
+		if !ok {
+			changes = append(changes, &Change{Type: Removed, Location: loc, Before: schemaString(o.Schema)})
+			continue
+		}
+		if !o.Required && n.Required {
+			changes = append(changes, &Change{Type: Changed, Location: loc + " required", Before: "false", After: "true", Rule: RuleParameterBecameRequired})
+		}
+		if o.Required && !n.Required {
+			changes = append(changes, &Change{Type: Changed, Location: loc + " required", Before: "true", After: "false"})
+		}
+		changes = append(changes, compareSchema(loc, o.Schema, n.Schema, true)...)
+	}
+	for _, n := range news {
+		loc := parameterLocation(n)
+		if _, ok := oldMap[loc]; !ok {
+			changes = append(changes, &Change{Type: Added, Location: loc, After: schemaString(n.Schema), Rule: ruleIf(n.Required, RuleRequiredParameterAdded)})
+		}
+	}
+	return changes
+}
+
+func compareRequestBody(o, n *topi.RequestBody) []*Change {
+	loc := "request body"
+	if o == nil && n == nil {
+		return nil
+	}
+	if o == nil {
+		return []*Change{{Type: Added, Location: loc, Rule: ruleIf(n.Required, RuleRequiredRequestBodyAdded)}}
+	}
+	if n == nil {
+		return []*Change{{Type: Removed, Location: loc}}
+	}
+	changes := make([]*Change, 0)
+	if !o.Required && n.Required {
+		changes = append(changes, &Change{Type: Changed, Location: loc + " required", Before: "false", After: "true", Rule: RuleRequestBodyBecameRequired})
+	}
+	changes = append(changes, compareContent(loc, o.Conetnt, n.Conetnt, true)...)
+	return changes
+}
+
+func compareResponses(olds, news []*topi.Response) []*Change {
+	changes := make([]*Change, 0)
+	newMap := make(map[string]*topi.Response)
+	for _, r := range news {
+		newMap[r.StatusCode] = r
+	}
+	oldMap := make(map[string]*topi.Response)
+	for _, o := range olds {
+		oldMap[o.StatusCode] = o
+		loc := fmt.Sprintf("response %s", o.StatusCode)
+		n, ok := newMap[o.StatusCode]
+		if !ok {
+			changes = append(changes, &Change{Type: Removed, Location: loc, Rule: ruleIf(o.Success(), RuleSuccessResponseRemoved)})
+			continue
+		}
+		changes = append(changes, compareContent(loc, o.Conetnt, n.Conetnt, false)...)
+	}
+	for _, n := range news {
+		if _, ok := oldMap[n.StatusCode]; !ok {
+			loc := fmt.Sprintf("response %s", n.StatusCode)
+			changes = append(changes, &Change{Type: Added, Location: loc})
+		}
+	}
+	return changes
+}
+
+func compareContent(loc string, olds, news []*topi.MediaTypeContent, request bool) []*Change {
+	changes := make([]*Change, 0)
+	newMap := make(map[string]*topi.MediaTypeContent)
+	for _, c := range news {
+		newMap[c.MediaType] = c
+	}
+	oldMap := make(map[string]*topi.MediaTypeContent)
+	for _, o := range sortedContent(olds) {
+		oldMap[o.MediaType] = o
+		l := fmt.Sprintf("%s [%s]", loc, o.MediaType)
+		n, ok := newMap[o.MediaType]
+		if !ok {
+			changes = append(changes, &Change{Type: Removed, Location: l, Rule: RuleMediaTypeRemoved})
+			continue
+		}
+		changes = append(changes, compareSchema(l, o.Schema, n.Schema, request)...)
+	}
+	for _, n := range sortedContent(news) {
+		if _, ok := oldMap[n.MediaType]; !ok {
+			l := fmt.Sprintf("%s [%s]", loc, n.MediaType)
+			changes = append(changes, &Change{Type: Added, Location: l})
+		}
+	}
+	return changes
+}
+
+func sortedContent(cs []*topi.MediaTypeContent) []*topi.MediaTypeContent {
+	ret := make([]*topi.MediaTypeContent, len(cs))
+	copy(ret, cs)
+	sort.Slice(ret, func(i, j int) bool { return ret[i].MediaType < ret[j].MediaType })
+	return ret
+}
+
+func compareSchema(loc string, o, n *topi.Schema, request bool) []*Change {
+	if o == nil || n == nil {
+		return nil
+	}
+	if len(o.AllOf) > 0 {
+		o = o.MergedAllOf()
+	}
+	if len(n.AllOf) > 0 {
+		n = n.MergedAllOf()
+	}
+
+	changes := make([]*Change, 0)
+	if o.Type != n.Type {
+		changes = append(changes, &Change{Type: Changed, Location: loc + " type", Before: o.Type, After: n.Type, Rule: RuleTypeChanged})
+		return changes
+	}
+	if o.Format != n.Format {
+		changes = append(changes, &Change{Type: Changed, Location: loc + " format", Before: o.Format, After: n.Format})
+	}
+	changes = append(changes, compareEnum(loc, o.Enum, n.Enum, request)...)
+	changes = append(changes, compareConstraints(loc, o, n, request)...)
+
+	switch o.Type {
+	case "object":
+		changes = append(changes, compareProperties(loc, o, n, request)...)
+	case "array":
+		if o.Items != nil && n.Items != nil {
+			changes = append(changes, compareSchema(loc+"[]", o.Items, n.Items, request)...)
+		}
+	}
+	return changes
+}
+
+// compareConstraints reports the changed constraints.
+// Tightened constraints are breaking only for the request, since the response may always be narrower.
+func compareConstraints(loc string, o, n *topi.Schema, request bool) []*Change {
+	changes := make([]*Change, 0)
+	add := func(name, before, after string, tightened bool, rule string) {
+		if before == after {
+			return
+		}
+		c := &Change{
+			Type:     Changed,
+			Location: fmt.Sprintf("%s %s", loc, name),
+			Before:   before,
+			After:    after,
+			Rule:     ruleIf(request && tightened, rule),
+		}
+		changes = append(changes, c)
+	}
+	add("maxLength", uintPtrString(o.MaxLength), uintPtrString(n.MaxLength), upperTightened(uintPtrFloat(o.MaxLength), uintPtrFloat(n.MaxLength)), RuleMaxLengthDecreased)
+	add("minLength", uintString(o.MinLength), uintString(n.MinLength), n.MinLength > o.MinLength, RuleMinLengthIncreased)
+	add("maximum", floatPtrString(o.Max), floatPtrString(n.Max), upperTightened(o.Max, n.Max), RuleMaxDecreased)
+	add("minimum", floatPtrString(o.Min), floatPtrString(n.Min), lowerTightened(o.Min, n.Min), RuleMinIncreased)
+	add("maxItems", uintPtrString(o.MaxItems), uintPtrString(n.MaxItems), upperTightened(uintPtrFloat(o.MaxItems), uintPtrFloat(n.MaxItems)), RuleMaxItemsDecreased)
+	add("minItems", uintString(o.MinItems), uintString(n.MinItems), n.MinItems > o.MinItems, RuleMinItemsIncreased)
+	return changes
+}
+
+func upperTightened(o, n *float64) bool {
+	if n == nil {
+		return false
+	}
+	return o == nil || *n < *o
+}
+
+func lowerTightened(o, n *float64) bool {
+	if n == nil {
+		return false
+	}
+	return o == nil || *n > *o
+}
+
+func uintPtrFloat(v *uint64) *float64 {
+	if v == nil {
+		return nil
+	}
+	f := float64(*v)
+	return &f
+}
+
+func uintPtrString(v *uint64) string {
+	if v == nil {
+		return "none"
+	}
+	return uintString(*v)
+}
+
+func uintString(v uint64) string {
+	return fmt.Sprintf("%d", v)
+}
+
+func floatPtrString(v *float64) string {
+	if v == nil {
+		return "none"
+	}
+	return fmt.Sprintf("%g", *v)
+}
+
+func ruleIf(cond bool, rule string) string {
+	if cond {
+		return rule
+	}
+	return ""
+}
+
+// compareEnum reports the changed enum values.
+// Narrowed enum breaks the request, and widened enum breaks the response since clients may not handle new values.
+func compareEnum(loc string, olds, news []interface{}, request bool) []*Change {
+	if len(olds) == 0 && len(news) == 0 {
+		return nil
+	}
+	removed := subtractValues(olds, news)
+	added := subtractValues(news, olds)
+	if len(removed) == 0 && len(added) == 0 {
+		return nil
+	}
+	c := &Change{Type: Changed, Location: loc + " enum", Before: valuesString(olds), After: valuesString(news)}
+	if request {
+		narrowed := len(removed) > 0 || len(olds) == 0
+		c.Rule = ruleIf(narrowed, RuleEnumNarrowed)
+	} else {
+		widened := len(added) > 0 || len(news) == 0
+		c.Rule = ruleIf(widened, RuleEnumWidened)
+	}
+	return []*Change{c}
+}
+
+func subtractValues(vs1, vs2 []interface{}) []interface{} {
+	ret := make([]interface{}, 0)
+	for _, v1 := range vs1 {
+		found := false
+		for _, v2 := range vs2 {
+			if fmt.Sprintf("%v", v1) == fmt.Sprintf("%v", v2) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			ret = append(ret, v1)
+		}
+	}
+	return ret
+}
+
+func valuesString(vs []interface{}) string {
+	ss := make([]string, len(vs))
+	for i, v := range vs {
+		ss[i] = fmt.Sprintf("%v", v)
+	}
+	return fmt.Sprintf("[%s]", strings.Join(ss, ", "))
+}
+
+func compareProperties(loc string, o, n *topi.Schema, request bool) []*Change {
+	changes := make([]*Change, 0)
+	for _, name := range sortedKeys(o.Properties) {
+		l := fmt.Sprintf("%s .%s", loc, name)
+		op := o.Properties[name]
+		np, ok := n.Properties[name]
+		if !ok {
+			// clients may still send the removed property, which only breaks them if it was required
+			removedBreaking := !request || containsString(name, o.Required)
+			changes = append(changes, &Change{Type: Removed, Location: l, Before: schemaString(op), Rule: ruleIf(removedBreaking, RulePropertyRemoved)})
+			continue
+		}
+		if request && !containsString(name, o.Required) && containsString(name, n.Required) {
+			changes = append(changes, &Change{Type: Changed, Location: l + " required", Before: "false", After: "true", Rule: RulePropertyBecameRequired})
+		}
+		changes = append(changes, compareSchema(l, op, np, request)...)
+	}
+	for _, name := range sortedKeys(n.Properties) {
+		if _, ok := o.Properties[name]; !ok {
+			l := fmt.Sprintf("%s .%s", loc, name)
+			required := containsString(name, n.Required)
+			changes = append(changes, &Change{Type: Added, Location: l, After: schemaString(n.Properties[name]), Rule: ruleIf(request && required, RuleRequiredPropertyAdded)})
+		}
+	}
+	return changes
+}
+
+func compareSecurity(olds, news []*topi.SecurityRequirement) []*Change {
+	o := securityStrings(olds)
+	n := securityStrings(news)
+	if strings.Join(o, " or ") == strings.Join(n, " or ") {
+		return nil
+	}
+	c := &Change{
+		Type:     Changed,
+		Location: "security",
+		Before:   securityString(o),
+		After:    securityString(n),
+	}
+	switch {
+	case len(o) == 0 || len(subtractStrings(n, o)) > 0:
+		c.Rule = RuleSecurityRequirementAdded
+	case len(n) > 0:
+		// clients using the removed alternatives can no longer be authorized
+		c.Rule = RuleSecurityAlternativeRemoved
+	}
+	return []*Change{c}
+}
+
+func securityStrings(rs []*topi.SecurityRequirement) []string {
+	ret := make([]string, 0)
+	for _, r := range rs {
+		if len(r.Schemes) == 0 {
+			ret = append(ret, "(anonymous)")
+			continue
+		}
+		schemes := make([]string, len(r.Schemes))
+		for i, s := range r.Schemes {
+			if len(s.Scopes) > 0 {
+				schemes[i] = fmt.Sprintf("%s(%s)", s.Key, strings.Join(s.Scopes, ","))
+			} else {
+				schemes[i] = s.Key
+			}
+		}
+		sort.Strings(schemes)
+		ret = append(ret, strings.Join(schemes, " + "))
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+func securityString(ss []string) string {
+	if len(ss) == 0 {
+		return "none"
+	}
+	return strings.Join(ss, " or ")
+}
+
+func subtractStrings(ss1, ss2 []string) []string {
+	ret := make([]string, 0)
+	for _, s := range ss1 {
+		if !containsString(s, ss2) {
+			ret = append(ret, s)
+		}
+	}
+	return ret
+}
+
+func schemaString(sc *topi.Schema) string {
+	if sc == nil {
+		return ""
+	}
+	if sc.Type == "array" && sc.Items != nil {
+		return fmt.Sprintf("array of %s", sc.Items.Type)
+	}
+	return sc.Type
+}
+
+func sortedKeys(m map[string]*topi.Schema) []string {
+	ret := make([]string, 0, len(m))
+	for k := range m {
+		ret = append(ret, k)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+func containsString(v string, ss []string) bool {
+	for _, s := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
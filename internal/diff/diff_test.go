@@ -0,0 +1,261 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lusingander/topi/internal/topi"
+)
+
+func TestCompareParameters(t *testing.T) {
+	olds := []*topi.Parameter{
+		{Name: "limit", In: "query", Schema: &topi.Schema{Type: "integer"}},
+		{Name: "offset", In: "query", Schema: &topi.Schema{Type: "integer"}},
+		{Name: "id", In: "path", Required: true, Schema: &topi.Schema{Type: "string"}},
+	}
+	news := []*topi.Parameter{
+		{Name: "limit", In: "query", Required: true, Schema: &topi.Schema{Type: "integer"}},
+		{Name: "id", In: "path", Required: true, Schema: &topi.Schema{Type: "integer"}},
+		{Name: "sort", In: "query", Schema: &topi.Schema{Type: "string"}},
+		{Name: "X-Trace", In: "header", Required: true, Schema: &topi.Schema{Type: "string"}},
+	}
+	want := []*Change{
+		{Type: Changed, Location: "query parameter limit required", Before: "false", After: "true", Rule: RuleParameterBecameRequired},
+		{Type: Removed, Location: "query parameter offset", Before: "integer"},
+		{Type: Changed, Location: "path parameter id type", Before: "string", After: "integer", Rule: RuleTypeChanged},
+		{Type: Added, Location: "query parameter sort", After: "string"},
+		{Type: Added, Location: "header parameter X-Trace", After: "string", Rule: RuleRequiredParameterAdded},
+	}
+	got := compareParameters(olds, news)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got=%v, want=%v", got, want)
+	}
+}
+
+func TestCompareEnum(t *testing.T) {
+	tests := []struct {
+		olds, news []interface{}
+		request    bool
+		want       []*Change
+	}{
+		{
+			olds:    []interface{}{"a", "b"},
+			news:    []interface{}{"a", "b"},
+			request: true,
+			want:    nil,
+		},
+		{
+			olds:    []interface{}{"a", "b"},
+			news:    []interface{}{"a", "b", "c"},
+			request: true,
+			want: []*Change{
+				{Type: Changed, Location: "x enum", Before: "[a, b]", After: "[a, b, c]"},
+			},
+		},
+		{
+			olds:    []interface{}{"a", "b"},
+			news:    []interface{}{"a"},
+			request: true,
+			want: []*Change{
+				{Type: Changed, Location: "x enum", Before: "[a, b]", After: "[a]", Rule: RuleEnumNarrowed},
+			},
+		},
+		{
+			olds:    nil,
+			news:    []interface{}{"a"},
+			request: true,
+			want: []*Change{
+				{Type: Changed, Location: "x enum", Before: "[]", After: "[a]", Rule: RuleEnumNarrowed},
+			},
+		},
+		{
+			olds:    []interface{}{"a", "b"},
+			news:    []interface{}{"a"},
+			request: false,
+			want: []*Change{
+				{Type: Changed, Location: "x enum", Before: "[a, b]", After: "[a]"},
+			},
+		},
+		{
+			olds:    []interface{}{"a", "b"},
+			news:    []interface{}{"a", "b", "c"},
+			request: false,
+			want: []*Change{
+				{Type: Changed, Location: "x enum", Before: "[a, b]", After: "[a, b, c]", Rule: RuleEnumWidened},
+			},
+		},
+	}
+	for _, test := range tests {
+		got := compareEnum("x", test.olds, test.news, test.request)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+}
+
+func TestCompareSchema(t *testing.T) {
+	o := &topi.Schema{
+		Type: "object",
+		Properties: map[string]*topi.Schema{
+			"foo":  {Type: "string"},
+			"bar":  {Type: "integer"},
+			"baz":  {Type: "array", Items: &topi.Schema{Type: "string"}},
+			"quux": {Type: "string"},
+		},
+		Required: []string{"quux"},
+	}
+	n := &topi.Schema{
+		Type: "object",
+		Properties: map[string]*topi.Schema{
+			"foo": {Type: "string"},
+			"baz": {Type: "array", Items: &topi.Schema{Type: "integer"}},
+			"qux": {Type: "boolean"},
+		},
+		Required: []string{"foo", "qux"},
+	}
+	tests := []struct {
+		request bool
+		want    []*Change
+	}{
+		{
+			request: true,
+			want: []*Change{
+				{Type: Removed, Location: "body .bar", Before: "integer"},
+				{Type: Changed, Location: "body .baz[] type", Before: "string", After: "integer", Rule: RuleTypeChanged},
+				{Type: Changed, Location: "body .foo required", Before: "false", After: "true", Rule: RulePropertyBecameRequired},
+				{Type: Removed, Location: "body .quux", Before: "string", Rule: RulePropertyRemoved},
+				{Type: Added, Location: "body .qux", After: "boolean", Rule: RuleRequiredPropertyAdded},
+			},
+		},
+		{
+			request: false,
+			want: []*Change{
+				{Type: Removed, Location: "body .bar", Before: "integer", Rule: RulePropertyRemoved},
+				{Type: Changed, Location: "body .baz[] type", Before: "string", After: "integer", Rule: RuleTypeChanged},
+				{Type: Removed, Location: "body .quux", Before: "string", Rule: RulePropertyRemoved},
+				{Type: Added, Location: "body .qux", After: "boolean"},
+			},
+		},
+	}
+	for _, test := range tests {
+		got := compareSchema("body", o, n, test.request)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+}
+
+func TestCompareSecurity(t *testing.T) {
+	tests := []struct {
+		olds, news []*topi.SecurityRequirement
+		want       []*Change
+	}{
+		{
+			olds: []*topi.SecurityRequirement{{Schemes: []*topi.SecurityRequirementScheme{{Key: "a"}}}},
+			news: []*topi.SecurityRequirement{{Schemes: []*topi.SecurityRequirementScheme{{Key: "a"}}}},
+			want: nil,
+		},
+		{
+			olds: nil,
+			news: []*topi.SecurityRequirement{{Schemes: []*topi.SecurityRequirementScheme{{Key: "a"}}}},
+			want: []*Change{
+				{Type: Changed, Location: "security", Before: "none", After: "a", Rule: RuleSecurityRequirementAdded},
+			},
+		},
+		{
+			olds: []*topi.SecurityRequirement{
+				{Schemes: []*topi.SecurityRequirementScheme{{Key: "a"}}},
+				{Schemes: []*topi.SecurityRequirementScheme{{Key: "b", Scopes: []string{"read"}}}},
+			},
+			news: []*topi.SecurityRequirement{{Schemes: []*topi.SecurityRequirementScheme{{Key: "a"}}}},
+			want: []*Change{
+				{Type: Changed, Location: "security", Before: "a or b(read)", After: "a", Rule: RuleSecurityAlternativeRemoved},
+			},
+		},
+		{
+			// anonymous access is removed
+			olds: []*topi.SecurityRequirement{
+				{Schemes: []*topi.SecurityRequirementScheme{}},
+				{Schemes: []*topi.SecurityRequirementScheme{{Key: "a"}}},
+			},
+			news: []*topi.SecurityRequirement{{Schemes: []*topi.SecurityRequirementScheme{{Key: "a"}}}},
+			want: []*Change{
+				{Type: Changed, Location: "security", Before: "(anonymous) or a", After: "a", Rule: RuleSecurityAlternativeRemoved},
+			},
+		},
+		{
+			// all requirements are removed, the operation becomes public
+			olds: []*topi.SecurityRequirement{{Schemes: []*topi.SecurityRequirementScheme{{Key: "a"}}}},
+			news: nil,
+			want: []*Change{
+				{Type: Changed, Location: "security", Before: "a", After: "none"},
+			},
+		},
+	}
+	for _, test := range tests {
+		got := compareSecurity(test.olds, test.news)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+}
+
+func TestCompareConstraints(t *testing.T) {
+	tests := []struct {
+		o, n    *topi.Schema
+		request bool
+		want    []*Change
+	}{
+		{
+			o:       &topi.Schema{Type: "string", MaxLength: ptr[uint64](20)},
+			n:       &topi.Schema{Type: "string", MaxLength: ptr[uint64](10)},
+			request: true,
+			want: []*Change{
+				{Type: Changed, Location: "x maxLength", Before: "20", After: "10", Rule: RuleMaxLengthDecreased},
+			},
+		},
+		{
+			o:       &topi.Schema{Type: "string", MaxLength: ptr[uint64](20)},
+			n:       &topi.Schema{Type: "string", MaxLength: ptr[uint64](10)},
+			request: false,
+			want: []*Change{
+				{Type: Changed, Location: "x maxLength", Before: "20", After: "10"},
+			},
+		},
+		{
+			o:       &topi.Schema{Type: "string", MaxLength: ptr[uint64](10)},
+			n:       &topi.Schema{Type: "string"},
+			request: true,
+			want: []*Change{
+				{Type: Changed, Location: "x maxLength", Before: "10", After: "none"},
+			},
+		},
+		{
+			o:       &topi.Schema{Type: "integer", Min: ptr[float64](1)},
+			n:       &topi.Schema{Type: "integer", Min: ptr[float64](5), Max: ptr[float64](10)},
+			request: true,
+			want: []*Change{
+				{Type: Changed, Location: "x maximum", Before: "none", After: "10", Rule: RuleMaxDecreased},
+				{Type: Changed, Location: "x minimum", Before: "1", After: "5", Rule: RuleMinIncreased},
+			},
+		},
+		{
+			o:       &topi.Schema{Type: "array", MinItems: 1},
+			n:       &topi.Schema{Type: "array", MinItems: 0},
+			request: true,
+			want: []*Change{
+				{Type: Changed, Location: "x minItems", Before: "1", After: "0"},
+			},
+		},
+	}
+	for _, test := range tests {
+		got := compareConstraints("x", test.o, test.n, test.request)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
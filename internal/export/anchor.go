@@ -0,0 +1,45 @@
+package export
+
+import (
+	"strings"
+
+	"github.com/lusingander/topi/internal/topi"
+)
+
+// slug converts s into a string which can be used as an id or a file name.
+func slug(s string) string {
+	var b strings.Builder
+	sep := false
+	for _, r := range strings.ToLower(s) {
+		if ('a' <= r && r <= 'z') || ('0' <= r && r <= '9') {
+			if sep && b.Len() > 0 {
+				b.WriteRune('-')
+			}
+			b.WriteRune(r)
+			sep = false
+		} else {
+			sep = true
+		}
+	}
+	return b.String()
+}
+
+func tagAnchor(name string) string {
+	return "tag-" + slug(name)
+}
+
+func tagFileName(name string) string {
+	s := slug(name)
+	if s == "" {
+		s = "untagged"
+	}
+	return s + ".md"
+}
+
+func operationAnchor(p *topi.Path) string {
+	return "operation-" + slug(p.Method+" "+p.UriPath)
+}
+
+func modelAnchor(name string) string {
+	return "model-" + slug(name)
+}
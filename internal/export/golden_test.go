@@ -0,0 +1,48 @@
+package export
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lusingander/topi/internal/openapi"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestMarkdownOperation(t *testing.T) {
+	doc, err := openapi.Load(filepath.Join("testdata", "show.yaml"))
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+	tests := []struct {
+		operationId string
+		golden      string
+	}{
+		{"listPets", "list_pets.md.golden"},
+		{"createPet", "create_pet.md.golden"},
+		{"deletePet", "delete_pet.md.golden"},
+	}
+	for _, test := range tests {
+		op := doc.FindPathByOperationId(test.operationId)
+		if op == nil {
+			t.Fatalf("operation not found: %s", test.operationId)
+		}
+		got := string(MarkdownOperation(op))
+
+		golden := filepath.Join("testdata", test.golden)
+		if *update {
+			if err := os.WriteFile(golden, []byte(got), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		want, err := os.ReadFile(golden)
+		if err != nil {
+			t.Fatalf("failed to read golden file: %v", err)
+		}
+		if got != string(want) {
+			t.Errorf("%s: got=\n%v\nwant=\n%v", test.golden, got, string(want))
+		}
+	}
+}
@@ -0,0 +1,382 @@
+package export
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html"
+	"html/template"
+	"sort"
+	"strings"
+
+	"github.com/lusingander/topi/internal/color"
+	"github.com/lusingander/topi/internal/topi"
+	"github.com/yuin/goldmark"
+)
+
+//go:embed html
+var htmlAssets embed.FS
+
+const (
+	htmlIndexFileName       = "index.html"
+	htmlSearchIndexFileName = "search-index.js"
+)
+
+// static files copied to the output directory as is
+var htmlStaticFileNames = []string{"style.css", "search.js"}
+
+var htmlTemplate = template.Must(
+	template.New("index.html.tmpl").
+		Funcs(template.FuncMap{
+			"lower":      strings.ToLower,
+			"parameters": newHTMLParameterTable,
+		}).
+		ParseFS(htmlAssets, "html/index.html.tmpl"),
+)
+
+type htmlDocument struct {
+	Title        string
+	Version      string
+	Description  template.HTML
+	ExDocsUrl    string
+	ExDocsDesc   string
+	Tags         []*htmlTag
+	Models       []*htmlModel
+	MethodColors template.CSS
+}
+
+type htmlTag struct {
+	Name        string
+	Anchor      string
+	Description template.HTML
+	Operations  []*htmlOperation
+}
+
+type htmlOperation struct {
+	Anchor      string
+	Method      string
+	UriPath     string
+	Summary     string
+	Description template.HTML
+	OperationId string
+	Deprecated  bool
+	Security    []template.HTML
+	Parameters  []*htmlParameter
+	RequestBody *htmlBody
+	Responses   []*htmlResponse
+}
+
+type htmlParameter struct {
+	Name        string
+	In          string
+	Type        template.HTML
+	Required    bool
+	Deprecated  bool
+	Description template.HTML
+	Notes       []schemaNote
+}
+
+type htmlParameterTable struct {
+	Parameters []*htmlParameter
+	In         bool // show "In" column
+}
+
+func newHTMLParameterTable(params []*htmlParameter, in bool) *htmlParameterTable {
+	return &htmlParameterTable{Parameters: params, In: in}
+}
+
+type htmlBody struct {
+	Required    bool
+	Description template.HTML
+	Contents    []*htmlContent
+}
+
+type htmlResponse struct {
+	StatusCode  string
+	Description template.HTML
+	Headers     []*htmlParameter
+	Contents    []*htmlContent
+}
+
+type htmlContent struct {
+	MediaType  string
+	Type       template.HTML
+	Properties []*htmlProperty
+}
+
+type htmlProperty struct {
+	Name        string
+	OneOf       int
+	Type        template.HTML
+	Required    bool
+	Deprecated  bool
+	Description string
+	Notes       []schemaNote
+	Properties  []*htmlProperty
+}
+
+type htmlModel struct {
+	Name        string
+	Anchor      string
+	Description template.HTML
+	Type        template.HTML
+	Properties  []*htmlProperty
+}
+
+type htmlSearchIndexEntry struct {
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	Summary     string `json:"summary,omitempty"`
+	OperationId string `json:"operationId,omitempty"`
+	Tag         string `json:"tag"`
+	Anchor      string `json:"anchor"`
+}
+
+// HTMLFiles renders the document as a self-contained static site.
+// The keys of the returned map are file names in the output directory.
+func HTMLFiles(doc *topi.Document) (map[string][]byte, error) {
+	d, err := newHTMLDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[string][]byte)
+
+	var index bytes.Buffer
+	if err := htmlTemplate.Execute(&index, d); err != nil {
+		return nil, err
+	}
+	ret[htmlIndexFileName] = index.Bytes()
+
+	searchIndex, err := htmlSearchIndex(doc)
+	if err != nil {
+		return nil, err
+	}
+	ret[htmlSearchIndexFileName] = searchIndex
+
+	for _, name := range htmlStaticFileNames {
+		bs, err := htmlAssets.ReadFile("html/" + name)
+		if err != nil {
+			return nil, err
+		}
+		ret[name] = bs
+	}
+	return ret, nil
+}
+
+func newHTMLDocument(doc *topi.Document) (*htmlDocument, error) {
+	colors, err := htmlMethodColors()
+	if err != nil {
+		return nil, err
+	}
+	info := doc.Info
+	d := &htmlDocument{
+		Title:        info.Title,
+		Version:      info.Version,
+		Description:  htmlMarkdown(info.Description),
+		ExDocsUrl:    info.ExDocsUrl,
+		ExDocsDesc:   info.ExDocsDescription,
+		MethodColors: colors,
+	}
+	if d.ExDocsDesc == "" {
+		d.ExDocsDesc = d.ExDocsUrl
+	}
+	for _, tag := range doc.Tags {
+		t := &htmlTag{
+			Name:        tag.Name,
+			Anchor:      tagAnchor(tag.Name),
+			Description: htmlMarkdown(tag.Description),
+		}
+		for _, p := range doc.TagPathMap[tag.Name] {
+			t.Operations = append(t.Operations, newHTMLOperation(p))
+		}
+		d.Tags = append(d.Tags, t)
+	}
+	if doc.Components != nil {
+		names := make([]string, 0, len(doc.Components.Schemas))
+		for name, sc := range doc.Components.Schemas {
+			if sc != nil {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			sc := doc.Components.Schemas[name]
+			m := &htmlModel{
+				Name:        name,
+				Anchor:      modelAnchor(name),
+				Description: htmlMarkdown(sc.Description),
+				Type:        htmlTypeString(sc),
+				Properties:  newHTMLProperties(schemaProperties(sc, schemaAccessAll)),
+			}
+			d.Models = append(d.Models, m)
+		}
+	}
+	return d, nil
+}
+
+func newHTMLOperation(op *topi.Path) *htmlOperation {
+	o := &htmlOperation{
+		Anchor:      operationAnchor(op),
+		Method:      op.Method,
+		UriPath:     op.UriPath,
+		Summary:     op.Summary,
+		Description: htmlMarkdown(op.Description),
+		OperationId: op.OperationId,
+		Deprecated:  op.Deprecated,
+	}
+	for _, r := range op.Security {
+		o.Security = append(o.Security, htmlSecurityRequirement(r))
+	}
+	params := make([]*topi.Parameter, 0)
+	params = append(params, op.PathParameters...)
+	params = append(params, op.QueryParameters...)
+	params = append(params, op.HeaderParameters...)
+	params = append(params, op.CookieParameters...)
+	for _, p := range params {
+		o.Parameters = append(o.Parameters, newHTMLParameter(p.Name, p))
+	}
+	if op.RequestBody != nil {
+		o.RequestBody = &htmlBody{
+			Required:    op.RequestBody.Required,
+			Description: htmlMarkdown(op.RequestBody.Description),
+			Contents:    newHTMLContents(op.RequestBody.Conetnt, schemaAccessWrite),
+		}
+	}
+	for _, r := range op.Responses {
+		res := &htmlResponse{
+			StatusCode:  r.StatusCode,
+			Description: htmlMarkdown(r.Description),
+			Contents:    newHTMLContents(r.Conetnt, schemaAccessRead),
+		}
+		for _, h := range r.Headers {
+			res.Headers = append(res.Headers, newHTMLParameter(h.Name, h.Parameter))
+		}
+		o.Responses = append(o.Responses, res)
+	}
+	return o
+}
+
+func newHTMLParameter(name string, p *topi.Parameter) *htmlParameter {
+	return &htmlParameter{
+		Name:        name,
+		In:          p.In,
+		Type:        htmlTypeString(p.Schema),
+		Required:    p.Required,
+		Deprecated:  p.Deprecated,
+		Description: htmlMarkdown(p.Description),
+		Notes:       schemaNotes(p.Schema),
+	}
+}
+
+func newHTMLContents(contents []*topi.MediaTypeContent, access schemaAccess) []*htmlContent {
+	contents = append([]*topi.MediaTypeContent{}, contents...)
+	sort.Slice(contents, func(i, j int) bool { return contents[i].MediaType < contents[j].MediaType })
+	ret := make([]*htmlContent, len(contents))
+	for i, c := range contents {
+		ret[i] = &htmlContent{MediaType: c.MediaType}
+		if c.Schema != nil {
+			ret[i].Type = htmlTypeString(c.Schema)
+			ret[i].Properties = newHTMLProperties(schemaProperties(c.Schema, access))
+		}
+	}
+	return ret
+}
+
+func newHTMLProperties(props []*schemaProperty) []*htmlProperty {
+	ret := make([]*htmlProperty, len(props))
+	for i, p := range props {
+		ret[i] = &htmlProperty{
+			Name:        p.name,
+			OneOf:       p.oneOf,
+			Type:        htmlTypeString(p.schema),
+			Required:    p.required,
+			Deprecated:  p.schema.Deprecated,
+			Description: oneLine(p.schema.Description),
+			Notes:       schemaNotes(p.schema),
+			Properties:  newHTMLProperties(p.children),
+		}
+	}
+	return ret
+}
+
+func htmlModelLink(name string) string {
+	return fmt.Sprintf(`<a href="#%s">%s</a>`, modelAnchor(name), html.EscapeString(name))
+}
+
+func htmlTypeString(sc *topi.Schema) template.HTML {
+	if sc == nil {
+		return ""
+	}
+	if sc.Ref != "" {
+		return template.HTML(htmlModelLink(sc.Ref))
+	}
+	if sc.Type == "array" && sc.Items != nil && sc.Items.Ref != "" {
+		return template.HTML(fmt.Sprintf("array of %s", htmlModelLink(sc.Items.Ref)))
+	}
+	return template.HTML(html.EscapeString(sc.TypeString()))
+}
+
+func htmlSecurityRequirement(r *topi.SecurityRequirement) template.HTML {
+	ss := make([]string, len(r.Schemes))
+	for i, s := range r.Schemes {
+		ss[i] = fmt.Sprintf("<code>%s</code>", html.EscapeString(s.Key))
+		if len(s.Scopes) > 0 {
+			ss[i] += fmt.Sprintf(" (%s)", html.EscapeString(strings.Join(s.Scopes, ", ")))
+		}
+	}
+	return template.HTML(strings.Join(ss, " and "))
+}
+
+// htmlMarkdown converts the description written in CommonMark to HTML.
+// Raw HTML in the description is not rendered.
+func htmlMarkdown(s string) template.HTML {
+	if s == "" {
+		return ""
+	}
+	var buf bytes.Buffer
+	if err := goldmark.Convert([]byte(s), &buf); err != nil {
+		return template.HTML(fmt.Sprintf("<p>%s</p>", html.EscapeString(s)))
+	}
+	return template.HTML(buf.String())
+}
+
+func htmlMethodColors() (template.CSS, error) {
+	var b strings.Builder
+	for _, method := range []string{"GET", "POST", "PUT", "PATCH", "DELETE"} {
+		hex, err := color.Hex(color.HttpMethod(method))
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, ".method-%s { background-color: %s; }\n", strings.ToLower(method), hex)
+	}
+	hex, err := color.Hex(color.HttpMethodDeprecated)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(&b, ".deprecated .method { background-color: %s; }\n", hex)
+	return template.CSS(b.String()), nil
+}
+
+func htmlSearchIndex(doc *topi.Document) ([]byte, error) {
+	entries := make([]*htmlSearchIndexEntry, 0)
+	for _, tag := range doc.Tags {
+		for _, p := range doc.TagPathMap[tag.Name] {
+			e := &htmlSearchIndexEntry{
+				Method:      p.Method,
+				Path:        p.UriPath,
+				Summary:     p.Summary,
+				OperationId: p.OperationId,
+				Tag:         tag.Name,
+				Anchor:      operationAnchor(p),
+			}
+			entries = append(entries, e)
+		}
+	}
+	bs, err := json.Marshal(entries)
+	if err != nil {
+		return nil, err
+	}
+	// loaded by script tag instead of fetch to work without server (file://)
+	return []byte(fmt.Sprintf("var searchIndex = %s;\n", bs)), nil
+}
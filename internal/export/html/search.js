@@ -0,0 +1,49 @@
+(function () {
+  var input = document.getElementById("search");
+  var results = document.getElementById("search-results");
+  var tags = document.getElementById("tags");
+
+  function matches(entry, words) {
+    var target = [entry.method, entry.path, entry.summary || "", entry.operationId || "", entry.tag]
+      .join(" ")
+      .toLowerCase();
+    return words.every(function (w) {
+      return target.indexOf(w) >= 0;
+    });
+  }
+
+  function render(entries) {
+    results.innerHTML = "";
+    entries.forEach(function (entry) {
+      var method = document.createElement("span");
+      method.className = "method method-" + entry.method.toLowerCase();
+      method.textContent = entry.method;
+
+      var a = document.createElement("a");
+      a.href = "#" + entry.anchor;
+      a.title = entry.summary || "";
+      a.appendChild(method);
+      a.appendChild(document.createTextNode(" " + entry.path));
+
+      var li = document.createElement("li");
+      li.appendChild(a);
+      results.appendChild(li);
+    });
+  }
+
+  input.addEventListener("input", function () {
+    var words = input.value.toLowerCase().split(/\s+/).filter(function (w) {
+      return w !== "";
+    });
+    if (words.length === 0) {
+      results.hidden = true;
+      tags.hidden = false;
+      return;
+    }
+    render(searchIndex.filter(function (entry) {
+      return matches(entry, words);
+    }));
+    results.hidden = false;
+    tags.hidden = true;
+  });
+})();
@@ -0,0 +1,105 @@
+package export
+
+import (
+	"html/template"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/lusingander/topi/internal/topi"
+)
+
+func TestHtmlTypeString(t *testing.T) {
+	tests := []struct {
+		schema *topi.Schema
+		want   template.HTML
+	}{
+		{
+			schema: nil,
+			want:   "",
+		},
+		{
+			schema: &topi.Schema{Type: "string", Format: "date-time"},
+			want:   "string(date-time)",
+		},
+		{
+			schema: &topi.Schema{Ref: "Pet", Type: "object"},
+			want:   `<a href="#model-pet">Pet</a>`,
+		},
+		{
+			schema: &topi.Schema{Type: "array", Items: &topi.Schema{Ref: "Pet<T>", Type: "object"}},
+			want:   `array of <a href="#model-pet-t">Pet&lt;T&gt;</a>`,
+		},
+	}
+	for _, test := range tests {
+		got := htmlTypeString(test.schema)
+		if got != test.want {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+}
+
+func TestHtmlMarkdown(t *testing.T) {
+	tests := []struct {
+		s    string
+		want template.HTML
+	}{
+		{"", ""},
+		{"**bold**", "<p><strong>bold</strong></p>\n"},
+		{"<script>alert(1)</script>", "<!-- raw HTML omitted -->\n"},
+	}
+	for _, test := range tests {
+		got := htmlMarkdown(test.s)
+		if got != test.want {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+}
+
+func TestHTMLFiles(t *testing.T) {
+	doc := topi.NewDocument(
+		&topi.Meta{},
+		&topi.Info{Title: "test"},
+		map[string][]*topi.Path{
+			"pets": {
+				{Method: "GET", UriPath: "/pets", Summary: "List pets"},
+				{Method: "DELETE", UriPath: "/pets/{id}", Deprecated: true},
+			},
+		},
+		nil,
+		&topi.Components{Schemas: map[string]*topi.Schema{"Pet": {Type: "object"}}},
+	)
+	files, err := HTMLFiles(doc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := make([]string, 0)
+	for name := range files {
+		got = append(got, name)
+	}
+	sort.Strings(got)
+	want := []string{"index.html", "search-index.js", "search.js", "style.css"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got=%v, want=%v", got, want)
+	}
+
+	index := string(files["index.html"])
+	for _, s := range []string{
+		`.method-get { background-color: #0087ff; }`,
+		`<span class="method method-get">GET</span>`,
+		`<article id="operation-delete-pets-id" class="operation deprecated">`,
+		`<article id="model-pet" class="model">`,
+	} {
+		if !strings.Contains(index, s) {
+			t.Errorf("%s is not found in index.html", s)
+		}
+	}
+
+	searchIndex := string(files["search-index.js"])
+	wantSearchIndex := `var searchIndex = [{"method":"GET","path":"/pets","summary":"List pets","tag":"pets","anchor":"operation-get-pets"},{"method":"DELETE","path":"/pets/{id}","tag":"pets","anchor":"operation-delete-pets-id"}];` + "\n"
+	if searchIndex != wantSearchIndex {
+		t.Errorf("got=%v, want=%v", searchIndex, wantSearchIndex)
+	}
+}
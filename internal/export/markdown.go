@@ -0,0 +1,349 @@
+package export
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/lusingander/topi/internal/topi"
+)
+
+const (
+	markdownIndexFileName  = "index.md"
+	markdownModelsFileName = "models.md"
+	markdownTagsDirName    = "tags"
+)
+
+type markdownExporter struct {
+	doc *topi.Document
+	// path prefix of links to the models section, empty if all sections are in the same file
+	modelsFile string
+	// if true, only a single operation is rendered without anchors and links to the models section
+	standalone bool
+}
+
+// Markdown renders the whole document as a single Markdown file.
+func Markdown(doc *topi.Document) []byte {
+	e := &markdownExporter{doc: doc}
+
+	var b strings.Builder
+	e.writeTitle(&b)
+
+	b.WriteString("## Index\n\n")
+	for _, tag := range doc.Tags {
+		fmt.Fprintf(&b, "- [%s](#%s)\n", tag.Name, tagAnchor(tag.Name))
+		for _, p := range doc.TagPathMap[tag.Name] {
+			fmt.Fprintf(&b, "  - [%s](#%s)\n", operationLinkText(p), operationAnchor(p))
+		}
+	}
+	if len(e.modelNames()) > 0 {
+		b.WriteString("- [Models](#models)\n")
+	}
+	b.WriteString("\n")
+
+	for _, tag := range doc.Tags {
+		e.writeTag(&b, tag, "##")
+	}
+	e.writeModels(&b, "##")
+	return []byte(b.String())
+}
+
+// MarkdownFiles renders the document as a directory tree,
+// an index page, one file per tag and a models file.
+// The keys of the returned map are slash-separated paths relative to the output directory.
+func MarkdownFiles(doc *topi.Document) map[string][]byte {
+	ret := make(map[string][]byte)
+	e := &markdownExporter{doc: doc, modelsFile: path.Join("..", markdownModelsFileName)}
+
+	var index strings.Builder
+	e.writeTitle(&index)
+	index.WriteString("## Index\n\n")
+	for _, tag := range doc.Tags {
+		tagFile := path.Join(markdownTagsDirName, tagFileName(tag.Name))
+		fmt.Fprintf(&index, "- [%s](%s)\n", tag.Name, tagFile)
+		for _, p := range doc.TagPathMap[tag.Name] {
+			fmt.Fprintf(&index, "  - [%s](%s#%s)\n", operationLinkText(p), tagFile, operationAnchor(p))
+		}
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "[%s](../%s)\n\n", doc.Info.Title, markdownIndexFileName)
+		e.writeTag(&b, tag, "#")
+		ret[tagFile] = []byte(b.String())
+	}
+	if len(e.modelNames()) > 0 {
+		fmt.Fprintf(&index, "- [Models](%s)\n", markdownModelsFileName)
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "[%s](%s)\n\n", doc.Info.Title, markdownIndexFileName)
+		models := &markdownExporter{doc: doc}
+		models.writeModels(&b, "#")
+		ret[markdownModelsFileName] = []byte(b.String())
+	}
+	ret[markdownIndexFileName] = []byte(index.String())
+	return ret
+}
+
+// MarkdownOperation renders a single operation as Markdown.
+func MarkdownOperation(op *topi.Path) []byte {
+	e := &markdownExporter{standalone: true}
+	var b strings.Builder
+	e.writeOperation(&b, op, "#")
+	return []byte(strings.TrimRight(b.String(), "\n") + "\n")
+}
+
+func (e *markdownExporter) writeTitle(b *strings.Builder) {
+	info := e.doc.Info
+	fmt.Fprintf(b, "# %s\n\n", info.Title)
+	if info.Version != "" {
+		fmt.Fprintf(b, "Version: `%s`\n\n", info.Version)
+	}
+	if info.Description != "" {
+		fmt.Fprintf(b, "%s\n\n", strings.TrimSpace(info.Description))
+	}
+	if info.ExDocsUrl != "" {
+		desc := info.ExDocsDescription
+		if desc == "" {
+			desc = info.ExDocsUrl
+		}
+		fmt.Fprintf(b, "See also: [%s](%s)\n\n", desc, info.ExDocsUrl)
+	}
+}
+
+func (e *markdownExporter) writeTag(b *strings.Builder, tag *topi.Tag, h string) {
+	fmt.Fprintf(b, "%s <a id=\"%s\"></a>%s\n\n", h, tagAnchor(tag.Name), tag.Name)
+	if tag.Description != "" {
+		fmt.Fprintf(b, "%s\n\n", strings.TrimSpace(tag.Description))
+	}
+	for _, p := range e.doc.TagPathMap[tag.Name] {
+		e.writeOperation(b, p, h+"#")
+	}
+}
+
+func (e *markdownExporter) writeOperation(b *strings.Builder, op *topi.Path, h string) {
+	if e.standalone {
+		fmt.Fprintf(b, "%s `%s %s`\n\n", h, op.Method, op.UriPath)
+	} else {
+		fmt.Fprintf(b, "%s <a id=\"%s\"></a>`%s %s`\n\n", h, operationAnchor(op), op.Method, op.UriPath)
+	}
+	if op.Deprecated {
+		b.WriteString("> **Deprecated**\n\n")
+	}
+	if op.Summary != "" {
+		fmt.Fprintf(b, "**%s**\n\n", op.Summary)
+	}
+	if op.Description != "" {
+		fmt.Fprintf(b, "%s\n\n", strings.TrimSpace(op.Description))
+	}
+	if op.OperationId != "" {
+		fmt.Fprintf(b, "Operation ID: `%s`\n\n", op.OperationId)
+	}
+
+	if len(op.Security) > 0 {
+		fmt.Fprintf(b, "%s# Security\n\n", h)
+		for _, r := range op.Security {
+			fmt.Fprintf(b, "- %s\n", markdownSecurityRequirement(r))
+		}
+		b.WriteString("\n")
+	}
+
+	params := make([]*topi.Parameter, 0)
+	params = append(params, op.PathParameters...)
+	params = append(params, op.QueryParameters...)
+	params = append(params, op.HeaderParameters...)
+	params = append(params, op.CookieParameters...)
+	if len(params) > 0 {
+		fmt.Fprintf(b, "%s# Parameters\n\n", h)
+		b.WriteString("|Name|In|Type|Required|Description|\n")
+		b.WriteString("|-|-|-|-|-|\n")
+		for _, p := range params {
+			fmt.Fprintf(b, "|%s|%s|%s|%s|%s|\n",
+				markdownName(p.Name, p.Deprecated), p.In, e.typeString(p.Schema), markdownBool(p.Required), markdownTableCell(e.schemaNotes(p.Description, p.Schema)))
+		}
+		b.WriteString("\n")
+	}
+
+	if op.RequestBody != nil {
+		fmt.Fprintf(b, "%s# Request body\n\n", h)
+		if op.RequestBody.Required {
+			b.WriteString("Required\n\n")
+		}
+		if op.RequestBody.Description != "" {
+			fmt.Fprintf(b, "%s\n\n", strings.TrimSpace(op.RequestBody.Description))
+		}
+		e.writeContent(b, op.RequestBody.Conetnt, schemaAccessWrite)
+	}
+
+	if len(op.Responses) > 0 {
+		fmt.Fprintf(b, "%s# Responses\n\n", h)
+		for _, r := range op.Responses {
+			fmt.Fprintf(b, "**%s** %s\n\n", r.StatusCode, oneLine(r.Description))
+			if len(r.Headers) > 0 {
+				b.WriteString("|Header|Type|Required|Description|\n")
+				b.WriteString("|-|-|-|-|\n")
+				for _, header := range r.Headers {
+					p := header.Parameter
+					fmt.Fprintf(b, "|%s|%s|%s|%s|\n",
+						markdownName(header.Name, p.Deprecated), e.typeString(p.Schema), markdownBool(p.Required), markdownTableCell(e.schemaNotes(p.Description, p.Schema)))
+				}
+				b.WriteString("\n")
+			}
+			e.writeContent(b, r.Conetnt, schemaAccessRead)
+		}
+	}
+}
+
+func (e *markdownExporter) writeContent(b *strings.Builder, contents []*topi.MediaTypeContent, access schemaAccess) {
+	contents = append([]*topi.MediaTypeContent{}, contents...)
+	sort.Slice(contents, func(i, j int) bool { return contents[i].MediaType < contents[j].MediaType })
+	for _, c := range contents {
+		if c.Schema == nil {
+			fmt.Fprintf(b, "`%s`\n\n", c.MediaType)
+			continue
+		}
+		fmt.Fprintf(b, "`%s`: %s\n\n", c.MediaType, e.typeString(c.Schema))
+		lines := e.schemaTree(c.Schema, 0, access)
+		if len(lines) > 0 {
+			b.WriteString(strings.Join(lines, "\n"))
+			b.WriteString("\n\n")
+		}
+	}
+}
+
+func (e *markdownExporter) writeModels(b *strings.Builder, h string) {
+	names := e.modelNames()
+	if len(names) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "%s <a id=\"models\"></a>Models\n\n", h)
+	for _, name := range names {
+		sc := e.doc.Components.Schemas[name]
+		fmt.Fprintf(b, "%s# <a id=\"%s\"></a>%s\n\n", h, modelAnchor(name), name)
+		if sc.Description != "" {
+			fmt.Fprintf(b, "%s\n\n", strings.TrimSpace(sc.Description))
+		}
+		fmt.Fprintf(b, "Type: %s\n\n", e.typeString(sc))
+		lines := e.schemaTree(sc, 0, schemaAccessAll)
+		if len(lines) > 0 {
+			b.WriteString(strings.Join(lines, "\n"))
+			b.WriteString("\n\n")
+		}
+	}
+}
+
+func (e *markdownExporter) modelNames() []string {
+	if e.doc.Components == nil {
+		return nil
+	}
+	names := make([]string, 0, len(e.doc.Components.Schemas))
+	for name, sc := range e.doc.Components.Schemas {
+		if sc != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (e *markdownExporter) modelLink(name string) string {
+	if e.standalone {
+		return fmt.Sprintf("`%s`", name)
+	}
+	return fmt.Sprintf("[%s](%s#%s)", name, e.modelsFile, modelAnchor(name))
+}
+
+func (e *markdownExporter) typeString(sc *topi.Schema) string {
+	if sc == nil {
+		return ""
+	}
+	if sc.Ref != "" {
+		return e.modelLink(sc.Ref)
+	}
+	if sc.Type == "array" && sc.Items != nil && sc.Items.Ref != "" {
+		return fmt.Sprintf("array of %s", e.modelLink(sc.Items.Ref))
+	}
+	s := sc.TypeString()
+	if s == "" {
+		return ""
+	}
+	return fmt.Sprintf("`%s`", s)
+}
+
+// schemaTree returns the properties of the schema as nested Markdown list items.
+func (e *markdownExporter) schemaTree(sc *topi.Schema, indentLevel int, access schemaAccess) []string {
+	return e.propertyLines(schemaProperties(sc, access), indentLevel)
+}
+
+func (e *markdownExporter) propertyLines(props []*schemaProperty, indentLevel int) []string {
+	indent := strings.Repeat("  ", indentLevel)
+	ret := make([]string, 0)
+	for _, p := range props {
+		var s string
+		if p.oneOf > 0 {
+			s = fmt.Sprintf("%s- one of [%d]: %s", indent, p.oneOf, e.typeString(p.schema))
+		} else {
+			s = fmt.Sprintf("%s- %s %s", indent, markdownName(p.name, p.schema.Deprecated), e.typeString(p.schema))
+			if p.required {
+				s += " **required**"
+			}
+			if notes := e.schemaNotes(p.schema.Description, p.schema); notes != "" {
+				s += " - " + notes
+			}
+		}
+		ret = append(ret, s)
+		ret = append(ret, e.propertyLines(p.children, indentLevel+1)...)
+	}
+	return ret
+}
+
+// schemaNotes returns the description and the constraints of the schema in a line.
+func (e *markdownExporter) schemaNotes(description string, sc *topi.Schema) string {
+	notes := make([]string, 0)
+	if description != "" {
+		notes = append(notes, oneLine(description))
+	}
+	for _, n := range schemaNotes(sc) {
+		notes = append(notes, fmt.Sprintf("%s: `%s`", n.Label, n.Value))
+	}
+	return strings.Join(notes, " ")
+}
+
+func markdownSecurityRequirement(r *topi.SecurityRequirement) string {
+	ss := make([]string, len(r.Schemes))
+	for i, s := range r.Schemes {
+		if len(s.Scopes) > 0 {
+			ss[i] = fmt.Sprintf("`%s` (%s)", s.Key, strings.Join(s.Scopes, ", "))
+		} else {
+			ss[i] = fmt.Sprintf("`%s`", s.Key)
+		}
+	}
+	return strings.Join(ss, " and ")
+}
+
+func markdownName(name string, deprecated bool) string {
+	if deprecated {
+		return fmt.Sprintf("~~`%s`~~", name)
+	}
+	return fmt.Sprintf("`%s`", name)
+}
+
+func markdownBool(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+var markdownTableCellReplacer = strings.NewReplacer("|", "\\|")
+
+func markdownTableCell(s string) string {
+	return markdownTableCellReplacer.Replace(s)
+}
+
+func operationLinkText(p *topi.Path) string {
+	s := fmt.Sprintf("`%s %s`", p.Method, p.UriPath)
+	if p.Summary != "" {
+		s += " " + p.Summary
+	}
+	return s
+}
@@ -0,0 +1,149 @@
+package export
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/lusingander/topi/internal/topi"
+)
+
+func TestSlug(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"pets", "pets"},
+		{"Pet Store", "pet-store"},
+		{"GET /pets/{petId}", "get-pets-petid"},
+		{"<untagged>", "untagged"},
+		{"", ""},
+	}
+	for _, test := range tests {
+		got := slug(test.s)
+		if got != test.want {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+}
+
+func TestSchemaTree(t *testing.T) {
+	schema := &topi.Schema{
+		Type:     "object",
+		Required: []string{"id"},
+		Properties: map[string]*topi.Schema{
+			"id":       {Type: "integer", Format: "int64"},
+			"password": {Type: "string", WriteOnly: true},
+			"owner":    {Ref: "User", Type: "object", Properties: map[string]*topi.Schema{"name": {Type: "string"}}},
+			"tags": {
+				Type: "array",
+				Items: &topi.Schema{
+					Type: "object",
+					Properties: map[string]*topi.Schema{
+						"name": {Type: "string", Description: "tag\nname"},
+					},
+				},
+			},
+			"status": {Type: "string", Enum: []interface{}{"a", "b"}, Deprecated: true},
+		},
+	}
+	tests := []struct {
+		modelsFile string
+		access     schemaAccess
+		want       []string
+	}{
+		{
+			modelsFile: "",
+			access:     schemaAccessRead,
+			want: []string{
+				"- `id` `integer(int64)` **required**",
+				"- `owner` [User](#model-user)",
+				"- ~~`status`~~ `string` - Enum: `[a, b]`",
+				"- `tags` `array of object`",
+				"  - `name` `string` - tag name",
+			},
+		},
+		{
+			modelsFile: "../models.md",
+			access:     schemaAccessWrite,
+			want: []string{
+				"- `id` `integer(int64)` **required**",
+				"- `owner` [User](../models.md#model-user)",
+				"- `password` `string`",
+				"- ~~`status`~~ `string` - Enum: `[a, b]`",
+				"- `tags` `array of object`",
+				"  - `name` `string` - tag name",
+			},
+		},
+	}
+	for _, test := range tests {
+		e := &markdownExporter{modelsFile: test.modelsFile}
+		got := e.schemaTree(schema, 0, test.access)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+}
+
+func TestMarkdownSecurityRequirement(t *testing.T) {
+	tests := []struct {
+		requirement *topi.SecurityRequirement
+		want        string
+	}{
+		{
+			requirement: &topi.SecurityRequirement{
+				Schemes: []*topi.SecurityRequirementScheme{{Key: "api_key"}},
+			},
+			want: "`api_key`",
+		},
+		{
+			requirement: &topi.SecurityRequirement{
+				Schemes: []*topi.SecurityRequirementScheme{
+					{Key: "api_key"},
+					{Key: "oauth", Scopes: []string{"read", "write"}},
+				},
+			},
+			want: "`api_key` and `oauth` (read, write)",
+		},
+	}
+	for _, test := range tests {
+		got := markdownSecurityRequirement(test.requirement)
+		if got != test.want {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+}
+
+func TestMarkdownFiles(t *testing.T) {
+	doc := topi.NewDocument(
+		&topi.Meta{},
+		&topi.Info{Title: "test"},
+		map[string][]*topi.Path{
+			"pets": {
+				{Method: "GET", UriPath: "/pets", Responses: []*topi.Response{
+					{StatusCode: "200", Conetnt: []*topi.MediaTypeContent{{MediaType: "application/json", Schema: &topi.Schema{Ref: "Pet", Type: "object"}}}},
+				}},
+			},
+		},
+		nil,
+		&topi.Components{Schemas: map[string]*topi.Schema{"Pet": {Type: "object"}}},
+	)
+	files := MarkdownFiles(doc)
+
+	got := make([]string, 0)
+	for name := range files {
+		got = append(got, name)
+	}
+	want := []string{"index.md", "models.md", "tags/pets.md"}
+	sort.Strings(got)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got=%v, want=%v", got, want)
+	}
+	if s := string(files["tags/pets.md"]); !strings.Contains(s, "[Pet](../models.md#model-pet)") {
+		t.Errorf("link to the model is not found: %s", s)
+	}
+	if s := string(files["index.md"]); !strings.Contains(s, "(tags/pets.md#operation-get-pets)") {
+		t.Errorf("link to the operation is not found: %s", s)
+	}
+}
@@ -0,0 +1,120 @@
+package export
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lusingander/topi/internal/topi"
+)
+
+type schemaAccess int
+
+const (
+	schemaAccessAll schemaAccess = iota
+	schemaAccessRead
+	schemaAccessWrite
+)
+
+func (a schemaAccess) visible(sc *topi.Schema) bool {
+	switch a {
+	case schemaAccessRead:
+		return !sc.WriteOnly
+	case schemaAccessWrite:
+		return !sc.ReadOnly
+	default:
+		return true
+	}
+}
+
+type schemaProperty struct {
+	name     string
+	oneOf    int // index (1-origin) of oneOf, 0 if the property is not an element of oneOf
+	schema   *topi.Schema
+	required bool
+	children []*schemaProperty
+}
+
+// schemaProperties returns the properties of the schema as a tree.
+// Schemas referenced by $ref are not expanded to avoid duplication and circular references.
+func schemaProperties(sc *topi.Schema, access schemaAccess) []*schemaProperty {
+	if len(sc.AllOf) > 0 {
+		return schemaProperties(sc.MergedAllOf(), access)
+	}
+	ret := make([]*schemaProperty, 0)
+	if len(sc.OneOf) > 0 {
+		for i, s := range sc.OneOf {
+			p := &schemaProperty{oneOf: i + 1, schema: s}
+			if s.Ref == "" {
+				p.children = schemaProperties(s, access)
+			}
+			ret = append(ret, p)
+		}
+		return ret
+	}
+	if sc.IsObjectArray() && sc.Items.Ref == "" {
+		return schemaProperties(sc.Items, access)
+	}
+	if sc.Type != "object" {
+		return ret
+	}
+	names := make([]string, 0, len(sc.Properties))
+	for name := range sc.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		prop := sc.Properties[name]
+		if prop == nil || !access.visible(prop) {
+			continue
+		}
+		p := &schemaProperty{
+			name:     name,
+			schema:   prop,
+			required: containsString(name, sc.Required),
+		}
+		if prop.Ref == "" {
+			p.children = schemaProperties(prop, access)
+		}
+		ret = append(ret, p)
+	}
+	return ret
+}
+
+type schemaNote struct {
+	Label string
+	Value string
+}
+
+// schemaNotes returns enum values, default value and constraints of the schema.
+func schemaNotes(sc *topi.Schema) []schemaNote {
+	ret := make([]schemaNote, 0)
+	if sc == nil || sc.Ref != "" {
+		return ret
+	}
+	if len(sc.Enum) > 0 {
+		ret = append(ret, schemaNote{"Enum", topi.SliceString(sc.Enum)})
+	} else if sc.Type == "array" && sc.Items != nil && len(sc.Items.Enum) > 0 {
+		ret = append(ret, schemaNote{"Enum", topi.SliceString(sc.Items.Enum)})
+	}
+	if sc.Default != nil {
+		ret = append(ret, schemaNote{"Default", fmt.Sprintf("%v", sc.Default)})
+	}
+	if cs := sc.ConstraintStrings(); len(cs) > 0 {
+		ret = append(ret, schemaNote{"Constraints", strings.Join(cs, ", ")})
+	}
+	return ret
+}
+
+func containsString(s string, ss []string) bool {
+	for _, e := range ss {
+		if s == e {
+			return true
+		}
+	}
+	return false
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
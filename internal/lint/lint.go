@@ -0,0 +1,287 @@
+package lint
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/lusingander/topi/internal/topi"
+	"gopkg.in/yaml.v3"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityOff     Severity = "off"
+)
+
+type Problem struct {
+	Rule        string   `json:"rule"`
+	Severity    Severity `json:"severity"`
+	Operation   string   `json:"operation,omitempty"`
+	OperationId string   `json:"operationId,omitempty"`
+	Location    string   `json:"location,omitempty"`
+	Line        int      `json:"line,omitempty"`
+	Message     string   `json:"message"`
+}
+
+type Rule struct {
+	Id          string
+	Description string
+	Severity    Severity // default severity
+
+	check         func(doc *topi.Document, path *topi.Path) []*Problem // for each operation
+	checkDocument func(doc *topi.Document) []*Problem                  // for the whole document
+}
+
+var Rules = []*Rule{
+	{
+		Id:            "openapi-validation",
+		Description:   "spec must be valid against the OpenAPI specification",
+		Severity:      SeverityError,
+		checkDocument: checkValidation,
+	},
+	{
+		Id:          "operation-operation-id",
+		Description: "operation must have an operationId",
+		Severity:    SeverityError,
+		check:       checkOperationId,
+	},
+	{
+		Id:          "operation-summary",
+		Description: "operation must have a summary",
+		Severity:    SeverityWarning,
+		check:       checkOperationSummary,
+	},
+	{
+		Id:          "operation-tags",
+		Description: "operation must have tags",
+		Severity:    SeverityWarning,
+		check:       checkOperationTags,
+	},
+	{
+		Id:          "operation-4xx-response",
+		Description: "operation must have at least one 4xx response",
+		Severity:    SeverityWarning,
+		check:       checkOperation4xxResponse,
+	},
+	{
+		Id:          "parameter-description",
+		Description: "parameter must have a description",
+		Severity:    SeverityWarning,
+		check:       checkParameterDescription,
+	},
+	{
+		Id:          "path-kebab-case",
+		Description: "path segments must be kebab-case",
+		Severity:    SeverityWarning,
+		check:       checkPathKebabCase,
+	},
+}
+
+func findRule(id string) *Rule {
+	for _, r := range Rules {
+		if r.Id == id {
+			return r
+		}
+	}
+	return nil
+}
+
+type Config struct {
+	Rules map[string]Severity `yaml:"rules"`
+}
+
+func LoadConfig(path string) (*Config, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := parseConfig(bs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+func parseConfig(bs []byte) (*Config, error) {
+	cfg := &Config{}
+	dec := yaml.NewDecoder(bytes.NewReader(bs))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (c *Config) validate() error {
+	for id, severity := range c.Rules {
+		if findRule(id) == nil {
+			return fmt.Errorf("unknown rule: %s", id)
+		}
+		switch severity {
+		case SeverityError, SeverityWarning, SeverityOff:
+		default:
+			return fmt.Errorf("invalid severity for %s: %s", id, severity)
+		}
+	}
+	return nil
+}
+
+func (c *Config) severity(r *Rule) Severity {
+	if c != nil {
+		if s, ok := c.Rules[r.Id]; ok {
+			return s
+		}
+	}
+	return r.Severity
+}
+
+// Run checks all operations of the document. cfg can be nil to use the default severities.
+func Run(doc *topi.Document, cfg *Config) []*Problem {
+	ret := make([]*Problem, 0)
+	for _, r := range Rules {
+		severity := cfg.severity(r)
+		if severity == SeverityOff || r.checkDocument == nil {
+			continue
+		}
+		for _, p := range r.checkDocument(doc) {
+			p.Rule = r.Id
+			p.Severity = severity
+			ret = append(ret, p)
+		}
+	}
+	for _, tag := range doc.Tags {
+		for _, path := range doc.TagPathMap[tag.Name] {
+			for _, r := range Rules {
+				severity := cfg.severity(r)
+				if severity == SeverityOff || r.check == nil {
+					continue
+				}
+				for _, p := range r.check(doc, path) {
+					p.Rule = r.Id
+					p.Severity = severity
+					p.Operation = fmt.Sprintf("%s %s", path.Method, path.UriPath)
+					p.OperationId = path.OperationId
+					ret = append(ret, p)
+				}
+			}
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Severity == SeverityError && ret[j].Severity != SeverityError
+	})
+	return ret
+}
+
+func HasError(ps []*Problem) bool {
+	for _, p := range ps {
+		if p.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+func checkValidation(doc *topi.Document) []*Problem {
+	ret := make([]*Problem, 0)
+	for _, e := range doc.ValidationErrors {
+		p := &Problem{
+			Location: e.Pointer,
+			Line:     e.Line,
+			Message:  e.Message,
+		}
+		if path := findPathByPointer(doc, e.Pointer); path != nil {
+			p.Operation = fmt.Sprintf("%s %s", path.Method, path.UriPath)
+			p.OperationId = path.OperationId
+		}
+		ret = append(ret, p)
+	}
+	return ret
+}
+
+var jsonPointerUnreplacer = strings.NewReplacer("~1", "/", "~0", "~")
+
+// findPathByPointer returns the operation which contains the node pointed by JSON pointer (/paths/{path}/{method}/...)
+func findPathByPointer(doc *topi.Document, pointer string) *topi.Path {
+	tokens := strings.Split(pointer, "/")
+	if len(tokens) < 4 || tokens[1] != "paths" {
+		return nil
+	}
+	uriPath := jsonPointerUnreplacer.Replace(tokens[2])
+	method := strings.ToUpper(tokens[3])
+	return doc.FindPath(method, uriPath)
+}
+
+func checkOperationId(_ *topi.Document, path *topi.Path) []*Problem {
+	if path.OperationId != "" {
+		return nil
+	}
+	return []*Problem{{Message: "operationId is missing"}}
+}
+
+func checkOperationSummary(_ *topi.Document, path *topi.Path) []*Problem {
+	if path.Summary != "" {
+		return nil
+	}
+	return []*Problem{{Message: "summary is missing"}}
+}
+
+func checkOperationTags(doc *topi.Document, path *topi.Path) []*Problem {
+	for _, p := range doc.TagPathMap[topi.UntaggedDummyTag] {
+		if p == path {
+			return []*Problem{{Message: "tags are missing"}}
+		}
+	}
+	return nil
+}
+
+func checkOperation4xxResponse(_ *topi.Document, path *topi.Path) []*Problem {
+	for _, r := range path.Responses {
+		if strings.HasPrefix(r.StatusCode, "4") {
+			return nil
+		}
+	}
+	return []*Problem{{Location: "responses", Message: "4xx response is missing"}}
+}
+
+func checkParameterDescription(_ *topi.Document, path *topi.Path) []*Problem {
+	ret := make([]*Problem, 0)
+	params := make([]*topi.Parameter, 0)
+	params = append(params, path.PathParameters...)
+	params = append(params, path.QueryParameters...)
+	params = append(params, path.HeaderParameters...)
+	params = append(params, path.CookieParameters...)
+	for _, p := range params {
+		if p.Description == "" {
+			loc := fmt.Sprintf("%s parameter %s", p.In, p.Name)
+			ret = append(ret, &Problem{Location: loc, Message: fmt.Sprintf("description of %s is missing", loc)})
+		}
+	}
+	return ret
+}
+
+var kebabCaseRegexp = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+func checkPathKebabCase(_ *topi.Document, path *topi.Path) []*Problem {
+	ret := make([]*Problem, 0)
+	for _, seg := range strings.Split(path.UriPath, "/") {
+		if seg == "" || strings.HasPrefix(seg, "{") {
+			continue
+		}
+		if !kebabCaseRegexp.MatchString(seg) {
+			ret = append(ret, &Problem{Location: "path", Message: fmt.Sprintf("path segment %q is not kebab-case", seg)})
+		}
+	}
+	return ret
+}
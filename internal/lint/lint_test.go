@@ -0,0 +1,126 @@
+package lint
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lusingander/topi/internal/topi"
+)
+
+func TestCheckPathKebabCase(t *testing.T) {
+	tests := []struct {
+		uriPath string
+		want    []*Problem
+	}{
+		{
+			uriPath: "/",
+			want:    []*Problem{},
+		},
+		{
+			uriPath: "/v1/pet-stores/{petStoreId}/pets",
+			want:    []*Problem{},
+		},
+		{
+			uriPath: "/petStores/{id}/pet_types",
+			want: []*Problem{
+				{Location: "path", Message: `path segment "petStores" is not kebab-case`},
+				{Location: "path", Message: `path segment "pet_types" is not kebab-case`},
+			},
+		},
+	}
+	for _, test := range tests {
+		got := checkPathKebabCase(nil, &topi.Path{UriPath: test.uriPath})
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	path := &topi.Path{
+		UriPath: "/pets",
+		Method:  "GET",
+		QueryParameters: []*topi.Parameter{
+			{Name: "limit", In: "query"},
+		},
+		Responses: []*topi.Response{
+			{StatusCode: "200"},
+			{StatusCode: "404"},
+		},
+	}
+	doc := topi.NewDocument(nil, nil, map[string][]*topi.Path{topi.UntaggedDummyTag: {path}}, nil, nil)
+	cfg := &Config{
+		Rules: map[string]Severity{
+			"operation-summary":     SeverityOff,
+			"parameter-description": SeverityError,
+		},
+	}
+	doc.ValidationErrors = []*topi.ValidationError{
+		{Pointer: "/paths/~1pets/get", Line: 5, Message: "invalid"},
+	}
+	want := []*Problem{
+		{Rule: "openapi-validation", Severity: SeverityError, Operation: "GET /pets", Location: "/paths/~1pets/get", Line: 5, Message: "invalid"},
+		{Rule: "operation-operation-id", Severity: SeverityError, Operation: "GET /pets", Message: "operationId is missing"},
+		{Rule: "parameter-description", Severity: SeverityError, Operation: "GET /pets", Location: "query parameter limit", Message: "description of query parameter limit is missing"},
+		{Rule: "operation-tags", Severity: SeverityWarning, Operation: "GET /pets", Message: "tags are missing"},
+	}
+	got := Run(doc, cfg)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got=%v, want=%v", got, want)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		cfg     *Config
+		wantErr bool
+	}{
+		{
+			cfg:     &Config{Rules: map[string]Severity{"operation-summary": SeverityOff}},
+			wantErr: false,
+		},
+		{
+			cfg:     &Config{Rules: map[string]Severity{"unknown-rule": SeverityOff}},
+			wantErr: true,
+		},
+		{
+			cfg:     &Config{Rules: map[string]Severity{"operation-summary": "fatal"}},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		err := test.cfg.validate()
+		if (err != nil) != test.wantErr {
+			t.Errorf("err=%v, wantErr=%v", err, test.wantErr)
+		}
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	tests := []struct {
+		yaml    string
+		want    *Config
+		wantErr bool
+	}{
+		{"", &Config{}, false},
+		{"rules:\n  operation-summary: off", &Config{Rules: map[string]Severity{"operation-summary": SeverityOff}}, false},
+		{"rule:\n  operation-summary: off", nil, true},
+		{"rules:\n  operation-sumary: off", nil, true},
+	}
+	for _, test := range tests {
+		got, err := parseConfig([]byte(test.yaml))
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("error is expected: %s", test.yaml)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+func WriteText(w io.Writer, ps []*Problem) error {
+	if len(ps) == 0 {
+		_, err := fmt.Fprintln(w, "no problems")
+		return err
+	}
+	for _, p := range ps {
+		if _, err := fmt.Fprintf(w, "%-7s [%s] %s: %s\n", p.Severity, p.Rule, p.Operation, p.Message); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "\n%d problems\n", len(ps))
+	return err
+}
+
+func WriteJSON(w io.Writer, ps []*Problem) error {
+	out := struct {
+		Problems []*Problem `json:"problems"`
+	}{
+		Problems: ps,
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(out)
+}
@@ -0,0 +1,241 @@
+// Package oauth acquires the access tokens of the OAuth2 and OpenID Connect security schemes defined in the specs.
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lusingander/topi/internal/topi"
+)
+
+// Grant is the OAuth2 flow to acquire the token.
+type Grant string
+
+const (
+	GrantClientCredentials Grant = "client_credentials"
+	GrantPassword          Grant = "password"
+	GrantAuthorizationCode Grant = "authorization_code" // with PKCE
+)
+
+// Config is the settings of the client registered to the authorization server.
+type Config struct {
+	Grant        Grant    `yaml:"grant"` // the first flow defined in the scheme is used if empty
+	ClientID     string   `yaml:"client_id"`
+	ClientSecret string   `yaml:"client_secret"` // empty for public clients
+	Username     string   `yaml:"username"`      // password flow
+	Password     string   `yaml:"password"`      // password flow
+	Scopes       []string `yaml:"scopes"`        // all scopes of the flow are requested if empty
+	RedirectPort int      `yaml:"redirect_port"` // port of the localhost redirect listener, a free port is used if 0
+}
+
+// Validate checks the values which can be checked without accessing the network.
+func (c *Config) Validate() error {
+	switch c.Grant {
+	case "", GrantClientCredentials, GrantPassword, GrantAuthorizationCode:
+	default:
+		return fmt.Errorf("invalid grant: %s", c.Grant)
+	}
+	if c.ClientID == "" {
+		return errors.New("client_id is not set")
+	}
+	if c.Grant == GrantClientCredentials && c.ClientSecret == "" {
+		return errors.New("client_secret is required for client_credentials")
+	}
+	if c.Grant == GrantPassword && c.Username == "" {
+		return errors.New("username is required for password")
+	}
+	if c.RedirectPort < 0 || c.RedirectPort > 65535 {
+		return fmt.Errorf("invalid redirect_port: %d", c.RedirectPort)
+	}
+	return nil
+}
+
+// GrantOf returns the grant to acquire the token of the scheme with the config.
+// If the grant is not set, the first one defined in the scheme is used in the order of
+// authorization code, client credentials (only if the client secret is set) and password.
+func GrantOf(scheme *topi.SecurityScheme, c Config) (Grant, error) {
+	if c.Grant != "" {
+		return c.Grant, nil
+	}
+	switch scheme.Type {
+	case "oauth2":
+		for _, g := range []Grant{GrantAuthorizationCode, GrantClientCredentials, GrantPassword} {
+			if g == GrantClientCredentials && c.ClientSecret == "" {
+				continue
+			}
+			if FlowOf(scheme.OAuthFlows, g) != nil {
+				return g, nil
+			}
+		}
+		return "", fmt.Errorf("no supported flow is defined in %s", scheme.Key)
+	case "openIdConnect":
+		return GrantAuthorizationCode, nil
+	default:
+		return "", fmt.Errorf("%s is not an OAuth2 or OpenID Connect scheme", scheme.Key)
+	}
+}
+
+// Client acquires the tokens with the config.
+type Client struct {
+	Config     Config
+	HTTPClient *http.Client           // http.DefaultClient is used if nil
+	Open       func(url string) error // opens the authorization URL in the browser for the authorization code flow
+
+	Now func() time.Time // time.Now is used if nil
+}
+
+// NewClient returns the client with the config.
+// Environment variables in the client secret and the password (e.g. "${CLIENT_SECRET}") are expanded.
+func NewClient(c Config) *Client {
+	c.ClientSecret = os.ExpandEnv(c.ClientSecret)
+	c.Password = os.ExpandEnv(c.Password)
+	return &Client{Config: c}
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+func (c *Client) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
+
+// Token acquires the token of the security scheme by the grant.
+// The endpoints of openIdConnect schemes are discovered from the OpenID Connect URL.
+func (c *Client) Token(ctx context.Context, scheme *topi.SecurityScheme, grant Grant) (*Token, error) {
+	flow, err := c.flow(ctx, scheme, grant)
+	if err != nil {
+		return nil, err
+	}
+	switch grant {
+	case GrantClientCredentials:
+		return c.ClientCredentials(ctx, flow)
+	case GrantPassword:
+		return c.Password(ctx, flow)
+	case GrantAuthorizationCode:
+		return c.AuthorizationCode(ctx, flow)
+	default:
+		return nil, fmt.Errorf("unsupported grant: %s", grant)
+	}
+}
+
+func (c *Client) flow(ctx context.Context, scheme *topi.SecurityScheme, grant Grant) (*topi.OAuthFlow, error) {
+	switch scheme.Type {
+	case "oauth2":
+		if flow := FlowOf(scheme.OAuthFlows, grant); flow != nil {
+			return flow, nil
+		}
+		return nil, fmt.Errorf("%s flow is not defined in %s", grant, scheme.Key)
+	case "openIdConnect":
+		return c.Discover(ctx, scheme.OpenIdConnectUrl)
+	default:
+		return nil, fmt.Errorf("%s is not an OAuth2 or OpenID Connect scheme", scheme.Key)
+	}
+}
+
+// FlowOf returns the flow of the grant, or nil if not defined.
+func FlowOf(flows *topi.OAtuhFlows, grant Grant) *topi.OAuthFlow {
+	if flows == nil {
+		return nil
+	}
+	switch grant {
+	case GrantClientCredentials:
+		return flows.ClientCredentialsFlow
+	case GrantPassword:
+		return flows.ResourceOwnerPasswordCredentialsFlow
+	case GrantAuthorizationCode:
+		return flows.AuthorizatonCodeFlow
+	default:
+		return nil
+	}
+}
+
+// ClientCredentials acquires the token by the client credentials flow.
+func (c *Client) ClientCredentials(ctx context.Context, flow *topi.OAuthFlow) (*Token, error) {
+	params := url.Values{}
+	params.Set("grant_type", string(GrantClientCredentials))
+	c.setScope(params, flow)
+	return c.requestToken(ctx, flow.TokenURL, params)
+}
+
+// Password acquires the token by the resource owner password credentials flow.
+func (c *Client) Password(ctx context.Context, flow *topi.OAuthFlow) (*Token, error) {
+	if c.Config.Username == "" {
+		return nil, fmt.Errorf("username is not set")
+	}
+	params := url.Values{}
+	params.Set("grant_type", string(GrantPassword))
+	params.Set("username", c.Config.Username)
+	params.Set("password", c.Config.Password)
+	c.setScope(params, flow)
+	return c.requestToken(ctx, flow.TokenURL, params)
+}
+
+func (c *Client) setScope(params url.Values, flow *topi.OAuthFlow) {
+	if scope := strings.Join(c.scopes(flow), " "); scope != "" {
+		params.Set("scope", scope)
+	}
+}
+
+func (c *Client) scopes(flow *topi.OAuthFlow) []string {
+	if len(c.Config.Scopes) > 0 {
+		return c.Config.Scopes
+	}
+	ret := make([]string, len(flow.Scopes))
+	for i, s := range flow.Scopes {
+		ret[i] = s.Name
+	}
+	return ret
+}
+
+type discoveryDocument struct {
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+}
+
+// Discover returns the flow with the endpoints of the OpenID Provider.
+// The url is the discovery document, or the issuer to which "/.well-known/openid-configuration" is added.
+func (c *Client) Discover(ctx context.Context, openIdConnectUrl string) (*topi.OAuthFlow, error) {
+	if openIdConnectUrl == "" {
+		return nil, fmt.Errorf("OpenID Connect URL is not defined")
+	}
+	u := openIdConnectUrl
+	if !strings.Contains(u, "/.well-known/") {
+		u = strings.TrimSuffix(u, "/") + "/.well-known/openid-configuration"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get the discovery document: %s", res.Status)
+	}
+	var doc discoveryDocument
+	if err := json.NewDecoder(res.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid discovery document: %w", err)
+	}
+	flow := &topi.OAuthFlow{
+		AuthorizationURL: doc.AuthorizationEndpoint,
+		TokenURL:         doc.TokenEndpoint,
+		Scopes:           []*topi.Scope{{Name: "openid"}},
+	}
+	return flow, nil
+}
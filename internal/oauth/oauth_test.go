@@ -0,0 +1,419 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lusingander/topi/internal/topi"
+)
+
+// testServer is the stand-in authorization server.
+type testServer struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	challenges map[string]string // code -> code_challenge
+	requests   []url.Values      // token requests
+	basicAuth  [2]string
+	denied     bool // authorization endpoint returns access_denied
+}
+
+func newTestServer(t *testing.T) *testServer {
+	s := &testServer{challenges: make(map[string]string)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/authorize", s.authorize)
+	mux.HandleFunc("/token", s.token)
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 s.URL,
+			"authorization_endpoint": s.URL + "/authorize",
+			"token_endpoint":         s.URL + "/token",
+		})
+	})
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testServer) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	redirect, _ := url.Parse(q.Get("redirect_uri"))
+	params := url.Values{"state": {q.Get("state")}}
+	if s.denied {
+		params.Set("error", "access_denied")
+	} else {
+		s.mu.Lock()
+		s.challenges["code-1"] = q.Get("code_challenge")
+		s.mu.Unlock()
+		params.Set("code", "code-1")
+	}
+	redirect.RawQuery = params.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (s *testServer) token(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	s.mu.Lock()
+	s.requests = append(s.requests, r.PostForm)
+	if id, secret, ok := r.BasicAuth(); ok {
+		s.basicAuth = [2]string{id, secret}
+	}
+	challenge := s.challenges[r.PostForm.Get("code")]
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	fail := func(code string) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": code, "error_description": "test"})
+	}
+	switch r.PostForm.Get("grant_type") {
+	case "client_credentials":
+		if id, secret, _ := r.BasicAuth(); id != "client" || secret != "secret" {
+			fail("invalid_client")
+			return
+		}
+	case "password":
+		if r.PostForm.Get("password") != "pass" {
+			fail("invalid_grant")
+			return
+		}
+	case "authorization_code":
+		if challenge == "" || codeChallenge(r.PostForm.Get("code_verifier")) != challenge {
+			fail("invalid_grant")
+			return
+		}
+	default:
+		fail("unsupported_grant_type")
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"access_token": "token-" + r.PostForm.Get("grant_type"),
+		"token_type":   "bearer",
+		"expires_in":   3600,
+		"scope":        r.PostForm.Get("scope"),
+	})
+}
+
+func (s *testServer) lastRequest() url.Values {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[len(s.requests)-1]
+}
+
+func (s *testServer) scheme() *topi.SecurityScheme {
+	flow := func() *topi.OAuthFlow {
+		return &topi.OAuthFlow{
+			AuthorizationURL: s.URL + "/authorize",
+			TokenURL:         s.URL + "/token",
+			Scopes:           []*topi.Scope{{Name: "read"}, {Name: "write"}},
+		}
+	}
+	return &topi.SecurityScheme{
+		Key:  "oauth",
+		Type: "oauth2",
+		OAuthFlows: &topi.OAtuhFlows{
+			ClientCredentialsFlow:                flow(),
+			ResourceOwnerPasswordCredentialsFlow: flow(),
+			AuthorizatonCodeFlow:                 flow(),
+		},
+	}
+}
+
+// openByClient follows the redirect from the authorization endpoint to the redirect listener, like a browser.
+func openByClient(u string) error {
+	res, err := http.Get(u)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	return nil
+}
+
+func TestClientToken(t *testing.T) {
+	s := newTestServer(t)
+	now := time.Date(2022, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		grant      Grant
+		config     Config
+		want       *Token
+		wantParams url.Values
+	}{
+		{
+			GrantClientCredentials,
+			Config{ClientID: "client", ClientSecret: "secret", Scopes: []string{"read"}},
+			&Token{AccessToken: "token-client_credentials", TokenType: "bearer", Expiry: now.Add(time.Hour), Scopes: []string{"read"}},
+			url.Values{"grant_type": {"client_credentials"}, "scope": {"read"}},
+		},
+		{
+			GrantPassword,
+			Config{ClientID: "public", Username: "user", Password: "pass"},
+			&Token{AccessToken: "token-password", TokenType: "bearer", Expiry: now.Add(time.Hour), Scopes: []string{"read", "write"}},
+			url.Values{"grant_type": {"password"}, "username": {"user"}, "password": {"pass"}, "scope": {"read write"}, "client_id": {"public"}},
+		},
+	}
+	for _, tt := range tests {
+		c := &Client{Config: tt.config, Now: func() time.Time { return now }}
+		got, err := c.Token(context.Background(), s.scheme(), tt.grant)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("got=%v, want=%v", got, tt.want)
+		}
+		if params := s.lastRequest(); !reflect.DeepEqual(params, tt.wantParams) {
+			t.Errorf("got=%v, want=%v", params, tt.wantParams)
+		}
+	}
+}
+
+func TestClientTokenAuthorizationCode(t *testing.T) {
+	s := newTestServer(t)
+	c := &Client{Config: Config{ClientID: "public"}, Open: openByClient}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	got, err := c.Token(ctx, s.scheme(), GrantAuthorizationCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.AccessToken != "token-authorization_code" {
+		t.Errorf("got=%v, want=%v", got.AccessToken, "token-authorization_code")
+	}
+	params := s.lastRequest()
+	if params.Get("code") != "code-1" || params.Get("client_id") != "public" {
+		t.Errorf("unexpected token request: %v", params)
+	}
+	if u, err := url.Parse(params.Get("redirect_uri")); err != nil || u.Hostname() != "localhost" || u.Path != redirectPath {
+		t.Errorf("unexpected redirect_uri: %v", params.Get("redirect_uri"))
+	}
+}
+
+func TestClientTokenAuthorizationCodeStrayRedirect(t *testing.T) {
+	s := newTestServer(t)
+	var strayStatus int
+	open := func(u string) error {
+		authURL, err := url.Parse(u)
+		if err != nil {
+			return err
+		}
+		// the redirect with the other state before the authorization completes
+		redirect, _ := url.Parse(authURL.Query().Get("redirect_uri"))
+		redirect.RawQuery = url.Values{"state": {"other"}, "code": {"code-2"}}.Encode()
+		res, err := http.Get(redirect.String())
+		if err != nil {
+			return err
+		}
+		res.Body.Close()
+		strayStatus = res.StatusCode
+		return openByClient(u)
+	}
+	c := &Client{Config: Config{ClientID: "public"}, Open: open}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	got, err := c.Token(ctx, s.scheme(), GrantAuthorizationCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strayStatus != http.StatusBadRequest {
+		t.Errorf("got=%v, want=%v", strayStatus, http.StatusBadRequest)
+	}
+	if got.AccessToken != "token-authorization_code" {
+		t.Errorf("got=%v, want=%v", got.AccessToken, "token-authorization_code")
+	}
+	if params := s.lastRequest(); params.Get("code") != "code-1" {
+		t.Errorf("got=%v, want=%v", params.Get("code"), "code-1")
+	}
+}
+
+func TestClientTokenErrors(t *testing.T) {
+	s := newTestServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// wrong client secret
+	c := &Client{Config: Config{ClientID: "client", ClientSecret: "wrong"}}
+	_, err := c.Token(ctx, s.scheme(), GrantClientCredentials)
+	var tokenErr *TokenError
+	if !errors.As(err, &tokenErr) || tokenErr.Code != "invalid_client" || tokenErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("got=%v, want=%v", err, "invalid_client")
+	}
+
+	// denied by the user
+	s.denied = true
+	c = &Client{Config: Config{ClientID: "public"}, Open: openByClient}
+	_, err = c.Token(ctx, s.scheme(), GrantAuthorizationCode)
+	if !errors.As(err, &tokenErr) || tokenErr.Code != "access_denied" {
+		t.Errorf("got=%v, want=%v", err, "access_denied")
+	}
+
+	// flow not defined
+	scheme := s.scheme()
+	scheme.OAuthFlows.ClientCredentialsFlow = nil
+	if _, err := c.Token(ctx, scheme, GrantClientCredentials); err == nil {
+		t.Errorf("error is expected")
+	}
+}
+
+func TestClientTokenOpenIdConnect(t *testing.T) {
+	s := newTestServer(t)
+	scheme := &topi.SecurityScheme{Key: "oidc", Type: "openIdConnect", OpenIdConnectUrl: s.URL}
+	c := &Client{Config: Config{ClientID: "client", ClientSecret: "secret"}}
+
+	got, err := c.Token(context.Background(), scheme, GrantClientCredentials)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.AccessToken != "token-client_credentials" {
+		t.Errorf("got=%v, want=%v", got.AccessToken, "token-client_credentials")
+	}
+	if s.basicAuth != [2]string{"client", "secret"} {
+		t.Errorf("got=%v, want=%v", s.basicAuth, [2]string{"client", "secret"})
+	}
+	if scope := s.lastRequest().Get("scope"); scope != "openid" {
+		t.Errorf("got=%v, want=%v", scope, "openid")
+	}
+}
+
+func TestStore(t *testing.T) {
+	now := time.Date(2022, 7, 1, 12, 0, 0, 0, time.UTC)
+	s := NewStore()
+	s.now = func() time.Time { return now }
+	s.Set("oauth", &Token{AccessToken: "abc", TokenType: "bearer", Expiry: now.Add(time.Minute)})
+	s.Set("mac", &Token{AccessToken: "def", TokenType: "MAC"})
+	s.Set("expired", &Token{AccessToken: "ghi", Expiry: now})
+
+	tests := []struct {
+		key  string
+		want string // empty if no valid token is stored
+	}{
+		{"oauth", "Bearer abc"},
+		{"mac", "MAC def"},
+		{"expired", ""},
+		{"unknown", ""},
+	}
+	for _, tt := range tests {
+		var got string
+		if token := s.Get(tt.key); token != nil {
+			got = token.AuthorizationHeader()
+		}
+		if got != tt.want {
+			t.Errorf("got=%v, want=%v", got, tt.want)
+		}
+	}
+}
+
+func TestGrantOf(t *testing.T) {
+	flow := &topi.OAuthFlow{TokenURL: "https://example.com/token"}
+	tests := []struct {
+		scheme  *topi.SecurityScheme
+		config  Config
+		want    Grant
+		wantErr bool
+	}{
+		{
+			&topi.SecurityScheme{Key: "oauth", Type: "oauth2", OAuthFlows: &topi.OAtuhFlows{ClientCredentialsFlow: flow, AuthorizatonCodeFlow: flow}},
+			Config{ClientID: "client", ClientSecret: "secret"},
+			GrantAuthorizationCode,
+			false,
+		},
+		{
+			&topi.SecurityScheme{Key: "oauth", Type: "oauth2", OAuthFlows: &topi.OAtuhFlows{ClientCredentialsFlow: flow, ResourceOwnerPasswordCredentialsFlow: flow}},
+			Config{ClientID: "client", ClientSecret: "secret"},
+			GrantClientCredentials,
+			false,
+		},
+		{
+			&topi.SecurityScheme{Key: "oauth", Type: "oauth2", OAuthFlows: &topi.OAtuhFlows{ClientCredentialsFlow: flow, ResourceOwnerPasswordCredentialsFlow: flow}},
+			Config{ClientID: "public"},
+			GrantPassword,
+			false,
+		},
+		{
+			&topi.SecurityScheme{Key: "oauth", Type: "oauth2", OAuthFlows: &topi.OAtuhFlows{ClientCredentialsFlow: flow}},
+			Config{ClientID: "client", Grant: GrantPassword},
+			GrantPassword,
+			false,
+		},
+		{
+			&topi.SecurityScheme{Key: "oauth", Type: "oauth2", OAuthFlows: &topi.OAtuhFlows{ImplicitFlow: flow}},
+			Config{ClientID: "client"},
+			"",
+			true,
+		},
+		{
+			&topi.SecurityScheme{Key: "oauth", Type: "oauth2"},
+			Config{ClientID: "client"},
+			"",
+			true,
+		},
+		{
+			&topi.SecurityScheme{Key: "oidc", Type: "openIdConnect"},
+			Config{ClientID: "client"},
+			GrantAuthorizationCode,
+			false,
+		},
+		{
+			&topi.SecurityScheme{Key: "api_key", Type: "apiKey"},
+			Config{ClientID: "client"},
+			"",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		got, err := GrantOf(tt.scheme, tt.config)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("error is expected: %v", tt.config)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("got=%v, want=%v", got, tt.want)
+		}
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		config  Config
+		wantErr bool
+	}{
+		{Config{ClientID: "client"}, false},
+		{Config{ClientID: "client", ClientSecret: "secret", Grant: GrantClientCredentials}, false},
+		{Config{ClientID: "client", Username: "user", Grant: GrantPassword}, false},
+		{Config{ClientID: "client", Grant: GrantAuthorizationCode, RedirectPort: 8080}, false},
+		{Config{}, true},
+		{Config{ClientID: "client", Grant: "implicit"}, true},
+		{Config{ClientID: "client", Grant: GrantClientCredentials}, true},
+		{Config{ClientID: "client", Grant: GrantPassword}, true},
+		{Config{ClientID: "client", RedirectPort: -1}, true},
+	}
+	for _, tt := range tests {
+		err := tt.config.Validate()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%v: got=%v, wantErr=%v", tt.config, err, tt.wantErr)
+		}
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	t.Setenv("TOPI_TEST_SECRET", "secret")
+	c := NewClient(Config{ClientID: "client", ClientSecret: "${TOPI_TEST_SECRET}", Password: "$TOPI_TEST_SECRET"})
+	if c.Config.ClientSecret != "secret" || c.Config.Password != "secret" {
+		t.Errorf("got=%v, want=%v", c.Config, "secret")
+	}
+}
@@ -0,0 +1,149 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/lusingander/topi/internal/topi"
+)
+
+const redirectPath = "/callback"
+
+// AuthorizationCode acquires the token by the authorization code flow with PKCE (RFC 7636).
+// The authorization URL is opened by Open, and the code is received by the listener on localhost
+// until the redirect arrives or the context is done.
+func (c *Client) AuthorizationCode(ctx context.Context, flow *topi.OAuthFlow) (*Token, error) {
+	if flow.AuthorizationURL == "" {
+		return nil, fmt.Errorf("authorization URL is not defined")
+	}
+	if c.Open == nil {
+		return nil, fmt.Errorf("no way to open the authorization URL")
+	}
+	verifier, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	state, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", c.Config.RedirectPort))
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for the redirect: %w", err)
+	}
+	redirectURL := fmt.Sprintf("http://localhost:%d%s", l.Addr().(*net.TCPAddr).Port, redirectPath)
+
+	results := make(chan callbackResult, 1)
+	srv := &http.Server{Handler: callbackHandler(state, results)}
+	go srv.Serve(l)
+	defer srv.Close()
+
+	authURL, err := authorizationURL(flow.AuthorizationURL, url.Values{
+		"response_type":         {"code"},
+		"client_id":             {c.Config.ClientID},
+		"redirect_uri":          {redirectURL},
+		"state":                 {state},
+		"code_challenge":        {codeChallenge(verifier)},
+		"code_challenge_method": {"S256"},
+	}, c.scopes(flow))
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Open(authURL); err != nil {
+		return nil, fmt.Errorf("failed to open the authorization URL: %w", err)
+	}
+
+	var code string
+	select {
+	case r := <-results:
+		if r.err != nil {
+			return nil, r.err
+		}
+		code = r.code
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	params := url.Values{}
+	params.Set("grant_type", string(GrantAuthorizationCode))
+	params.Set("code", code)
+	params.Set("redirect_uri", redirectURL)
+	params.Set("code_verifier", verifier)
+	return c.requestToken(ctx, flow.TokenURL, params)
+}
+
+func authorizationURL(base string, params url.Values, scopes []string) (string, error) {
+	u, err := url.Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization URL: %w", err)
+	}
+	q := u.Query()
+	for k, vs := range params {
+		q[k] = vs
+	}
+	if len(scopes) > 0 {
+		q.Set("scope", strings.Join(scopes, " "))
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+type callbackResult struct {
+	code string
+	err  error
+}
+
+// callbackHandler receives the redirect from the authorization server and sends the result once.
+// The requests with the other state (e.g. stale or forged redirects) are rejected without ending the flow.
+func callbackHandler(state string, results chan<- callbackResult) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(redirectPath, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("state") != state {
+			http.Error(w, "Authorization failed: state of the redirect does not match", http.StatusBadRequest)
+			return
+		}
+		var result callbackResult
+		switch {
+		case q.Get("error") != "":
+			result.err = &TokenError{Code: q.Get("error"), Description: q.Get("error_description")}
+		case q.Get("code") == "":
+			result.err = errors.New("code is not found in the redirect")
+		default:
+			result.code = q.Get("code")
+		}
+		if result.err != nil {
+			http.Error(w, fmt.Sprintf("Authorization failed: %s", result.err), http.StatusBadRequest)
+		} else {
+			fmt.Fprintln(w, "Authorization completed. You can close this window.")
+		}
+		select {
+		case results <- result:
+		default: // already received
+		}
+	})
+	return mux
+}
+
+// randomString returns the string of 43 unreserved characters, used as the code verifier and the state.
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func codeChallenge(verifier string) string {
+	h := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(h[:])
+}
@@ -0,0 +1,49 @@
+package oauth
+
+import (
+	"sync"
+	"time"
+)
+
+// Store keeps the tokens per security scheme in memory, they are not saved to the files.
+type Store struct {
+	mu     sync.Mutex
+	tokens map[string]*Token // key of the security scheme -> token
+	now    func() time.Time
+}
+
+func NewStore() *Store {
+	return &Store{
+		tokens: make(map[string]*Token),
+		now:    time.Now,
+	}
+}
+
+// Set stores the token of the security scheme, replacing the previous one.
+func (s *Store) Set(key string, t *Token) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[key] = t
+}
+
+// Get returns the token of the security scheme, or nil if not stored or expired.
+func (s *Store) Get(key string) *Token {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.tokens[key]
+	if !ok {
+		return nil
+	}
+	if t.Expired(s.now()) {
+		delete(s.tokens, key)
+		return nil
+	}
+	return t
+}
+
+// Delete removes the token of the security scheme.
+func (s *Store) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tokens, key)
+}
@@ -0,0 +1,136 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Token is the access token issued by the token endpoint.
+type Token struct {
+	AccessToken  string
+	TokenType    string
+	RefreshToken string
+	Expiry       time.Time // zero if the expiration is not known
+	Scopes       []string  // granted scopes, nil if the server does not return them
+}
+
+// Expired reports whether the token is expired at the time.
+func (t *Token) Expired(now time.Time) bool {
+	return !t.Expiry.IsZero() && !now.Before(t.Expiry)
+}
+
+// AuthorizationHeader returns the value of the Authorization header to send the token.
+func (t *Token) AuthorizationHeader() string {
+	typ := t.TokenType
+	if typ == "" || strings.EqualFold(typ, "bearer") {
+		typ = "Bearer"
+	}
+	return typ + " " + t.AccessToken
+}
+
+// TokenError is the error response of the token endpoint (RFC 6749 section 5.2).
+type TokenError struct {
+	StatusCode  int
+	Code        string
+	Description string
+}
+
+func (e *TokenError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("token request failed: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	if e.Description == "" {
+		return fmt.Sprintf("token request failed: %s", e.Code)
+	}
+	return fmt.Sprintf("token request failed: %s: %s", e.Code, e.Description)
+}
+
+type tokenResponse struct {
+	AccessToken  string      `json:"access_token"`
+	TokenType    string      `json:"token_type"`
+	RefreshToken string      `json:"refresh_token"`
+	ExpiresIn    json.Number `json:"expires_in"`
+	Scope        string      `json:"scope"`
+
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// requestToken posts the parameters to the token endpoint and returns the issued token.
+func (c *Client) requestToken(ctx context.Context, tokenURL string, params url.Values) (*Token, error) {
+	if tokenURL == "" {
+		return nil, fmt.Errorf("token URL is not defined")
+	}
+	if c.Config.ClientSecret == "" {
+		// public client identifies itself by the parameter
+		params.Set("client_id", c.Config.ClientID)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if c.Config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(c.Config.ClientID), url.QueryEscape(c.Config.ClientSecret))
+	}
+
+	res, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	return parseTokenResponse(res, body, c.now())
+}
+
+func parseTokenResponse(res *http.Response, body []byte, now time.Time) (*Token, error) {
+	var tr tokenResponse
+	if mt, _, _ := mime.ParseMediaType(res.Header.Get("Content-Type")); mt == "application/x-www-form-urlencoded" || mt == "text/plain" {
+		// some servers (e.g. GitHub) return the form by default
+		vs, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, err
+		}
+		tr = tokenResponse{
+			AccessToken:      vs.Get("access_token"),
+			TokenType:        vs.Get("token_type"),
+			RefreshToken:     vs.Get("refresh_token"),
+			ExpiresIn:        json.Number(vs.Get("expires_in")),
+			Scope:            vs.Get("scope"),
+			Error:            vs.Get("error"),
+			ErrorDescription: vs.Get("error_description"),
+		}
+	} else if err := json.Unmarshal(body, &tr); err != nil && res.StatusCode == http.StatusOK {
+		return nil, fmt.Errorf("invalid token response: %w", err)
+	}
+
+	if res.StatusCode != http.StatusOK || tr.Error != "" {
+		return nil, &TokenError{StatusCode: res.StatusCode, Code: tr.Error, Description: tr.ErrorDescription}
+	}
+	if tr.AccessToken == "" {
+		return nil, fmt.Errorf("invalid token response: access_token is empty")
+	}
+	t := &Token{
+		AccessToken:  tr.AccessToken,
+		TokenType:    tr.TokenType,
+		RefreshToken: tr.RefreshToken,
+	}
+	if n, err := tr.ExpiresIn.Int64(); err == nil && n > 0 {
+		t.Expiry = now.Add(time.Duration(n) * time.Second)
+	}
+	if tr.Scope != "" {
+		t.Scopes = strings.Fields(tr.Scope)
+	}
+	return t, nil
+}
@@ -2,25 +2,105 @@ package openapi
 
 import (
 	"context"
+	"io"
+	"net/http"
 	"net/url"
+	"os"
 	"path"
 	"path/filepath"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/lusingander/topi/internal/cache"
 	"github.com/lusingander/topi/internal/topi"
 )
 
+// Loader loads the specs.
+type Loader struct {
+	// Client is used to load the remote specs and external refs, http.DefaultClient if nil.
+	Client *http.Client
+	// Cache keeps the remote specs and external refs to use offline, not used if nil.
+	Cache *cache.Cache
+}
+
+// Load loads the spec with the default HTTP client.
 func Load(path string) (*topi.Document, error) {
-	ctx := context.Background()
-	loader := openapi3.Loader{
-		Context:               ctx,
-		IsExternalRefsAllowed: true,
+	return (&Loader{}).Load(path)
+}
+
+func (l *Loader) readFromURI(st *remoteStatus) openapi3.ReadFromURIFunc {
+	client := l.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if l.Cache == nil {
+		return openapi3.ReadFromURIs(openapi3.ReadFromHTTP(client), openapi3.ReadFromFile)
+	}
+	return openapi3.ReadFromURIs(st.readFromCache(l.Cache, client), openapi3.ReadFromFile)
+}
+
+// remoteStatus is the state of the remote documents read while loading a spec.
+type remoteStatus struct {
+	stale    bool
+	cachedAt time.Time
+}
+
+func (s *remoteStatus) readFromCache(c *cache.Cache, client *http.Client) openapi3.ReadFromURIFunc {
+	return func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+		if location.Scheme == "" || location.Host == "" {
+			return nil, openapi3.ErrURINotSupported
+		}
+		r, err := c.Fetch(client, location.String())
+		if err != nil {
+			return nil, err
+		}
+		if r.Stale {
+			if !s.stale || r.FetchedAt.Before(s.cachedAt) {
+				s.cachedAt = r.FetchedAt
+			}
+			s.stale = true
+		}
+		return r.Data, nil
+	}
+}
+
+func (s *remoteStatus) setMeta(meta *topi.Meta) {
+	meta.Stale = s.stale
+	meta.CachedAt = s.cachedAt
+}
+
+func isRemote(uri *url.URL) bool {
+	return uri.Scheme == "http" || uri.Scheme == "https"
+}
+
+// Load loads the spec from the file path or URL.
+// The path can also be StdinPath to read from stdin, or "git:REV:path" to read the file at the Git revision.
+func (l *Loader) Load(path string) (*topi.Document, error) {
+	switch {
+	case path == StdinPath:
+		return l.loadStdin(os.Stdin)
+	case strings.HasPrefix(path, gitPrefix):
+		return l.loadGit(path)
 	}
 
+	ctx := context.Background()
+	st := &remoteStatus{}
+	loader, sources := newLoader(ctx, l.readFromURI(st))
+
 	if uri, err := url.ParseRequestURI(path); err == nil {
-		if doc, err := loader.LoadFromURI(uri); err == nil {
-			return convert(path, doc), nil
+		doc, err := loader.LoadFromURI(uri)
+		if err == nil {
+			ret := convert(path, doc)
+			ret.ValidationErrors = validate(ctx, doc, sources.get(uri))
+			annotateSources(ret, newSourceMap(sources.sources, nil), uri)
+			st.setMeta(ret.Meta)
+			return ret, nil
+		}
+		if isRemote(uri) {
+			// not to hide the error (e.g. unauthorized) by loading as a file
+			return nil, err
 		}
 	}
 
@@ -32,16 +112,139 @@ func Load(path string) (*topi.Document, error) {
 	if err != nil {
 		return nil, err
 	}
-	return convert(fp, doc), nil
+	location := &url.URL{Path: filepath.ToSlash(fp)}
+	ret := convert(fp, doc)
+	ret.ValidationErrors = validate(ctx, doc, sources.get(location))
+	annotateSources(ret, newSourceMap(sources.sources, nil), location)
+	st.setMeta(ret.Meta)
+	return ret, nil
+}
+
+func newLoader(ctx context.Context, reader openapi3.ReadFromURIFunc) (*openapi3.Loader, *sourceRecorder) {
+	sources := newSourceRecorder(reader)
+	loader := &openapi3.Loader{
+		Context:               ctx,
+		IsExternalRefsAllowed: true,
+		ReadFromURIFunc:       sources.read,
+	}
+	return loader, sources
+}
+
+// loadStdin loads the spec read from r. Relative refs are resolved from the current directory.
+func (l *Loader) loadStdin(r io.Reader) (*topi.Document, error) {
+	bs, err := readSource(r)
+	if err != nil {
+		return nil, err
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	st := &remoteStatus{}
+	loader, sources := newLoader(ctx, l.readFromURI(st))
+	location := &url.URL{Path: filepath.ToSlash(filepath.Join(wd, StdinPath))}
+	doc, err := loader.LoadFromDataWithPath(bs, location)
+	if err != nil {
+		return nil, err
+	}
+	sources.add(location, bs)
+	ret := convert(StdinPath, doc)
+	ret.Meta.FileName = "stdin"
+	ret.ValidationErrors = validate(ctx, doc, bs)
+	annotateSources(ret, newSourceMap(sources.sources, func(u *url.URL) (string, bool) {
+		if *u == *location {
+			return ret.Meta.FileName, false
+		}
+		return localFile(u)
+	}), location)
+	st.setMeta(ret.Meta)
+	return ret, nil
+}
+
+// loadGit loads the spec at the Git revision. Relative refs are resolved from the same revision.
+func (l *Loader) loadGit(path string) (*topi.Document, error) {
+	src, err := parseGitSource(path)
+	if err != nil {
+		return nil, err
+	}
+	bs, err := src.show(src.path)
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	st := &remoteStatus{}
+	loader, sources := newLoader(ctx, openapi3.ReadFromURIs(src.read, l.readFromURI(st)))
+	doc, err := loader.LoadFromDataWithPath(bs, src.location())
+	if err != nil {
+		return nil, err
+	}
+	sources.add(src.location(), bs)
+	ret := convert(path, doc)
+	ret.Meta.FileName = src.fileName()
+	ret.ValidationErrors = validate(ctx, doc, bs)
+	annotateSources(ret, newSourceMap(sources.sources, src.file), src.location())
+	st.setMeta(ret.Meta)
+	return ret, nil
+}
+
+// sourceRecorder keeps the raw contents read by the loader.
+type sourceRecorder struct {
+	reader  openapi3.ReadFromURIFunc
+	sources map[string][]byte
+}
+
+func newSourceRecorder(reader openapi3.ReadFromURIFunc) *sourceRecorder {
+	return &sourceRecorder{
+		reader:  reader,
+		sources: make(map[string][]byte),
+	}
+}
+
+func (r *sourceRecorder) read(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+	bs, err := r.reader(loader, location)
+	if err != nil {
+		return nil, err
+	}
+	r.sources[location.String()] = bs
+	return bs, nil
+}
+
+// add adds the contents not read by the loader, such as the root document loaded from data.
+func (r *sourceRecorder) add(location *url.URL, bs []byte) {
+	r.sources[location.String()] = bs
+}
+
+func (r *sourceRecorder) get(location *url.URL) []byte {
+	return r.sources[location.String()]
 }
 
 func convert(filepath string, t *openapi3.T) *topi.Document {
 	meta := convertMeta(filepath)
 	info := convertInfo(t.OpenAPI, t.Info, t.ExternalDocs)
-	paths := convertPaths(t.Paths)
+	security := convertSecurityRequirements(t.Security)
+	paths := convertPaths(t.Paths, security)
 	tags := convertTags(t.Tags)
 	components := convertComponents(&t.Components)
-	return topi.NewDocument(meta, info, paths, tags, components)
+	doc := topi.NewDocument(meta, info, paths, tags, components)
+	doc.Servers = convertServers(t.Servers)
+	doc.Security = security
+	return doc
+}
+
+func convertServers(servers openapi3.Servers) []*topi.Server {
+	ret := make([]*topi.Server, 0, len(servers))
+	for _, s := range servers {
+		if s == nil {
+			continue
+		}
+		server := &topi.Server{
+			Url:         s.URL,
+			Description: s.Description,
+		}
+		ret = append(ret, server)
+	}
+	return ret
 }
 
 func convertMeta(filepath string) *topi.Meta {
@@ -52,6 +255,10 @@ func convertMeta(filepath string) *topi.Meta {
 }
 
 func convertInfo(openapi string, info *openapi3.Info, exDocs *openapi3.ExternalDocs) *topi.Info {
+	if info == nil {
+		// invalid, but continue to show other parts
+		info = &openapi3.Info{}
+	}
 	contactName, contactUrl, contactEmail := convertInfoContact(info.Contact)
 	licenseName, licenseUrl := convertInfoLicense(info.License)
 	exDocsDesc, exDocsUrl := convertExternalDocs(exDocs)
@@ -98,19 +305,19 @@ func convertExternalDocs(d *openapi3.ExternalDocs) (desc, url string) {
 	return
 }
 
-func convertPaths(paths openapi3.Paths) map[string][]*topi.Path {
+func convertPaths(paths openapi3.Paths, security []*topi.SecurityRequirement) map[string][]*topi.Path {
 	ret := make(map[string][]*topi.Path)
 	for k, v := range paths {
-		items := convertPathItem(v, k)
+		items := convertPathItem(v, k, security)
 		ret = mergeMap(ret, items)
 	}
 	return ret
 }
 
-func convertPathItem(pathItem *openapi3.PathItem, uriPath string) map[string][]*topi.Path {
+func convertPathItem(pathItem *openapi3.PathItem, uriPath string, security []*topi.SecurityRequirement) map[string][]*topi.Path {
 	ret := make(map[string][]*topi.Path)
 	for method, op := range pathItem.Operations() {
-		path := convertOperation(pathItem, op, method, uriPath)
+		path := convertOperation(pathItem, op, method, uriPath, security)
 		tag := getTag(op)
 		if _, ok := ret[tag]; !ok {
 			ret[tag] = make([]*topi.Path, 0)
@@ -127,10 +334,11 @@ func getTag(op *openapi3.Operation) string {
 	return op.Tags[0]
 }
 
-func convertOperation(pathItem *openapi3.PathItem, op *openapi3.Operation, method, uriPath string) *topi.Path {
-	params := op.Parameters
-	if len(params) == 0 {
-		params = pathItem.Parameters
+func convertOperation(pathItem *openapi3.PathItem, op *openapi3.Operation, method, uriPath string, security []*topi.SecurityRequirement) *topi.Path {
+	params := mergeParameters(pathItem.Parameters, op.Parameters)
+	var declared []*topi.SecurityRequirement
+	if op.Security != nil {
+		declared = convertSecurityRequirements(*op.Security)
 	}
 
 	ret := &topi.Path{
@@ -146,8 +354,8 @@ func convertOperation(pathItem *openapi3.PathItem, op *openapi3.Operation, metho
 		CookieParameters: convertParameters(params, "cookie"),
 		RequestBody:      convertRequestBody(op.RequestBody),
 		Responses:        convertResponses(op.Responses),
-		Security:         convertSecurityRequirements(op.Security),
 	}
+	ret.Security, ret.SecurityOrigin = topi.EffectiveSecurity(declared, security)
 	return ret
 }
 
@@ -170,9 +378,25 @@ func mergeMap(m1, m2 map[string][]*topi.Path) map[string][]*topi.Path {
 	return ret
 }
 
+// mergeParameters returns the parameters defined at the path item level and the operation level.
+// The parameters of the operation override the ones of the path item with the same name and location.
+func mergeParameters(pathParams, opParams openapi3.Parameters) openapi3.Parameters {
+	ret := make(openapi3.Parameters, 0, len(pathParams)+len(opParams))
+	for _, p := range pathParams {
+		if p.Value != nil && opParams.GetByInAndName(p.Value.In, p.Value.Name) != nil {
+			continue
+		}
+		ret = append(ret, p)
+	}
+	return append(ret, opParams...)
+}
+
 func convertParameters(params openapi3.Parameters, in string) []*topi.Parameter {
 	ret := make([]*topi.Parameter, 0)
 	for _, param := range params {
+		if param.Value == nil {
+			continue
+		}
 		if param.Value.In == in {
 			p := &topi.Parameter{
 				Name:        param.Value.Name,
@@ -181,6 +405,14 @@ func convertParameters(params openapi3.Parameters, in string) []*topi.Parameter
 				Required:    param.Value.Required,
 				Deprecated:  param.Value.Deprecated,
 				Schema:      convertSchema(param.Value.Schema),
+
+				Style:           param.Value.Style,
+				Explode:         param.Value.Explode,
+				AllowEmptyValue: param.Value.AllowEmptyValue,
+				AllowReserved:   param.Value.AllowReserved,
+				Example:         param.Value.Example,
+				Examples:        convertExamples(param.Value.Examples),
+				Content:         convertContent(param.Value.Content),
 			}
 			ret = append(ret, p)
 		}
@@ -188,12 +420,32 @@ func convertParameters(params openapi3.Parameters, in string) []*topi.Parameter
 	return ret
 }
 
+func convertExamples(examples openapi3.Examples) []*topi.Example {
+	ret := make([]*topi.Example, 0)
+	for _, k := range sortedKeys(examples) {
+		v := examples[k]
+		if v == nil || v.Value == nil {
+			continue
+		}
+		e := &topi.Example{
+			Name:          k,
+			Summary:       v.Value.Summary,
+			Description:   v.Value.Description,
+			Value:         v.Value.Value,
+			ExternalValue: v.Value.ExternalValue,
+		}
+		ret = append(ret, e)
+	}
+	return ret
+}
+
 func convertSchema(schema *openapi3.SchemaRef) *topi.Schema {
 	if schema == nil || schema.Value == nil {
 		return nil
 	}
 	sc := schema.Value
 	return &topi.Schema{
+		Ref:          schemaRefName(schema.Ref),
 		Type:         sc.Type,
 		Format:       sc.Format,
 		Default:      sc.Default,
@@ -217,9 +469,34 @@ func convertSchema(schema *openapi3.SchemaRef) *topi.Schema {
 		Items:        convertSchema(sc.Items),
 		Required:     sc.Required,
 		Properties:   convertSchemas(sc.Properties),
+		Example:      sc.Example,
+		XML:          convertXML(sc.XML),
 	}
 }
 
+func convertXML(x *openapi3.XML) *topi.XML {
+	if x == nil {
+		return nil
+	}
+	return &topi.XML{
+		Name:      x.Name,
+		Namespace: x.Namespace,
+		Prefix:    x.Prefix,
+		Attribute: x.Attribute,
+		Wrapped:   x.Wrapped,
+	}
+}
+
+const componentsSchemasRefPrefix = "#/components/schemas/"
+
+func schemaRefName(ref string) string {
+	i := strings.Index(ref, componentsSchemasRefPrefix)
+	if i < 0 {
+		return ""
+	}
+	return ref[i+len(componentsSchemasRefPrefix):]
+}
+
 func convertSchemas(s openapi3.Schemas) map[string]*topi.Schema {
 	ret := make(map[string]*topi.Schema)
 	for k, v := range s {
@@ -254,18 +531,48 @@ func convertContent(content openapi3.Content) []*topi.MediaTypeContent {
 		c := &topi.MediaTypeContent{
 			MediaType: k,
 			Schema:    convertSchema(v.Schema),
+			Encoding:  convertEncoding(v.Encoding),
 		}
 		ret = append(ret, c)
 	}
+	// sort to fix order because openapi3.Content is map
+	sort.Slice(ret, func(i, j int) bool { return ret[i].MediaType < ret[j].MediaType })
+	return ret
+}
+
+func convertEncoding(encoding map[string]*openapi3.Encoding) []*topi.Encoding {
+	ret := make([]*topi.Encoding, 0)
+	for _, k := range sortedKeys(encoding) {
+		e := encoding[k]
+		if e == nil {
+			continue
+		}
+		enc := &topi.Encoding{
+			Property:      k,
+			ContentType:   e.ContentType,
+			Headers:       convertHeaders(e.Headers),
+			Style:         e.Style,
+			Explode:       e.Explode,
+			AllowReserved: e.AllowReserved,
+		}
+		ret = append(ret, enc)
+	}
 	return ret
 }
 
 func convertResponses(responses openapi3.Responses) []*topi.Response {
 	ret := make([]*topi.Response, 0)
 	for status, response := range responses {
+		if response.Value == nil {
+			continue
+		}
+		desc := ""
+		if response.Value.Description != nil { // required, but may be missing in invalid spec
+			desc = *response.Value.Description
+		}
 		r := &topi.Response{
 			StatusCode:  status,
-			Description: *response.Value.Description, // required
+			Description: desc,
 			Conetnt:     convertContent(response.Value.Content),
 			Headers:     convertHeaders(response.Value.Headers),
 		}
@@ -279,6 +586,9 @@ func convertResponses(responses openapi3.Responses) []*topi.Response {
 func convertHeaders(headers openapi3.Headers) []*topi.Header {
 	ret := make([]*topi.Header, 0)
 	for k, v := range headers {
+		if v.Value == nil {
+			continue
+		}
 		p := &topi.Parameter{
 			// name/in must not be specified
 			Description: v.Value.Description,
@@ -292,6 +602,8 @@ func convertHeaders(headers openapi3.Headers) []*topi.Header {
 		}
 		ret = append(ret, h)
 	}
+	// sort to fix order because openapi3.Headers is map
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Name < ret[j].Name })
 	return ret
 }
 
@@ -307,20 +619,20 @@ func convertTags(tags openapi3.Tags) []*topi.Tag {
 	return ret
 }
 
-func convertSecurityRequirements(rs *openapi3.SecurityRequirements) []*topi.SecurityRequirement {
-	// fixme: use instead if OpenAPI Object has Security Requirement Object
+// convertSecurityRequirements returns nil if the requirements are not declared, and empty if declared as empty.
+func convertSecurityRequirements(rs openapi3.SecurityRequirements) []*topi.SecurityRequirement {
 	if rs == nil {
 		return nil
 	}
 	ret := make([]*topi.SecurityRequirement, 0)
-	for _, r := range *rs {
+	for _, r := range rs {
 		req := &topi.SecurityRequirement{
 			Schemes: make([]*topi.SecurityRequirementScheme, 0),
 		}
-		for k, v := range r {
+		for _, k := range sortedKeys(r) {
 			s := &topi.SecurityRequirementScheme{
 				Key:    k,
-				Scopes: v,
+				Scopes: r[k],
 			}
 			req.Schemes = append(req.Schemes, s)
 		}
@@ -331,13 +643,18 @@ func convertSecurityRequirements(rs *openapi3.SecurityRequirements) []*topi.Secu
 
 func convertComponents(components *openapi3.Components) *topi.Components {
 	return &topi.Components{
+		Schemas:         convertSchemas(components.Schemas),
 		SecuritySchemes: convertSecuritySchemes(components.SecuritySchemes),
 	}
 }
 
 func convertSecuritySchemes(schemes openapi3.SecuritySchemes) []*topi.SecurityScheme {
 	ret := make([]*topi.SecurityScheme, 0)
-	for k, v := range schemes {
+	for _, k := range sortedKeys(schemes) {
+		v := schemes[k]
+		if v.Value == nil {
+			continue
+		}
 		s := &topi.SecurityScheme{
 			Key:              k,
 			Type:             v.Value.Type,
@@ -380,10 +697,10 @@ func convertOAtuhFlow(flow *openapi3.OAuthFlow) *topi.OAuthFlow {
 
 func convertScopes(scopes map[string]string) []*topi.Scope {
 	ret := make([]*topi.Scope, 0)
-	for k, v := range scopes {
+	for _, k := range sortedKeys(scopes) {
 		s := &topi.Scope{
 			Name:   k,
-			Detail: v,
+			Detail: scopes[k],
 		}
 		ret = append(ret, s)
 	}
@@ -1,9 +1,11 @@
 package openapi
 
 import (
+	"path/filepath"
 	"reflect"
 	"testing"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/lusingander/topi/internal/topi"
 )
 
@@ -47,3 +49,105 @@ func TestMergeMap(t *testing.T) {
 		t.Errorf("got=%v, want=%v", got, want)
 	}
 }
+
+func TestMergeParameters(t *testing.T) {
+	param := func(name, in, desc string) *openapi3.ParameterRef {
+		return &openapi3.ParameterRef{Value: &openapi3.Parameter{Name: name, In: in, Description: desc}}
+	}
+	tests := []struct {
+		pathParams openapi3.Parameters
+		opParams   openapi3.Parameters
+		want       openapi3.Parameters
+	}{
+		{
+			pathParams: nil,
+			opParams:   nil,
+			want:       openapi3.Parameters{},
+		},
+		{
+			pathParams: openapi3.Parameters{param("id", "path", "")},
+			opParams:   nil,
+			want:       openapi3.Parameters{param("id", "path", "")},
+		},
+		{
+			pathParams: openapi3.Parameters{param("id", "path", "")},
+			opParams:   openapi3.Parameters{param("limit", "query", "")},
+			want:       openapi3.Parameters{param("id", "path", ""), param("limit", "query", "")},
+		},
+		{
+			pathParams: openapi3.Parameters{param("id", "path", "path item"), param("id", "query", "path item")},
+			opParams:   openapi3.Parameters{param("id", "path", "operation")},
+			want:       openapi3.Parameters{param("id", "query", "path item"), param("id", "path", "operation")},
+		},
+	}
+	for _, test := range tests {
+		got := mergeParameters(test.pathParams, test.opParams)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+}
+
+func TestLoadSecurity(t *testing.T) {
+	dir := writeSourceMapTestFiles(t, map[string]string{
+		"openapi.yaml": `openapi: 3.0.3
+info:
+  title: Pet API
+  version: 1.0.0
+security:
+  - api_key: []
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: ok
+    post:
+      operationId: addPet
+      security:
+        - oauth: [write]
+      responses:
+        "200":
+          description: ok
+  /health:
+    get:
+      operationId: health
+      security: []
+      responses:
+        "200":
+          description: ok
+`,
+	})
+	doc, err := Load(filepath.Join(dir, "openapi.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Security) != 1 || doc.Security[0].Schemes[0].Key != "api_key" {
+		t.Errorf("got=%v, want=%v", doc.Security, "api_key")
+	}
+	tests := []struct {
+		operationId string
+		want        []string
+		wantOrigin  topi.SecurityOrigin
+	}{
+		{"listPets", []string{"api_key"}, topi.SecurityInherited},
+		{"addPet", []string{"oauth"}, topi.SecurityDeclared},
+		{"health", []string{}, topi.SecurityOverridden},
+	}
+	for _, tt := range tests {
+		p := doc.FindPathByOperationId(tt.operationId)
+		got := make([]string, 0)
+		for _, r := range p.Security {
+			for _, s := range r.Schemes {
+				got = append(got, s.Key)
+			}
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("got=%v, want=%v", got, tt.want)
+		}
+		if p.SecurityOrigin != tt.wantOrigin {
+			t.Errorf("got=%v, want=%v", p.SecurityOrigin, tt.wantOrigin)
+		}
+	}
+}
@@ -0,0 +1,354 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/lusingander/topi/internal/topi"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	formatYaml = "yaml"
+	formatJson = "json"
+)
+
+var _ topi.SourceReader = (*sourceMap)(nil)
+
+// Raw returns the contents of the node at the source, in the format of the file.
+// The contents are sliced from the file as they are written, unless the refs are replaced by deref.
+func (m *sourceMap) Raw(src *topi.Source, deref bool) (string, string, error) {
+	location, ok := m.locations[src.File]
+	if !ok {
+		return "", "", fmt.Errorf("source not found: %s", src.File)
+	}
+	n := m.pointed(location, src.Pointer)
+	if n == nil {
+		return "", "", fmt.Errorf("node not found: %s", src)
+	}
+	format := m.format(location)
+	if !deref || !containsRef(n.node) {
+		if s, ok := sliceSource(m.sources[location.String()], n.node, format); ok {
+			return s, format, nil
+		}
+	}
+	node := n.node
+	if deref {
+		node = m.inline(n, make(map[string]bool))
+	}
+	var s string
+	var err error
+	if format == formatJson {
+		s, err = encodeJson(node)
+	} else {
+		s, err = encodeYaml(node)
+	}
+	if err != nil {
+		return "", "", err
+	}
+	return s, format, nil
+}
+
+func containsRef(node *yaml.Node) bool {
+	if refValue(node) != "" {
+		return true
+	}
+	for _, c := range node.Content {
+		if containsRef(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// sliceSource returns the text of the mapping or sequence node in the source, with the indentation of the node removed.
+// Returns false for the other nodes (e.g. scalars and YAML flow collections) to encode them instead.
+func sliceSource(source []byte, node *yaml.Node, format string) (string, bool) {
+	if node.Kind != yaml.MappingNode && node.Kind != yaml.SequenceNode {
+		return "", false
+	}
+	lines := strings.Split(strings.ReplaceAll(string(source), "\r\n", "\n"), "\n")
+	var body []string
+	var indent int
+	if format == formatJson {
+		line, column := node.Line, node.Column
+		if line < 1 || line > len(lines) || column < 1 || column > len([]rune(lines[line-1])) {
+			return "", false
+		}
+		// the following lines are indented as the line of the opening bracket
+		indent = indentOf(lines[line-1])
+		body = jsonSourceLines(string([]rune(lines[line-1])[column-1:]), lines[line:])
+	} else {
+		if node.Style&yaml.FlowStyle != 0 {
+			return "", false
+		}
+		line, column := blockStart(lines, node)
+		if line < 1 {
+			return "", false
+		}
+		// the column of the first key or item is the indentation of the block
+		indent = column - 1
+		body = yamlSourceLines(string([]rune(lines[line-1])[column-1:]), lines[line:], indent, node.Kind == yaml.SequenceNode)
+	}
+	if body == nil {
+		return "", false
+	}
+	for i := 1; i < len(body); i++ {
+		n := indentOf(body[i])
+		if n > indent {
+			n = indent
+		}
+		body[i] = body[i][n:]
+	}
+	return strings.Join(body, "\n") + "\n", true
+}
+
+// blockStart returns the position of the first key or "-" of the block collection.
+// The position of the first child is used because the position of the collection node is not always at the start.
+func blockStart(lines []string, node *yaml.Node) (int, int) {
+	if (node.Kind != yaml.MappingNode && node.Kind != yaml.SequenceNode) || node.Style&yaml.FlowStyle != 0 {
+		if node.Line < 1 || node.Line > len(lines) || node.Column < 1 || node.Column > len([]rune(lines[node.Line-1])) {
+			return 0, 0
+		}
+		return node.Line, node.Column
+	}
+	if len(node.Content) == 0 {
+		return 0, 0
+	}
+	line, column := blockStart(lines, node.Content[0])
+	if line < 1 || node.Kind == yaml.MappingNode {
+		return line, column
+	}
+	// the "-" of the first item
+	rs := []rune(lines[line-1])
+	for i := column - 2; i >= 0; i-- {
+		if rs[i] == '-' {
+			return line, i + 1
+		}
+	}
+	return 0, 0
+}
+
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
+
+// yamlSourceLines returns the lines of the block until the line less indented than the block.
+// The blank lines and the comments after the block are not included.
+func yamlSourceLines(first string, rest []string, indent int, sequence bool) []string {
+	ret := []string{first}
+	pending := make([]string, 0)
+	for _, line := range rest {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			pending = append(pending, line)
+			continue
+		}
+		n := indentOf(line)
+		if n < indent || (n == indent && sequence && !strings.HasPrefix(trimmed, "-")) || trimmed == "---" || trimmed == "..." {
+			break
+		}
+		ret = append(ret, pending...)
+		ret = append(ret, line)
+		pending = pending[:0]
+	}
+	return ret
+}
+
+// jsonSourceLines returns the lines of the JSON object or array until the closing bracket.
+// Returns nil if the brackets are not closed.
+func jsonSourceLines(first string, rest []string) []string {
+	text := strings.Join(append([]string{first}, rest...), "\n")
+	depth := 0
+	inString, escaped := false, false
+	for i, r := range text {
+		switch {
+		case inString:
+			switch {
+			case escaped:
+				escaped = false
+			case r == '\\':
+				escaped = true
+			case r == '"':
+				inString = false
+			}
+		case r == '"':
+			inString = true
+		case r == '{' || r == '[':
+			depth++
+		case r == '}' || r == ']':
+			depth--
+			if depth == 0 {
+				return strings.Split(text[:i+1], "\n")
+			}
+		}
+	}
+	return nil
+}
+
+// pointed returns the node pointed by the JSON pointer in the file without following $ref.
+func (m *sourceMap) pointed(location *url.URL, pointer string) *sourceNode {
+	n := m.node(location)
+	if n == nil || pointer == "" {
+		return n
+	}
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = jsonPointerUnreplacer.Replace(token)
+		c := childNode(n.node, token)
+		if c == nil {
+			return nil
+		}
+		n = &sourceNode{
+			location: n.location,
+			pointer:  n.pointer + jsonPointer(token),
+			node:     c,
+		}
+	}
+	return n
+}
+
+func (m *sourceMap) format(location *url.URL) string {
+	if bytes.HasPrefix(bytes.TrimSpace(m.sources[location.String()]), []byte("{")) {
+		return formatJson
+	}
+	return formatYaml
+}
+
+// inline returns the copy of the node with the $refs replaced by the referred nodes.
+// Circular refs are left as they are.
+func (m *sourceMap) inline(n *sourceNode, visiting map[string]bool) *yaml.Node {
+	if ref := refValue(n.node); ref != "" {
+		target := m.resolve(n.location, ref)
+		if target == nil {
+			return n.node
+		}
+		key := target.location.String() + "#" + target.pointer
+		if visiting[key] {
+			return n.node
+		}
+		visiting[key] = true
+		defer delete(visiting, key)
+		return m.inline(target, visiting)
+	}
+
+	node := n.node
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	ret := *node
+	switch node.Kind {
+	case yaml.MappingNode:
+		ret.Content = make([]*yaml.Node, len(node.Content))
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			k, v := node.Content[i], node.Content[i+1]
+			c := &sourceNode{
+				location: n.location,
+				pointer:  n.pointer + jsonPointer(k.Value),
+				node:     v,
+			}
+			ret.Content[i] = k
+			ret.Content[i+1] = m.inline(c, visiting)
+		}
+	case yaml.SequenceNode:
+		ret.Content = make([]*yaml.Node, len(node.Content))
+		for i, v := range node.Content {
+			c := &sourceNode{
+				location: n.location,
+				pointer:  n.pointer + jsonPointer(strconv.Itoa(i)),
+				node:     v,
+			}
+			ret.Content[i] = m.inline(c, visiting)
+		}
+	}
+	return &ret
+}
+
+func encodeYaml(node *yaml.Node) (string, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(node); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// encodeJson encodes the node as JSON, keeping the order of the keys.
+func encodeJson(node *yaml.Node) (string, error) {
+	var buf bytes.Buffer
+	if err := writeJson(&buf, node, ""); err != nil {
+		return "", err
+	}
+	buf.WriteString("\n")
+	return buf.String(), nil
+}
+
+func writeJson(buf *bytes.Buffer, node *yaml.Node, indent string) error {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return writeJson(buf, node.Alias, indent)
+	case yaml.MappingNode:
+		if len(node.Content) == 0 {
+			buf.WriteString("{}")
+			return nil
+		}
+		buf.WriteString("{\n")
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, err := json.Marshal(node.Content[i].Value)
+			if err != nil {
+				return err
+			}
+			buf.WriteString(indent + "  ")
+			buf.Write(key)
+			buf.WriteString(": ")
+			if err := writeJson(buf, node.Content[i+1], indent+"  "); err != nil {
+				return err
+			}
+			if i+2 < len(node.Content) {
+				buf.WriteString(",")
+			}
+			buf.WriteString("\n")
+		}
+		buf.WriteString(indent + "}")
+	case yaml.SequenceNode:
+		if len(node.Content) == 0 {
+			buf.WriteString("[]")
+			return nil
+		}
+		buf.WriteString("[\n")
+		for i, c := range node.Content {
+			buf.WriteString(indent + "  ")
+			if err := writeJson(buf, c, indent+"  "); err != nil {
+				return err
+			}
+			if i+1 < len(node.Content) {
+				buf.WriteString(",")
+			}
+			buf.WriteString("\n")
+		}
+		buf.WriteString(indent + "]")
+	case yaml.ScalarNode:
+		var v interface{}
+		if err := node.Decode(&v); err != nil {
+			return err
+		}
+		bs, err := json.Marshal(v)
+		if err != nil {
+			// values not representable in JSON (e.g. .inf) are written as strings
+			if bs, err = json.Marshal(node.Value); err != nil {
+				return err
+			}
+		}
+		buf.Write(bs)
+	default:
+		return fmt.Errorf("unsupported node kind: %v", node.Kind)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package openapi
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lusingander/topi/internal/cache"
+	"github.com/lusingander/topi/internal/remote"
+)
+
+func TestLoaderRemote(t *testing.T) {
+	files := map[string]string{
+		"/api/openapi.yaml":     sourceTestSpec,
+		"/api/schemas/pet.yaml": sourceTestPetSchema("name"),
+	}
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		content, ok := files[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(content))
+	}))
+	defer ts.Close()
+	ca := filepath.Join(t.TempDir(), "ca.pem")
+	bs := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+	if err := os.WriteFile(ca, bs, 0644); err != nil {
+		t.Fatal(err)
+	}
+	url := ts.URL + "/api/openapi.yaml"
+
+	client, err := remote.NewClient(remote.Config{CACert: ca, BearerToken: "secret"}, []string{url})
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err := (&Loader{Client: client}).Load(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	op := doc.FindPathByOperationId("listPets")
+	if op == nil {
+		t.Fatal("operation is not loaded")
+	}
+	// loaded from the external ref with the same client
+	if _, ok := op.Responses[0].Conetnt[0].Schema.Properties["name"]; !ok {
+		t.Errorf("external ref is not resolved: %v", op.Responses[0].Conetnt[0].Schema)
+	}
+
+	// the error of the request is returned instead of the error of loading as a file
+	client, err = remote.NewClient(remote.Config{CACert: ca}, []string{url})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = (&Loader{Client: client}).Load(url)
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("unauthorized error is expected: %v", err)
+	}
+}
+
+func TestLoaderCache(t *testing.T) {
+	files := map[string]string{
+		"/api/openapi.yaml":     sourceTestSpec,
+		"/api/schemas/pet.yaml": sourceTestPetSchema("name"),
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		if r.Header.Get("If-Modified-Since") != "" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte(files[r.URL.Path]))
+	}))
+	c, err := cache.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	loader := &Loader{Client: ts.Client(), Cache: c}
+	url := ts.URL + "/api/openapi.yaml"
+
+	for _, online := range []bool{true, true, false} {
+		if !online {
+			ts.Close()
+		}
+		doc, err := loader.Load(url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if doc.Meta.Stale == online {
+			t.Errorf("got=%v, want=%v", doc.Meta.Stale, !online)
+		}
+		if !online && doc.Meta.CachedAt.IsZero() {
+			t.Errorf("cached time is not set")
+		}
+		op := doc.FindPathByOperationId("listPets")
+		if op == nil {
+			t.Fatal("operation is not loaded")
+		}
+		if _, ok := op.Responses[0].Conetnt[0].Schema.Properties["name"]; !ok {
+			t.Errorf("external ref is not resolved: %v", op.Responses[0].Conetnt[0].Schema)
+		}
+	}
+	if n := len(c.Entries()); n != 2 {
+		t.Errorf("got=%v, want=%v", n, 2)
+	}
+}
@@ -0,0 +1,109 @@
+package openapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os/exec"
+	"path"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const (
+	// StdinPath is the path to read the spec from stdin.
+	StdinPath = "-"
+
+	gitPrefix = "git:"
+)
+
+// readSource reads all data from r, used to read the spec from stdin.
+func readSource(r io.Reader) ([]byte, error) {
+	bs, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(bs)) == 0 {
+		return nil, errors.New("empty spec is given from stdin")
+	}
+	return bs, nil
+}
+
+// gitSource is the spec at a Git revision, given as "git:REV:path".
+type gitSource struct {
+	rev  string
+	path string // relative to the root of the repository
+}
+
+// parseGitSource parses "git:REV:path". The path is relative to the root of the repository as in `git show`,
+// or to the current directory if it starts with "./" or "../".
+func parseGitSource(s string) (*gitSource, error) {
+	revPath := strings.TrimPrefix(s, gitPrefix)
+	rev, p, ok := strings.Cut(revPath, ":")
+	if !ok || rev == "" || p == "" {
+		return nil, fmt.Errorf("invalid git source (must be git:REV:path): %s", s)
+	}
+	if strings.HasPrefix(rev, "-") {
+		// would be parsed as an option of git
+		return nil, fmt.Errorf("invalid git revision: %s", rev)
+	}
+	if strings.HasPrefix(p, "./") || strings.HasPrefix(p, "../") {
+		prefix, err := git("rev-parse", "--show-prefix")
+		if err != nil {
+			return nil, err
+		}
+		p = path.Join(strings.TrimSpace(string(prefix)), p)
+	}
+	p = path.Clean(p)
+	if p == ".." || strings.HasPrefix(p, "../") {
+		return nil, fmt.Errorf("path is outside of the repository: %s", s)
+	}
+	return &gitSource{rev: rev, path: p}, nil
+}
+
+// location returns the location of the spec to resolve the relative refs from.
+func (s *gitSource) location() *url.URL {
+	return &url.URL{Path: "/" + s.path}
+}
+
+func (s *gitSource) fileName() string {
+	return fmt.Sprintf("%s@%s", path.Base(s.path), s.rev)
+}
+
+// file returns the file name shown as the source of the elements, which cannot be edited since it is at the revision.
+func (s *gitSource) file(location *url.URL) (string, bool) {
+	if isRemote(location) {
+		return location.String(), false
+	}
+	return fmt.Sprintf("%s%s:%s", gitPrefix, s.rev, strings.TrimPrefix(path.Clean(location.Path), "/")), false
+}
+
+func (s *gitSource) show(p string) ([]byte, error) {
+	return git("show", fmt.Sprintf("%s:%s", s.rev, p))
+}
+
+// read reads the files referred from the spec at the same revision.
+// Remote URLs are not supported and passed to the next reader.
+func (s *gitSource) read(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+	if location.Scheme != "" || location.Host != "" {
+		return nil, openapi3.ErrURINotSupported
+	}
+	return s.show(strings.TrimPrefix(path.Clean(location.Path), "/"))
+}
+
+func git(args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git %s: %s", strings.Join(args, " "), msg)
+		}
+		return nil, fmt.Errorf("git %s: %w", strings.Join(args, " "), err)
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,158 @@
+package openapi
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sourceTestSpec = `openapi: 3.0.3
+info:
+  title: Pet API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: "./schemas/pet.yaml"
+`
+
+func sourceTestPetSchema(prop string) string {
+	return "type: object\nproperties:\n  " + prop + ":\n    type: string\n"
+}
+
+func TestLoadStdin(t *testing.T) {
+	doc, err := (&Loader{}).loadStdin(strings.NewReader(strings.ReplaceAll(sourceTestSpec, "$ref: \"./schemas/pet.yaml\"", "type: string")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := doc.Meta.FileName, "stdin"; got != want {
+		t.Errorf("got=%v, want=%v", got, want)
+	}
+	if doc.FindPathByOperationId("listPets") == nil {
+		t.Errorf("operation is not loaded")
+	}
+
+	if _, err := (&Loader{}).loadStdin(strings.NewReader("\n")); err == nil {
+		t.Errorf("error is expected for empty input")
+	}
+}
+
+func TestParseGitSource(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    *gitSource
+		wantErr bool
+	}{
+		{"git:HEAD~3:api/openapi.yaml", &gitSource{"HEAD~3", "api/openapi.yaml"}, false},
+		{"git:v1.0.0:openapi.yaml", &gitSource{"v1.0.0", "openapi.yaml"}, false},
+		{"git:main:api/../openapi.yaml", &gitSource{"main", "openapi.yaml"}, false},
+		{"git:HEAD", nil, true},
+		{"git::openapi.yaml", nil, true},
+		{"git:HEAD:", nil, true},
+		{"git:HEAD:api/../../openapi.yaml", nil, true},
+		{"git:--output=/tmp/x:openapi.yaml", nil, true},
+	}
+	for _, test := range tests {
+		got, err := parseGitSource(test.s)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("error is expected: %s", test.s)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+}
+
+func TestLoadGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com", "GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	write := func(name, content string) {
+		t.Helper()
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	run("init", "-q")
+	write("api/openapi.yaml", sourceTestSpec)
+	write("api/schemas/pet.yaml", sourceTestPetSchema("name"))
+	run("add", "-A")
+	run("commit", "-q", "-m", "first")
+	write("api/schemas/pet.yaml", sourceTestPetSchema("nickname"))
+	run("commit", "-q", "-a", "-m", "second")
+	write("api/schemas/pet.yaml", sourceTestPetSchema("uncommitted"))
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(filepath.Join(dir, "api")); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	tests := []struct {
+		path       string
+		fileName   string
+		prop       string
+		schemaFile string
+	}{
+		{"git:HEAD~1:api/openapi.yaml", "openapi.yaml@HEAD~1", "name", "git:HEAD~1:api/schemas/pet.yaml"},
+		{"git:HEAD:./openapi.yaml", "openapi.yaml@HEAD", "nickname", "git:HEAD:api/schemas/pet.yaml"},
+	}
+	for _, test := range tests {
+		doc, err := Load(test.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := doc.Meta.FileName; got != test.fileName {
+			t.Errorf("got=%v, want=%v", got, test.fileName)
+		}
+		op := doc.FindPathByOperationId("listPets")
+		if op == nil {
+			t.Fatalf("operation is not loaded: %s", test.path)
+		}
+		schema := op.Responses[0].Conetnt[0].Schema
+		if _, ok := schema.Properties[test.prop]; len(schema.Properties) != 1 || !ok {
+			t.Errorf("unexpected schema properties of %s: %v", test.path, schema.Properties)
+		}
+		if schema.Source == nil || schema.Source.File != test.schemaFile || schema.Source.Local {
+			t.Errorf("unexpected schema source of %s: %v", test.path, schema.Source)
+		}
+	}
+
+	if _, err := Load("git:HEAD:api/notfound.yaml"); err == nil {
+		t.Errorf("error is expected for the file not in the revision")
+	}
+}
@@ -0,0 +1,277 @@
+package openapi
+
+import (
+	"net/url"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/lusingander/topi/internal/topi"
+	"gopkg.in/yaml.v3"
+)
+
+// maxRefDepth limits the number of refs followed at once to avoid infinite loops on circular refs.
+const maxRefDepth = 32
+
+// sourceMap finds where the elements of the spec are defined, following $ref across the files read by the loader.
+type sourceMap struct {
+	sources   map[string][]byte
+	roots     map[string]*yaml.Node
+	locations map[string]*url.URL // by the file names of the sources
+	depth     int                 // number of refs being resolved
+	// file returns the name of the file shown to users and whether it can be edited.
+	file func(location *url.URL) (string, bool)
+}
+
+func newSourceMap(sources map[string][]byte, file func(*url.URL) (string, bool)) *sourceMap {
+	if file == nil {
+		file = localFile
+	}
+	return &sourceMap{
+		sources:   sources,
+		roots:     make(map[string]*yaml.Node),
+		locations: make(map[string]*url.URL),
+		file:      file,
+	}
+}
+
+func localFile(location *url.URL) (string, bool) {
+	if isRemote(location) {
+		return location.String(), false
+	}
+	return filepath.FromSlash(location.Path), true
+}
+
+func (m *sourceMap) root(location *url.URL) *yaml.Node {
+	key := location.String()
+	if root, ok := m.roots[key]; ok {
+		return root
+	}
+	root := parseSource(m.sources[key])
+	m.roots[key] = root
+	return root
+}
+
+// sourceNode is a node in the source with its location.
+type sourceNode struct {
+	location *url.URL
+	pointer  string
+	node     *yaml.Node
+}
+
+// node returns the root node of the document at the location.
+func (m *sourceMap) node(location *url.URL) *sourceNode {
+	root := m.root(location)
+	if root == nil {
+		return nil
+	}
+	return &sourceNode{location: location, node: root}
+}
+
+// child returns the descendant node following the tokens.
+// If a node on the way is a reference object, the referenced node is used instead.
+func (m *sourceMap) child(n *sourceNode, tokens ...string) *sourceNode {
+	for _, token := range tokens {
+		n = m.deref(n)
+		if n == nil {
+			return nil
+		}
+		c := childNode(n.node, token)
+		if c == nil {
+			return nil
+		}
+		n = &sourceNode{
+			location: n.location,
+			pointer:  n.pointer + jsonPointer(token),
+			node:     c,
+		}
+	}
+	return m.deref(n)
+}
+
+// deref returns the node referred by the $ref of n, or n itself if n is not a reference object.
+func (m *sourceMap) deref(n *sourceNode) *sourceNode {
+	for i := 0; n != nil && i < maxRefDepth; i++ {
+		ref := refValue(n.node)
+		if ref == "" {
+			return n
+		}
+		n = m.resolve(n.location, ref)
+	}
+	return nil
+}
+
+func (m *sourceMap) resolve(base *url.URL, ref string) *sourceNode {
+	if m.depth >= maxRefDepth {
+		return nil
+	}
+	m.depth++
+	defer func() { m.depth-- }()
+
+	file, fragment, _ := strings.Cut(ref, "#")
+	location := base
+	if file != "" {
+		u, err := url.Parse(file)
+		if err != nil {
+			return nil
+		}
+		location = resolveLocation(base, u)
+	}
+	n := m.node(location)
+	if n == nil || fragment == "" {
+		return n
+	}
+	tokens := strings.Split(strings.TrimPrefix(fragment, "/"), "/")
+	for i, token := range tokens {
+		tokens[i] = jsonPointerUnreplacer.Replace(token)
+	}
+	return m.child(n, tokens...)
+}
+
+// resolveLocation resolves the location of the referred document in the same way as openapi3.Loader.
+func resolveLocation(base, ref *url.URL) *url.URL {
+	if ref.Scheme != "" || ref.Host != "" || strings.HasPrefix(ref.Path, "/") {
+		return ref
+	}
+	ret := *base
+	ret.Path = path.Join(path.Dir(base.Path), ref.Path)
+	return &ret
+}
+
+func refValue(node *yaml.Node) string {
+	if node.Kind != yaml.MappingNode {
+		return ""
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == "$ref" && node.Content[i+1].Kind == yaml.ScalarNode {
+			return node.Content[i+1].Value
+		}
+	}
+	return ""
+}
+
+func (m *sourceMap) source(n *sourceNode) *topi.Source {
+	if n == nil {
+		return nil
+	}
+	file, local := m.file(n.location)
+	m.locations[file] = n.location
+	return &topi.Source{
+		File:    file,
+		Pointer: n.pointer,
+		Line:    n.node.Line,
+		Local:   local,
+	}
+}
+
+// annotateSources sets the sources of the info, operations, parameters, responses and schemas of the document loaded from the location.
+func annotateSources(doc *topi.Document, m *sourceMap, location *url.URL) {
+	root := m.node(location)
+	if root == nil {
+		return
+	}
+	doc.Sources = m
+	if doc.Info != nil {
+		doc.Info.Source = m.source(m.child(root, "info"))
+	}
+	for _, paths := range doc.TagPathMap {
+		for _, p := range paths {
+			annotatePathSources(p, m, root)
+		}
+	}
+	if doc.Components != nil {
+		for name, sc := range doc.Components.Schemas {
+			annotateSchemaSources(sc, m, m.child(root, "components", "schemas", name), 0)
+		}
+	}
+}
+
+func annotatePathSources(p *topi.Path, m *sourceMap, root *sourceNode) {
+	item := m.child(root, "paths", p.UriPath)
+	if item == nil {
+		return
+	}
+	op := m.child(item, strings.ToLower(p.Method))
+	if op == nil {
+		return
+	}
+	p.Source = m.source(op)
+
+	params := append(parameterNodes(m, item), parameterNodes(m, op)...) // operation level overrides path item level
+	for _, ps := range [][]*topi.Parameter{p.PathParameters, p.QueryParameters, p.HeaderParameters, p.CookieParameters} {
+		for _, param := range ps {
+			var n *sourceNode
+			for _, pn := range params {
+				if childValue(pn.node, "in") == param.In && childValue(pn.node, "name") == param.Name {
+					n = pn
+				}
+			}
+			if n == nil {
+				continue
+			}
+			param.Source = m.source(n)
+			annotateSchemaSources(param.Schema, m, m.child(n, "schema"), 0)
+			for _, c := range param.Content {
+				annotateSchemaSources(c.Schema, m, m.child(n, "content", c.MediaType, "schema"), 0)
+			}
+		}
+	}
+
+	if p.RequestBody != nil {
+		body := m.child(op, "requestBody")
+		for _, c := range p.RequestBody.Conetnt {
+			annotateSchemaSources(c.Schema, m, m.child(body, "content", c.MediaType, "schema"), 0)
+		}
+	}
+
+	for _, r := range p.Responses {
+		n := m.child(op, "responses", r.StatusCode)
+		if n == nil {
+			continue
+		}
+		r.Source = m.source(n)
+		for _, c := range r.Conetnt {
+			annotateSchemaSources(c.Schema, m, m.child(n, "content", c.MediaType, "schema"), 0)
+		}
+	}
+}
+
+func parameterNodes(m *sourceMap, n *sourceNode) []*sourceNode {
+	params := m.child(n, "parameters")
+	if params == nil || params.node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	ret := make([]*sourceNode, 0, len(params.node.Content))
+	for i := range params.node.Content {
+		if pn := m.child(params, strconv.Itoa(i)); pn != nil {
+			ret = append(ret, pn)
+		}
+	}
+	return ret
+}
+
+func childValue(node *yaml.Node, key string) string {
+	c := childNode(node, key)
+	if c == nil || c.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return c.Value
+}
+
+func annotateSchemaSources(sc *topi.Schema, m *sourceMap, n *sourceNode, depth int) {
+	if sc == nil || n == nil || depth > maxRefDepth {
+		return
+	}
+	sc.Source = m.source(n)
+	for name, prop := range sc.Properties {
+		annotateSchemaSources(prop, m, m.child(n, "properties", name), depth+1)
+	}
+	annotateSchemaSources(sc.Items, m, m.child(n, "items"), depth+1)
+	for i, s := range sc.AllOf {
+		annotateSchemaSources(s, m, m.child(n, "allOf", strconv.Itoa(i)), depth+1)
+	}
+	for i, s := range sc.OneOf {
+		annotateSchemaSources(s, m, m.child(n, "oneOf", strconv.Itoa(i)), depth+1)
+	}
+}
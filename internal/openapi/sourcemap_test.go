@@ -0,0 +1,338 @@
+package openapi
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lusingander/topi/internal/topi"
+)
+
+var sourceMapTestFiles = map[string]string{
+	"openapi.yaml": `openapi: 3.0.3
+info:
+  title: Pet API
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    $ref: "./paths/pet.yaml"
+components:
+  schemas:
+    Pet:
+      $ref: "./schemas/pet.yaml"
+`,
+	"paths/pet.yaml": `parameters:
+  - $ref: "../common.yaml#/parameters/Id"
+get:
+  operationId: getPet
+  parameters:
+    - name: verbose
+      in: query
+      schema:
+        type: boolean
+  responses:
+    "200":
+      description: ok
+      content:
+        application/json:
+          schema:
+            $ref: "../schemas/pet.yaml"
+    "404":
+      $ref: "../common.yaml#/responses/NotFound"
+`,
+	"common.yaml": `parameters:
+  Id:
+    name: id
+    in: path
+    required: true
+    schema:
+      type: string
+responses:
+  NotFound:
+    description: not found
+`,
+	"schemas/pet.yaml": `type: object
+properties:
+  name:
+    type: string
+  tag:
+    $ref: "./tag.yaml#/Tag"
+`,
+	"schemas/tag.yaml": `Tag:
+  type: string
+`,
+}
+
+func writeSourceMapTestFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestAnnotateSources(t *testing.T) {
+	dir := writeSourceMapTestFiles(t, sourceMapTestFiles)
+	doc, err := Load(filepath.Join(dir, "openapi.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := doc.FindPathByOperationId("getPet")
+	if p == nil {
+		t.Fatal("operation is not loaded")
+	}
+	file := func(name string) string {
+		return filepath.Join(dir, filepath.FromSlash(name))
+	}
+
+	tests := []struct {
+		name string
+		got  *topi.Source
+		want *topi.Source
+	}{
+		{"operation", p.Source, &topi.Source{File: file("paths/pet.yaml"), Pointer: "/get", Line: 3, Local: true}},
+		{"path item parameter", p.PathParameters[0].Source, &topi.Source{File: file("common.yaml"), Pointer: "/parameters/Id", Line: 2, Local: true}},
+		{"operation parameter", p.QueryParameters[0].Source, &topi.Source{File: file("paths/pet.yaml"), Pointer: "/get/parameters/0", Line: 6, Local: true}},
+		{"response", p.Responses[0].Source, &topi.Source{File: file("paths/pet.yaml"), Pointer: "/get/responses/200", Line: 11, Local: true}},
+		{"referred response", p.Responses[1].Source, &topi.Source{File: file("common.yaml"), Pointer: "/responses/NotFound", Line: 9, Local: true}},
+		{"response schema", p.Responses[0].Conetnt[0].Schema.Source, &topi.Source{File: file("schemas/pet.yaml"), Pointer: "", Line: 1, Local: true}},
+		{"property", p.Responses[0].Conetnt[0].Schema.Properties["tag"].Source, &topi.Source{File: file("schemas/tag.yaml"), Pointer: "/Tag", Line: 1, Local: true}},
+		{"component schema", doc.Components.Schemas["Pet"].Properties["name"].Source, &topi.Source{File: file("schemas/pet.yaml"), Pointer: "/properties/name", Line: 3, Local: true}},
+	}
+	for _, tt := range tests {
+		if tt.got == nil {
+			t.Errorf("%s: source is not set", tt.name)
+			continue
+		}
+		if *tt.got != *tt.want {
+			t.Errorf("%s: got=%v, want=%v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestRaw(t *testing.T) {
+	dir := writeSourceMapTestFiles(t, sourceMapTestFiles)
+	doc, err := Load(filepath.Join(dir, "openapi.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	response := doc.FindPathByOperationId("getPet").Responses[0]
+
+	tests := []struct {
+		deref bool
+		want  string
+	}{
+		{false, `description: ok
+content:
+  application/json:
+    schema:
+      $ref: "../schemas/pet.yaml"
+`},
+		{true, `description: ok
+content:
+  application/json:
+    schema:
+      type: object
+      properties:
+        name:
+          type: string
+        tag:
+          type: string
+`},
+	}
+	for _, tt := range tests {
+		got, format, err := doc.Sources.Raw(response.Source, tt.deref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if format != "yaml" {
+			t.Errorf("got=%v, want=%v", format, "yaml")
+		}
+		if got != tt.want {
+			t.Errorf("got=%v, want=%v", got, tt.want)
+		}
+	}
+}
+
+func TestRawJson(t *testing.T) {
+	dir := writeSourceMapTestFiles(t, map[string]string{
+		"openapi.json": `{
+  "openapi": "3.0.3",
+  "info": {"title": "Pet API", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "get": {
+        "operationId": "listPets",
+        "responses": {
+          "200": {"$ref": "#/components/responses/Pets"}
+        }
+      }
+    }
+  },
+  "components": {
+    "responses": {
+      "Pets": {"description": "ok", "content": {"application/json": {"schema": {"type": "array", "maxItems": 10}}}}
+    }
+  }
+}`,
+	})
+	doc, err := Load(filepath.Join(dir, "openapi.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	op := doc.FindPathByOperationId("listPets")
+	got, format, err := doc.Sources.Raw(op.Source, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+  "operationId": "listPets",
+  "responses": {
+    "200": {
+      "description": "ok",
+      "content": {
+        "application/json": {
+          "schema": {
+            "type": "array",
+            "maxItems": 10
+          }
+        }
+      }
+    }
+  }
+}
+`
+	if format != "json" {
+		t.Errorf("got=%v, want=%v", format, "json")
+	}
+	if got != want {
+		t.Errorf("got=%v, want=%v", got, want)
+	}
+}
+
+func TestRawSource(t *testing.T) {
+	dir := writeSourceMapTestFiles(t, map[string]string{
+		"openapi.yaml": `openapi: 3.0.3
+info: {title: Pet API, version: 1.0.0}
+paths:
+  /pets:
+    get:
+      # list the pets
+      operationId: listPets
+      parameters:
+      - name: 'limit'
+        in: query
+        schema: {type: integer, maximum: 100}
+
+      - name: tag
+        in: query
+      responses:
+        "200":
+          description: >
+            the pets
+          content:
+            application/json: {}   # no schema yet
+
+    # the other operations
+  /stores:
+    get:
+      operationId: listStores
+      responses:
+        "200": {description: ok}
+`,
+		"openapi.json": `{
+  "openapi": "3.0.3",
+  "info": {"title": "Pet API", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "get": {
+        "operationId": "listPets",
+        "responses": {"200": {"description": "a \"}\" brace"}}
+      }
+    }
+  }
+}`,
+	})
+	tests := []struct {
+		file    string
+		pointer string
+		want    string
+	}{
+		{"openapi.yaml", "/paths/~1pets/get", `operationId: listPets
+parameters:
+- name: 'limit'
+  in: query
+  schema: {type: integer, maximum: 100}
+
+- name: tag
+  in: query
+responses:
+  "200":
+    description: >
+      the pets
+    content:
+      application/json: {}   # no schema yet
+`},
+		{"openapi.yaml", "/paths/~1pets/get/parameters", `- name: 'limit'
+  in: query
+  schema: {type: integer, maximum: 100}
+
+- name: tag
+  in: query
+`},
+		{"openapi.yaml", "/paths/~1pets/get/parameters/1", `name: tag
+in: query
+`},
+		{"openapi.yaml", "/paths/~1stores/get/responses/200", `{description: ok}
+`},
+		{"openapi.json", "/paths/~1pets/get", `{
+  "operationId": "listPets",
+  "responses": {"200": {"description": "a \"}\" brace"}}
+}
+`},
+	}
+	for _, test := range tests {
+		doc, err := Load(filepath.Join(dir, test.file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		src := &topi.Source{File: filepath.Join(dir, test.file), Pointer: test.pointer}
+		got, _, err := doc.Sources.Raw(src, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.want {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+}
+
+func TestResolveLocation(t *testing.T) {
+	tests := []struct {
+		base string
+		ref  string
+		want string
+	}{
+		{"/api/openapi.yaml", "./schemas/pet.yaml", "/api/schemas/pet.yaml"},
+		{"/api/paths/pet.yaml", "../common.yaml", "/api/common.yaml"},
+		{"/api/openapi.yaml", "/other/pet.yaml", "/other/pet.yaml"},
+		{"https://example.com/api/openapi.yaml?v=1", "pet.yaml", "https://example.com/api/pet.yaml?v=1"},
+		{"/api/openapi.yaml", "https://example.com/pet.yaml", "https://example.com/pet.yaml"},
+	}
+	for _, tt := range tests {
+		base, _ := url.Parse(tt.base)
+		ref, _ := url.Parse(tt.ref)
+		got := resolveLocation(base, ref).String()
+		if got != tt.want {
+			t.Errorf("got=%v, want=%v", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/lusingander/topi/internal/topi"
+	"gopkg.in/yaml.v3"
+)
+
+type validator interface {
+	Validate(ctx context.Context) error
+}
+
+// validate validates each element of the document separately to collect errors as many as possible,
+// since openapi3.T.Validate returns only the first error.
+func validate(ctx context.Context, t *openapi3.T, source []byte) []*topi.ValidationError {
+	ret := make([]*topi.ValidationError, 0)
+	add := func(pointer string, err error) {
+		e := &topi.ValidationError{
+			Pointer: pointer,
+			Message: err.Error(),
+		}
+		ret = append(ret, e)
+	}
+	check := func(pointer string, v validator) {
+		if err := v.Validate(ctx); err != nil {
+			add(pointer, err)
+		}
+	}
+
+	if t.OpenAPI == "" {
+		add("/openapi", errors.New("value of openapi must be a non-empty string"))
+	}
+	if t.Info == nil {
+		add("/info", errors.New("must be an object"))
+	} else {
+		check("/info", t.Info)
+	}
+
+	for _, p := range sortedKeys(t.Paths) {
+		item := t.Paths[p]
+		if item == nil {
+			continue
+		}
+		if len(item.Parameters) > 0 {
+			check(jsonPointer("paths", p, "parameters"), item.Parameters)
+		}
+		ops := item.Operations()
+		for _, method := range sortedKeys(ops) {
+			pointer := jsonPointer("paths", p, strings.ToLower(method))
+			check(pointer, ops[method])
+			if missing := missingPathParams(p, item.Parameters, ops[method].Parameters); len(missing) > 0 {
+				add(pointer, fmt.Errorf("must define all path parameters (missing: %s)", strings.Join(missing, ", ")))
+			}
+		}
+	}
+	if len(ret) == 0 {
+		check("/paths", t.Paths)
+	}
+
+	components := t.Components
+	for _, k := range sortedKeys(components.Schemas) {
+		check(jsonPointer("components", "schemas", k), components.Schemas[k])
+	}
+	for _, k := range sortedKeys(components.Parameters) {
+		check(jsonPointer("components", "parameters", k), components.Parameters[k])
+	}
+	for _, k := range sortedKeys(components.RequestBodies) {
+		check(jsonPointer("components", "requestBodies", k), components.RequestBodies[k])
+	}
+	for _, k := range sortedKeys(components.Responses) {
+		check(jsonPointer("components", "responses", k), components.Responses[k])
+	}
+	for _, k := range sortedKeys(components.Headers) {
+		check(jsonPointer("components", "headers", k), components.Headers[k])
+	}
+	for _, k := range sortedKeys(components.SecuritySchemes) {
+		check(jsonPointer("components", "securitySchemes", k), components.SecuritySchemes[k])
+	}
+
+	if t.Security != nil {
+		check("/security", t.Security)
+	}
+	if t.Servers != nil {
+		check("/servers", t.Servers)
+	}
+	if t.Tags != nil {
+		check("/tags", t.Tags)
+	}
+
+	if len(ret) == 0 {
+		// in case of errors not covered above
+		if err := t.Validate(ctx); err != nil {
+			add("", err)
+		}
+	}
+
+	if root := parseSource(source); root != nil {
+		for _, e := range ret {
+			e.Line = sourceLine(root, e.Pointer)
+		}
+	}
+	return ret
+}
+
+var pathParamRegexp = regexp.MustCompile(`{([^}]+)}`)
+
+// missingPathParams returns the names of path template parameters which are not defined in parameters.
+func missingPathParams(uriPath string, pathParams, opParams openapi3.Parameters) []string {
+	defined := make(map[string]bool)
+	for _, params := range []openapi3.Parameters{pathParams, opParams} {
+		for _, p := range params {
+			if p != nil && p.Value != nil && p.Value.In == openapi3.ParameterInPath {
+				defined[p.Value.Name] = true
+			}
+		}
+	}
+	ret := make([]string, 0)
+	for _, m := range pathParamRegexp.FindAllStringSubmatch(uriPath, -1) {
+		if !defined[m[1]] {
+			ret = append(ret, m[1])
+		}
+	}
+	return ret
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	ret := make([]string, 0, len(m))
+	for k := range m {
+		ret = append(ret, k)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+var jsonPointerReplacer = strings.NewReplacer("~", "~0", "/", "~1")
+
+func jsonPointer(tokens ...string) string {
+	var s strings.Builder
+	for _, t := range tokens {
+		s.WriteString("/")
+		s.WriteString(jsonPointerReplacer.Replace(t))
+	}
+	return s.String()
+}
+
+var jsonPointerUnreplacer = strings.NewReplacer("~1", "/", "~0", "~")
+
+func parseSource(source []byte) *yaml.Node {
+	if len(source) == 0 {
+		return nil
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(source, &node); err != nil {
+		return nil
+	}
+	if node.Kind != yaml.DocumentNode || len(node.Content) == 0 {
+		return nil
+	}
+	return node.Content[0]
+}
+
+// sourceLine returns the line of the node pointed by the JSON pointer.
+// If the node is not found, the line of the nearest ancestor is returned.
+func sourceLine(root *yaml.Node, pointer string) int {
+	node := root
+	line := node.Line
+	if pointer == "" {
+		return line
+	}
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = jsonPointerUnreplacer.Replace(token)
+		next := childNode(node, token)
+		if next == nil {
+			break
+		}
+		node = next
+		line = node.Line
+	}
+	return line
+}
+
+func childNode(node *yaml.Node, token string) *yaml.Node {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == token {
+				// use the line of the key
+				v := node.Content[i+1]
+				ret := *v
+				ret.Line = node.Content[i].Line
+				return &ret
+			}
+		}
+	case yaml.SequenceNode:
+		if i, err := strconv.Atoi(token); err == nil && 0 <= i && i < len(node.Content) {
+			return node.Content[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package openapi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestJsonPointer(t *testing.T) {
+	tests := []struct {
+		tokens []string
+		want   string
+	}{
+		{[]string{}, ""},
+		{[]string{"info"}, "/info"},
+		{[]string{"paths", "/pets/{id}", "get"}, "/paths/~1pets~1{id}/get"},
+		{[]string{"components", "schemas", "a~b"}, "/components/schemas/a~0b"},
+	}
+	for _, test := range tests {
+		got := jsonPointer(test.tokens...)
+		if got != test.want {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+}
+
+func TestSourceLine(t *testing.T) {
+	source := `openapi: 3.0.0
+info:
+  title: test
+paths:
+  /pets/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+`
+	root := parseSource([]byte(source))
+	tests := []struct {
+		pointer string
+		want    int
+	}{
+		{"", 1},
+		{"/info", 2},
+		{"/info/title", 3},
+		{"/paths/~1pets~1{id}/get", 6},
+		{"/paths/~1pets~1{id}/get/parameters/0/in", 9},
+		{"/paths/~1pets~1{id}/get/responses", 6},
+		{"/paths/~1foo", 4},
+	}
+	for _, test := range tests {
+		got := sourceLine(root, test.pointer)
+		if got != test.want {
+			t.Errorf("pointer=%v: got=%v, want=%v", test.pointer, got, test.want)
+		}
+	}
+}
+
+func TestMissingPathParams(t *testing.T) {
+	param := func(name, in string) *openapi3.ParameterRef {
+		return &openapi3.ParameterRef{Value: &openapi3.Parameter{Name: name, In: in}}
+	}
+	tests := []struct {
+		uriPath    string
+		pathParams openapi3.Parameters
+		opParams   openapi3.Parameters
+		want       []string
+	}{
+		{"/pets", nil, nil, []string{}},
+		{"/pets/{id}", nil, openapi3.Parameters{param("id", "path")}, []string{}},
+		{"/pets/{id}", openapi3.Parameters{param("id", "path")}, nil, []string{}},
+		{"/pets/{id}", nil, openapi3.Parameters{param("id", "query")}, []string{"id"}},
+		{"/pets/{id}/toys/{toyId}", nil, openapi3.Parameters{param("id", "path")}, []string{"toyId"}},
+	}
+	for _, test := range tests {
+		got := missingPathParams(test.uriPath, test.pathParams, test.opParams)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package recent
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/lusingander/topi/internal/config"
+	"github.com/lusingander/topi/internal/topi"
+)
+
+const (
+	fileName = "recent.json"
+
+	// DefaultLimit is the number of the operations kept per spec if the limit is not set.
+	DefaultLimit = 20
+)
+
+// Entry identifies a viewed operation by method and path, same as the bookmarks.
+type Entry struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+}
+
+func (e *Entry) matches(p *topi.Path) bool {
+	return e.Method == p.Method && e.Path == p.UriPath
+}
+
+// Store keeps the recently viewed operations of all specs in a file.
+type Store struct {
+	path  string
+	limit int
+	specs map[string][]*Entry
+}
+
+// DefaultPath returns the path of the recent file in the data directory.
+func DefaultPath() (string, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fileName), nil
+}
+
+// Load reads the recent file. If the file does not exist, returns an empty store.
+// If the file can not be read or is broken, returns an empty store with the error, which can still be used.
+// If limit is not positive, DefaultLimit is used.
+func Load(path string, limit int) (*Store, error) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	s := &Store{
+		path:  path,
+		limit: limit,
+		specs: make(map[string][]*Entry),
+	}
+	bs, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(bs, &s.specs); err != nil {
+		s.specs = make(map[string][]*Entry)
+		return s, fmt.Errorf("broken recent file: %w", err)
+	}
+	return s, nil
+}
+
+// List returns the operations of the spec, the most recent first.
+func (s *Store) List(spec string) []*Entry {
+	return s.specs[spec]
+}
+
+// Add moves the operation to the top of the list (drops the oldest if the list is full) and saves the file.
+func (s *Store) Add(spec string, p *topi.Path) error {
+	es := []*Entry{{Method: p.Method, Path: p.UriPath}}
+	for _, e := range s.specs[spec] {
+		if !e.matches(p) && len(es) < s.limit {
+			es = append(es, e)
+		}
+	}
+	s.specs[spec] = es
+	return s.save()
+}
+
+func (s *Store) save() error {
+	bs, err := json.MarshalIndent(s.specs, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, bs, 0644)
+}
+
+// Resolve returns the operations of the entries found in the document, and the number of the entries not found.
+func Resolve(doc *topi.Document, es []*Entry) ([]*topi.Path, int) {
+	found := make([]*topi.Path, 0)
+	notFound := 0
+	for _, e := range es {
+		if p := doc.FindPath(e.Method, e.Path); p != nil {
+			found = append(found, p)
+		} else {
+			notFound++
+		}
+	}
+	return found, notFound
+}
@@ -0,0 +1,81 @@
+package recent
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/lusingander/topi/internal/topi"
+)
+
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "recent.json")
+	s, err := Load(path, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	get := &topi.Path{Method: "GET", UriPath: "/pets"}
+	post := &topi.Path{Method: "POST", UriPath: "/pets"}
+	del := &topi.Path{Method: "DELETE", UriPath: "/pets/{id}"}
+
+	tests := []struct {
+		path *topi.Path
+		want []*Entry
+	}{
+		{get, []*Entry{{"GET", "/pets"}}},
+		{post, []*Entry{{"POST", "/pets"}, {"GET", "/pets"}}},
+		{get, []*Entry{{"GET", "/pets"}, {"POST", "/pets"}}},
+		{del, []*Entry{{"DELETE", "/pets/{id}"}, {"GET", "/pets"}}},
+	}
+	for _, test := range tests {
+		if err := s.Add("Pet API", test.path); err != nil {
+			t.Fatal(err)
+		}
+		// reload to check the saved file
+		s, err = Load(path, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := s.List("Pet API"); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+	if got := s.List("other"); len(got) != 0 {
+		t.Errorf("got=%v, want=[]", got)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	get := &topi.Path{Method: "GET", UriPath: "/pets", OperationId: "listPets"}
+	doc := topi.NewDocument(&topi.Meta{}, &topi.Info{}, map[string][]*topi.Path{"pets": {get}}, nil, nil)
+	es := []*Entry{{"DELETE", "/pets"}, {"GET", "/pets"}}
+
+	found, notFound := Resolve(doc, es)
+	if want := []*topi.Path{get}; !reflect.DeepEqual(found, want) {
+		t.Errorf("got=%v, want=%v", found, want)
+	}
+	if notFound != 1 {
+		t.Errorf("got=%v, want=%v", notFound, 1)
+	}
+}
+
+func TestLoadBroken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "recent.json")
+	if err := os.WriteFile(path, []byte(`{"Pet API": [{"method": 1}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := Load(path, 0)
+	if err == nil {
+		t.Errorf("error is expected")
+	}
+	if s == nil || len(s.List("Pet API")) != 0 {
+		t.Fatalf("empty store is expected: %v", s)
+	}
+	if err := s.Add("Pet API", &topi.Path{Method: "GET", UriPath: "/pets"}); err != nil {
+		t.Fatal(err)
+	}
+	if s, err = Load(path, 0); err != nil || len(s.List("Pet API")) != 1 {
+		t.Errorf("got=(%v, %v), want one entry", s.List("Pet API"), err)
+	}
+}
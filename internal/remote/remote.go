@@ -0,0 +1,212 @@
+package remote
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Config is the settings of the HTTP client to load the remote specs and external refs.
+// The headers and the credentials are sent only to Hosts, or to the hosts of the specs given as paths if Hosts is empty.
+type Config struct {
+	Headers     map[string]string `yaml:"headers"`
+	Hosts       []string          `yaml:"hosts"` // "host" or "host:port"
+	BearerToken string            `yaml:"bearer_token"`
+	BasicAuth   string            `yaml:"basic_auth"` // "username:password"
+	CACert      string            `yaml:"ca_cert"`    // PEM file of the CA certificates added to the system pool
+	Insecure    bool              `yaml:"insecure"`   // skip verifying the server certificates
+	Timeout     time.Duration     `yaml:"timeout"`
+	Proxy       string            `yaml:"proxy"` // proxy URL, the environment variables (HTTP_PROXY etc.) are used if empty
+}
+
+// Merge returns the config overridden by the values set in c2.
+func (c Config) Merge(c2 Config) Config {
+	ret := c
+	ret.Headers = make(map[string]string)
+	for k, v := range c.Headers {
+		ret.Headers[k] = v
+	}
+	for k, v := range c2.Headers {
+		ret.Headers[k] = v
+	}
+	if len(c2.Hosts) > 0 {
+		ret.Hosts = c2.Hosts
+	}
+	if c2.BearerToken != "" || c2.BasicAuth != "" {
+		// the credentials of c2 replace both kinds, not to conflict with the other one
+		ret.BearerToken = c2.BearerToken
+		ret.BasicAuth = c2.BasicAuth
+	}
+	if c2.CACert != "" {
+		ret.CACert = c2.CACert
+	}
+	if c2.Insecure {
+		ret.Insecure = true
+	}
+	if c2.Timeout != 0 {
+		ret.Timeout = c2.Timeout
+	}
+	if c2.Proxy != "" {
+		ret.Proxy = c2.Proxy
+	}
+	return ret
+}
+
+// WithoutCredentials returns the config without the headers and the credentials,
+// used for the requests to the hosts other than the specs (e.g. the token endpoints).
+func (c Config) WithoutCredentials() Config {
+	ret := c
+	ret.Headers = nil
+	ret.Hosts = nil
+	ret.BearerToken = ""
+	ret.BasicAuth = ""
+	return ret
+}
+
+// ParseHeader parses "Name: value".
+func ParseHeader(s string) (string, string, error) {
+	name, value, ok := strings.Cut(s, ":")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return "", "", fmt.Errorf("invalid header (must be \"Name: value\"): %s", s)
+	}
+	return name, strings.TrimSpace(value), nil
+}
+
+// Validate checks the values which can be checked without accessing the files and the network.
+func (c *Config) Validate() error {
+	if c.BearerToken != "" && c.BasicAuth != "" {
+		return errors.New("only one of bearer_token and basic_auth can be set")
+	}
+	if c.BasicAuth != "" && !strings.Contains(c.BasicAuth, ":") {
+		return errors.New("invalid basic_auth (must be \"username:password\")")
+	}
+	if c.Timeout < 0 {
+		return fmt.Errorf("invalid timeout: %s", c.Timeout)
+	}
+	if c.Proxy != "" {
+		if _, err := url.Parse(c.Proxy); err != nil {
+			return fmt.Errorf("invalid proxy: %w", err)
+		}
+	}
+	return nil
+}
+
+// NewClient returns the HTTP client with the settings.
+// Environment variables in the header values and the credentials (e.g. "${API_TOKEN}") are expanded.
+// The headers are sent to the hosts of the remote specs in paths unless the hosts are set in the config,
+// not to leak the credentials to the hosts of external refs or redirects.
+func NewClient(c Config, paths []string) (*http.Client, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if c.Proxy != "" {
+		u, _ := url.Parse(c.Proxy)
+		transport.Proxy = http.ProxyURL(u)
+	}
+	if c.CACert != "" || c.Insecure {
+		tlsConfig := &tls.Config{
+			InsecureSkipVerify: c.Insecure,
+		}
+		if c.CACert != "" {
+			pool, err := certPool(c.CACert)
+			if err != nil {
+				return nil, err
+			}
+			tlsConfig.RootCAs = pool
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+	headers := make(http.Header)
+	for k, v := range c.Headers {
+		headers.Set(k, os.ExpandEnv(v))
+	}
+	switch {
+	case c.BearerToken != "":
+		headers.Set("Authorization", "Bearer "+os.ExpandEnv(c.BearerToken))
+	case c.BasicAuth != "":
+		auth := base64.StdEncoding.EncodeToString([]byte(os.ExpandEnv(c.BasicAuth)))
+		headers.Set("Authorization", "Basic "+auth)
+	}
+	hosts := c.Hosts
+	if len(hosts) == 0 {
+		hosts = specHosts(paths)
+	}
+	return &http.Client{
+		Transport: &headerTransport{base: transport, headers: headers, hosts: hosts},
+		Timeout:   c.Timeout,
+	}, nil
+}
+
+func certPool(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", path)
+	}
+	return pool, nil
+}
+
+// specHosts returns the hosts of the remote specs, the paths which are not URLs (e.g. local files) are skipped.
+func specHosts(paths []string) []string {
+	hosts := make([]string, 0)
+	for _, p := range paths {
+		u, err := url.ParseRequestURI(p)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			continue
+		}
+		hosts = append(hosts, u.Host)
+	}
+	return hosts
+}
+
+// matchHost reports whether the host of the request matches the entry, the port is compared only if the entry has it.
+func matchHost(u *url.URL, entry string) bool {
+	if strings.Contains(entry, ":") && !strings.HasSuffix(entry, "]") {
+		return strings.EqualFold(u.Host, entry)
+	}
+	return strings.EqualFold(u.Hostname(), strings.Trim(entry, "[]"))
+}
+
+// headerTransport adds the headers to the requests to the hosts.
+// The headers are not added to the other hosts, so the Authorization header stripped by http.Client
+// on the redirect to another host is not added again.
+type headerTransport struct {
+	base    http.RoundTripper
+	headers http.Header
+	hosts   []string
+}
+
+func (t *headerTransport) allowed(u *url.URL) bool {
+	for _, h := range t.hosts {
+		if matchHost(u, h) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.allowed(req.URL) {
+		return t.base.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	for k, vs := range t.headers {
+		req.Header[k] = vs
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,266 @@
+package remote
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMerge(t *testing.T) {
+	c1 := Config{
+		Headers:     map[string]string{"X-A": "a", "X-B": "b"},
+		BearerToken: "token",
+		Timeout:     10 * time.Second,
+	}
+	c2 := Config{
+		Headers:  map[string]string{"X-B": "bb"},
+		Insecure: true,
+		Timeout:  time.Second,
+	}
+	want := Config{
+		Headers:     map[string]string{"X-A": "a", "X-B": "bb"},
+		BearerToken: "token",
+		Insecure:    true,
+		Timeout:     time.Second,
+	}
+	if got := c1.Merge(c2); !reflect.DeepEqual(got, want) {
+		t.Errorf("got=%v, want=%v", got, want)
+	}
+}
+
+func TestMergeCredentials(t *testing.T) {
+	tests := []struct {
+		c1, c2 Config
+		want   Config
+	}{
+		{
+			Config{BearerToken: "token"},
+			Config{BasicAuth: "user:pass"},
+			Config{Headers: map[string]string{}, BasicAuth: "user:pass"},
+		},
+		{
+			Config{BasicAuth: "user:pass"},
+			Config{BearerToken: "token"},
+			Config{Headers: map[string]string{}, BearerToken: "token"},
+		},
+		{
+			Config{BearerToken: "token"},
+			Config{BearerToken: "token2"},
+			Config{Headers: map[string]string{}, BearerToken: "token2"},
+		},
+		{
+			Config{BasicAuth: "user:pass"},
+			Config{},
+			Config{Headers: map[string]string{}, BasicAuth: "user:pass"},
+		},
+	}
+	for _, test := range tests {
+		got := test.c1.Merge(test.c2)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+		if err := got.Validate(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestWithoutCredentials(t *testing.T) {
+	c := Config{
+		Headers:     map[string]string{"X-A": "a"},
+		Hosts:       []string{"example.com"},
+		BearerToken: "token",
+		CACert:      "ca.pem",
+		Timeout:     time.Second,
+		Proxy:       "http://proxy.example.com",
+	}
+	want := Config{
+		CACert:  "ca.pem",
+		Timeout: time.Second,
+		Proxy:   "http://proxy.example.com",
+	}
+	if got := c.WithoutCredentials(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got=%v, want=%v", got, want)
+	}
+}
+
+func TestParseHeader(t *testing.T) {
+	tests := []struct {
+		s         string
+		wantName  string
+		wantValue string
+		wantErr   bool
+	}{
+		{"X-Api-Key: abc", "X-Api-Key", "abc", false},
+		{"X-Empty:", "X-Empty", "", false},
+		{"Authorization: Bearer a:b", "Authorization", "Bearer a:b", false},
+		{"X-Api-Key", "", "", true},
+		{": abc", "", "", true},
+	}
+	for _, test := range tests {
+		name, value, err := ParseHeader(test.s)
+		if (err != nil) != test.wantErr {
+			t.Errorf("unexpected error: %s: %v", test.s, err)
+			continue
+		}
+		if name != test.wantName || value != test.wantValue {
+			t.Errorf("got=(%v, %v), want=(%v, %v)", name, value, test.wantName, test.wantValue)
+		}
+	}
+}
+
+// newTestServer returns the TLS server which requires the headers, and the path of its CA certificate.
+func newTestServer(t *testing.T, headers map[string]string) (*httptest.Server, string) {
+	t.Helper()
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for k, v := range headers {
+			if r.Header.Get(k) != v {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		}
+		w.Write([]byte("ok"))
+	}))
+	t.Cleanup(ts.Close)
+	ca := filepath.Join(t.TempDir(), "ca.pem")
+	bs := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+	if err := os.WriteFile(ca, bs, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return ts, ca
+}
+
+func TestNewClient(t *testing.T) {
+	t.Setenv("TOPI_TEST_TOKEN", "secret")
+	ts, ca := newTestServer(t, map[string]string{
+		"Authorization": "Bearer secret",
+		"X-Api-Version": "2",
+	})
+
+	tests := []struct {
+		config     Config
+		wantStatus int
+		wantErr    bool
+	}{
+		{Config{CACert: ca, BearerToken: "${TOPI_TEST_TOKEN}", Headers: map[string]string{"X-Api-Version": "2"}}, http.StatusOK, false},
+		{Config{Insecure: true, BearerToken: "secret", Headers: map[string]string{"X-Api-Version": "2"}}, http.StatusOK, false},
+		{Config{CACert: ca, BearerToken: "wrong", Headers: map[string]string{"X-Api-Version": "2"}}, http.StatusUnauthorized, false},
+		{Config{BearerToken: "secret", Headers: map[string]string{"X-Api-Version": "2"}}, 0, true}, // unknown authority
+	}
+	for _, test := range tests {
+		client, err := NewClient(test.config, []string{ts.URL + "/openapi.yaml"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Get(ts.URL)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("error is expected: %v", test.config)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode != test.wantStatus {
+			t.Errorf("got=%v, want=%v", resp.StatusCode, test.wantStatus)
+		}
+	}
+}
+
+func TestNewClientBasicAuth(t *testing.T) {
+	ts, ca := newTestServer(t, map[string]string{"Authorization": "Basic dXNlcjpwYXNz"}) // user:pass
+	client, err := NewClient(Config{CACert: ca, BasicAuth: "user:pass"}, []string{ts.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Get(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("got=%v, want=%v", resp.StatusCode, http.StatusOK)
+	}
+}
+
+func TestNewClientHosts(t *testing.T) {
+	var got http.Header
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header
+	}))
+	t.Cleanup(other.Close)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, other.URL, http.StatusFound)
+			return
+		}
+		got = r.Header
+	}))
+	t.Cleanup(ts.Close)
+	otherHost := strings.TrimPrefix(other.URL, "http://")
+
+	tests := []struct {
+		config Config
+		paths  []string
+		url    string
+		want   bool
+	}{
+		{Config{}, []string{ts.URL + "/openapi.yaml"}, ts.URL + "/schemas.yaml", true},
+		{Config{}, []string{ts.URL + "/openapi.yaml"}, other.URL + "/schemas.yaml", false},
+		{Config{}, []string{ts.URL + "/openapi.yaml"}, ts.URL + "/redirect", false},
+		{Config{}, []string{"openapi.yaml"}, ts.URL, false},
+		{Config{Hosts: []string{otherHost}}, []string{ts.URL}, other.URL, true},
+		{Config{Hosts: []string{otherHost}}, []string{ts.URL}, ts.URL, false},
+		{Config{Hosts: []string{"127.0.0.1"}}, nil, other.URL, true},
+		{Config{Hosts: []string{"127.0.0.1:1"}}, nil, other.URL, false},
+	}
+	for _, test := range tests {
+		test.config.BearerToken = "secret"
+		test.config.Headers = map[string]string{"X-Api-Key": "key"}
+		client, err := NewClient(test.config, test.paths)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = nil
+		resp, err := client.Get(test.url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		sent := got.Get("Authorization") == "Bearer secret" && got.Get("X-Api-Key") == "key"
+		leaked := got.Get("Authorization") != "" || got.Get("X-Api-Key") != ""
+		if sent != test.want || (!test.want && leaked) {
+			t.Errorf("got=%v, want=%v (%v, %v)", got, test.want, test.config.Hosts, test.url)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		config Config
+		want   string
+	}{
+		{Config{BearerToken: "a", BasicAuth: "u:p"}, "only one of bearer_token and basic_auth can be set"},
+		{Config{BasicAuth: "user"}, "invalid basic_auth (must be \"username:password\")"},
+		{Config{Timeout: -time.Second}, "invalid timeout: -1s"},
+	}
+	for _, test := range tests {
+		err := test.config.Validate()
+		if err == nil {
+			t.Errorf("error is expected: %v", test.config)
+			continue
+		}
+		if got := err.Error(); got != test.want {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+}
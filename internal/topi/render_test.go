@@ -0,0 +1,196 @@
+package topi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSchemaTypeString(t *testing.T) {
+	tests := []struct {
+		schema *Schema
+		want   string
+	}{
+		{
+			schema: &Schema{},
+			want:   "",
+		},
+		{
+			schema: &Schema{
+				Type: "integer",
+			},
+			want: "integer",
+		},
+		{
+			schema: &Schema{
+				Type:   "string",
+				Format: "password",
+			},
+			want: "string(password)",
+		},
+		{
+			schema: &Schema{
+				Type: "array",
+				Items: &Schema{
+					Type: "boolean",
+				},
+			},
+			want: "array of boolean",
+		},
+		{
+			schema: &Schema{
+				Type: "object",
+				Properties: map[string]*Schema{
+					"foo": {Type: "number"},
+				},
+			},
+			want: "object",
+		},
+		{
+			schema: &Schema{
+				OneOf: []*Schema{
+					{Type: "integer"},
+					{Type: "object", Properties: map[string]*Schema{}},
+					{Type: "string"},
+					{Type: "object", Properties: map[string]*Schema{}},
+				},
+			},
+			want: "one of (integer | object[2] | string | object[4])",
+		},
+		{
+			schema: &Schema{
+				AllOf: []*Schema{
+					{Type: "integer"},
+					{Type: "boolean"},
+					{Type: "string"},
+				},
+			},
+			want: "string",
+		},
+		{
+			schema: &Schema{
+				AllOf: []*Schema{
+					{Type: "object", Properties: map[string]*Schema{}},
+					{Type: "object", Properties: map[string]*Schema{}},
+				},
+			},
+			want: "object",
+		},
+	}
+	for _, test := range tests {
+		got := test.schema.TypeString()
+		if got != test.want {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+}
+
+func TestSchemaConstraintStrings(t *testing.T) {
+	tests := []struct {
+		schema *Schema
+		want   []string
+	}{
+		{
+			schema: &Schema{
+				Type: "number",
+				Min:  ptr[float64](1),
+				Max:  ptr[float64](10),
+			},
+			want: []string{
+				"1 <= n <= 10",
+			},
+		},
+		{
+			schema: &Schema{
+				Type:         "number",
+				Min:          ptr(-123.45),
+				Max:          ptr[float64](0),
+				ExclusiveMin: true,
+				ExclusiveMax: true,
+			},
+			want: []string{
+				"-123.45 < n < 0",
+			},
+		},
+		{
+			schema: &Schema{
+				Type:       "integer",
+				MultipleOf: ptr[float64](5),
+			},
+			want: []string{
+				"multiple of 5",
+			},
+		},
+		{
+			schema: &Schema{
+				Type:       "number",
+				Max:        ptr[float64](20),
+				MultipleOf: ptr(0.5),
+			},
+			want: []string{
+				"n <= 20",
+				"multiple of 0.5",
+			},
+		},
+		{
+			schema: &Schema{
+				Type:      "string",
+				MinLength: 1,
+				MaxLength: ptr[uint64](30),
+				Pattern:   "/(?=.*[a-z])(?=.*[A-Z])(?=.*[0-9])/",
+			},
+			want: []string{
+				"1 <= len <= 30",
+				"/(?=.*[a-z])(?=.*[A-Z])(?=.*[0-9])/",
+			},
+		},
+		{
+			schema: &Schema{
+				Type:     "array",
+				MinItems: 2,
+				MaxItems: ptr[uint64](5),
+			},
+			want: []string{
+				"2 <= items <= 5",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		got := test.schema.ConstraintStrings()
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+}
+
+func TestSliceString(t *testing.T) {
+	tests := []struct {
+		slice []interface{}
+		want  string
+	}{
+		{
+			slice: []interface{}{},
+			want:  "[]",
+		},
+		{
+			slice: []interface{}{"foo", "bar", "baz"},
+			want:  "[foo, bar, baz]",
+		},
+		{
+			slice: []interface{}{1},
+			want:  "[1]",
+		},
+		{
+			slice: []interface{}{0.25, "abc"},
+			want:  "[0.25, abc]",
+		},
+	}
+
+	for _, test := range tests {
+		got := SliceString(test.slice)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package topi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// max number of the items or properties used in the serialization examples
+const (
+	maxSampleItems      = 3
+	maxSampleProperties = 3
+)
+
+type sampleEntry struct {
+	key   string // empty for array elements
+	value string
+}
+
+// SerializationExample returns an example of the serialized parameter with sample values,
+// following the style values defined in the OpenAPI Specification.
+// Returns empty if the parameter is not an array or an object, or the style does not support the type.
+func (p *Parameter) SerializationExample() string {
+	if p.Schema == nil {
+		return ""
+	}
+	var entries []sampleEntry
+	object := false
+	switch p.Schema.Type {
+	case "array":
+		entries = sampleArray(p.Schema.Items)
+	case "object":
+		entries = sampleObject(p.Schema)
+		object = true
+	}
+	if len(entries) == 0 {
+		return ""
+	}
+	return serialize(p.Name, entries, object, p.SerializationStyle(), p.Exploded())
+}
+
+func serialize(name string, entries []sampleEntry, object bool, style string, explode bool) string {
+	values := func() []string {
+		ret := make([]string, 0, len(entries)*2)
+		for _, e := range entries {
+			if object {
+				ret = append(ret, e.key)
+			}
+			ret = append(ret, e.value)
+		}
+		return ret
+	}
+	pairs := func(sep string) []string {
+		ret := make([]string, len(entries))
+		for i, e := range entries {
+			if object {
+				ret[i] = e.key + sep + e.value
+			} else {
+				ret[i] = name + sep + e.value
+			}
+		}
+		return ret
+	}
+
+	switch style {
+	case StyleMatrix:
+		if explode {
+			return ";" + strings.Join(pairs("="), ";")
+		}
+		return fmt.Sprintf(";%s=%s", name, strings.Join(values(), ","))
+	case StyleLabel:
+		if explode && object {
+			return "." + strings.Join(pairs("="), ".")
+		}
+		return "." + strings.Join(values(), ".")
+	case StyleForm:
+		if explode {
+			return strings.Join(pairs("="), "&")
+		}
+		return fmt.Sprintf("%s=%s", name, strings.Join(values(), ","))
+	case StyleSimple:
+		if explode && object {
+			return strings.Join(pairs("="), ",")
+		}
+		return strings.Join(values(), ",")
+	case StyleSpaceDelimited, StylePipeDelimited:
+		if explode {
+			return ""
+		}
+		sep := "%20"
+		if style == StylePipeDelimited {
+			sep = "|"
+		}
+		return fmt.Sprintf("%s=%s", name, strings.Join(values(), sep))
+	case StyleDeepObject:
+		if !object {
+			return ""
+		}
+		ret := make([]string, len(entries))
+		for i, e := range entries {
+			ret[i] = fmt.Sprintf("%s[%s]=%s", name, e.key, e.value)
+		}
+		return strings.Join(ret, "&")
+	}
+	return ""
+}
+
+func sampleArray(items *Schema) []sampleEntry {
+	if items == nil {
+		return nil
+	}
+	if len(items.Enum) > 0 {
+		ret := make([]sampleEntry, 0, maxSampleItems)
+		for _, v := range items.Enum {
+			if len(ret) == maxSampleItems {
+				break
+			}
+			ret = append(ret, sampleEntry{value: fmt.Sprint(v)})
+		}
+		return ret
+	}
+	var values []string
+	switch items.Type {
+	case "integer":
+		values = []string{"1", "2", "3"}
+	case "number":
+		values = []string{"1.5", "2.5", "3.5"}
+	case "boolean":
+		values = []string{"true", "false"}
+	case "string", "":
+		values = []string{"a", "b", "c"}
+	default:
+		return nil // nested arrays and objects are not defined
+	}
+	ret := make([]sampleEntry, len(values))
+	for i, v := range values {
+		ret[i] = sampleEntry{value: v}
+	}
+	return ret
+}
+
+func sampleObject(sc *Schema) []sampleEntry {
+	ret := make([]sampleEntry, 0, maxSampleProperties)
+	for _, name := range sc.propertyNames() {
+		if len(ret) == maxSampleProperties {
+			break
+		}
+		if v, ok := sampleValue(sc.Properties[name]); ok {
+			ret = append(ret, sampleEntry{key: name, value: v})
+		}
+	}
+	return ret
+}
+
+// propertyNames returns the names of the properties in sorted order.
+func (sc *Schema) propertyNames() []string {
+	names := make([]string, 0, len(sc.Properties))
+	for name := range sc.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sampleValue(sc *Schema) (string, bool) {
+	if sc == nil {
+		return "", false
+	}
+	if sc.Default != nil {
+		return fmt.Sprint(sc.Default), true
+	}
+	if len(sc.Enum) > 0 {
+		return fmt.Sprint(sc.Enum[0]), true
+	}
+	switch sc.Type {
+	case "integer":
+		return "1", true
+	case "number":
+		return "1.5", true
+	case "boolean":
+		return "true", true
+	case "string", "":
+		return "a", true
+	}
+	return "", false
+}
@@ -0,0 +1,101 @@
+package topi
+
+import "testing"
+
+func TestParameterSerializationExample(t *testing.T) {
+	array := &Schema{Type: "array", Items: &Schema{Type: "string", Enum: []interface{}{"blue", "black", "brown"}}}
+	object := &Schema{Type: "object", Properties: map[string]*Schema{
+		"R": {Type: "integer", Default: 100},
+		"G": {Type: "integer", Default: 200},
+		"B": {Type: "integer", Default: 150},
+	}}
+	tests := []struct {
+		in      string
+		style   string
+		explode *bool
+		schema  *Schema
+		want    string
+	}{
+		// https://spec.openapis.org/oas/v3.0.3#style-examples (objects are ordered by the property names)
+		{"path", StyleMatrix, ptr(false), array, ";color=blue,black,brown"},
+		{"path", StyleMatrix, ptr(false), object, ";color=B,150,G,200,R,100"},
+		{"path", StyleMatrix, ptr(true), array, ";color=blue;color=black;color=brown"},
+		{"path", StyleMatrix, ptr(true), object, ";B=150;G=200;R=100"},
+		{"path", StyleLabel, ptr(false), array, ".blue.black.brown"},
+		{"path", StyleLabel, ptr(false), object, ".B.150.G.200.R.100"},
+		{"path", StyleLabel, ptr(true), array, ".blue.black.brown"},
+		{"path", StyleLabel, ptr(true), object, ".B=150.G=200.R=100"},
+		{"query", StyleForm, ptr(false), array, "color=blue,black,brown"},
+		{"query", StyleForm, ptr(false), object, "color=B,150,G,200,R,100"},
+		{"query", "", nil, array, "color=blue&color=black&color=brown"},
+		{"query", "", nil, object, "B=150&G=200&R=100"},
+		{"path", "", nil, array, "blue,black,brown"},
+		{"path", "", nil, object, "B,150,G,200,R,100"},
+		{"header", StyleSimple, ptr(true), array, "blue,black,brown"},
+		{"header", StyleSimple, ptr(true), object, "B=150,G=200,R=100"},
+		{"query", StyleSpaceDelimited, ptr(false), array, "color=blue%20black%20brown"},
+		{"query", StyleSpaceDelimited, ptr(false), object, "color=B%20150%20G%20200%20R%20100"},
+		{"query", StylePipeDelimited, ptr(false), array, "color=blue|black|brown"},
+		{"query", StylePipeDelimited, ptr(false), object, "color=B|150|G|200|R|100"},
+		{"query", StyleSpaceDelimited, ptr(true), array, ""},
+		{"query", StylePipeDelimited, ptr(true), array, ""},
+		{"query", StyleDeepObject, ptr(true), object, "color[B]=150&color[G]=200&color[R]=100"},
+		{"query", StyleDeepObject, ptr(true), array, ""},
+		{"query", "", nil, &Schema{Type: "string"}, ""},
+		{"query", "", nil, &Schema{Type: "array", Items: &Schema{Type: "integer"}}, "color=1&color=2&color=3"},
+	}
+	for _, tt := range tests {
+		p := &Parameter{Name: "color", In: tt.in, Style: tt.style, Explode: tt.explode, Schema: tt.schema}
+		got := p.SerializationExample()
+		if got != tt.want {
+			t.Errorf("got=%v, want=%v", got, tt.want)
+		}
+	}
+}
+
+func TestParameterSerializationDefaults(t *testing.T) {
+	tests := []struct {
+		in          string
+		style       string
+		explode     *bool
+		wantStyle   string
+		wantExplode bool
+	}{
+		{"query", "", nil, StyleForm, true},
+		{"cookie", "", nil, StyleForm, true},
+		{"path", "", nil, StyleSimple, false},
+		{"header", "", nil, StyleSimple, false},
+		{"query", StyleForm, ptr(false), StyleForm, false},
+		{"path", StyleMatrix, nil, StyleMatrix, false},
+	}
+	for _, tt := range tests {
+		p := &Parameter{In: tt.in, Style: tt.style, Explode: tt.explode}
+		if got := p.SerializationStyle(); got != tt.wantStyle {
+			t.Errorf("got=%v, want=%v", got, tt.wantStyle)
+		}
+		if got := p.Exploded(); got != tt.wantExplode {
+			t.Errorf("got=%v, want=%v", got, tt.wantExplode)
+		}
+	}
+}
+
+func TestParameterExplodeApplicable(t *testing.T) {
+	tests := []struct {
+		style   string
+		explode *bool
+		want    bool
+	}{
+		{StyleForm, ptr(true), true},
+		{StyleSpaceDelimited, nil, true},
+		{StyleSpaceDelimited, ptr(false), true},
+		{StyleSpaceDelimited, ptr(true), false},
+		{StylePipeDelimited, ptr(true), false},
+		{StyleDeepObject, ptr(true), true},
+	}
+	for _, tt := range tests {
+		p := &Parameter{In: "query", Style: tt.style, Explode: tt.explode}
+		if got := p.ExplodeApplicable(); got != tt.want {
+			t.Errorf("got=%v, want=%v", got, tt.want)
+		}
+	}
+}
@@ -1,9 +1,11 @@
 package topi
 
 import (
+	"fmt"
 	"net/http"
 	"sort"
 	"strings"
+	"time"
 )
 
 const (
@@ -16,6 +18,12 @@ type Document struct {
 	TagPathMap map[string][]*Path
 	Tags       []*Tag
 	Components *Components
+	Servers    []*Server
+	Security   []*SecurityRequirement // applied to the operations which do not declare their own
+
+	ValidationErrors []*ValidationError
+
+	Sources SourceReader // nil if the sources are not available
 }
 
 func NewDocument(meta *Meta, info *Info, tagPathMap map[string][]*Path, tags []*Tag, components *Components) *Document {
@@ -84,9 +92,71 @@ func (d *Document) FindPathByOperationId(operationId string) *Path {
 	return nil
 }
 
+func (d *Document) FindPath(method, uriPath string) *Path {
+	for _, paths := range d.TagPathMap {
+		for _, path := range paths {
+			if path.Method == method && path.UriPath == uriPath {
+				return path
+			}
+		}
+	}
+	return nil
+}
+
 type Meta struct {
 	FileName string
 	FullPath string
+
+	// Stale is true if some remote documents could not be fetched and the cached contents are used.
+	Stale    bool
+	CachedAt time.Time // the oldest time the stale contents were fetched
+}
+
+type ValidationError struct {
+	Pointer string // JSON pointer
+	Line    int    // 0 if unknown
+	Message string
+}
+
+func (e *ValidationError) Location() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s (line %d)", e.Pointer, e.Line)
+	}
+	return e.Pointer
+}
+
+func (e *ValidationError) String() string {
+	return fmt.Sprintf("%s: %s", e.Location(), e.Message)
+}
+
+// Source is the location where the element is defined, which may be an external file referred by $ref.
+type Source struct {
+	File    string // file path, or URL of the remote document
+	Pointer string // JSON pointer in the file
+	Line    int    // 0 if unknown
+	Local   bool   // true if File is a local file which can be edited
+}
+
+func (s *Source) String() string {
+	if s.Line > 0 {
+		return fmt.Sprintf("%s#%s (line %d)", s.File, s.Pointer, s.Line)
+	}
+	return fmt.Sprintf("%s#%s", s.File, s.Pointer)
+}
+
+// SourceReader reads the raw contents of the sources.
+type SourceReader interface {
+	// Raw returns the contents of the node at the source and its format ("yaml" or "json").
+	// If deref is true, all $refs in the node are replaced by the referred contents.
+	Raw(src *Source, deref bool) (string, string, error)
+}
+
+// Contains reports whether o is in the same file as s and at or under the pointer of s.
+func (s *Source) Contains(o *Source) bool {
+	if s == nil || o == nil || s.File != o.File {
+		return false
+	}
+	return o.Pointer == s.Pointer || strings.HasPrefix(o.Pointer, s.Pointer+"/")
 }
 
 type Info struct {
@@ -102,6 +172,36 @@ type Info struct {
 	Version           string
 	ExDocsDescription string
 	ExDocsUrl         string
+
+	Source *Source
+}
+
+type Server struct {
+	Url         string
+	Description string
+}
+
+// DefaultServer returns the server whose url or description matches s.
+// If s is empty, returns the first server of the document, or nil if the document has no servers.
+// If s does not match any servers, s is regarded as a url.
+func (d *Document) DefaultServer(s string) *Server {
+	if s == "" {
+		if len(d.Servers) == 0 {
+			return nil
+		}
+		return d.Servers[0]
+	}
+	for _, server := range d.Servers {
+		if server.Url == s || server.Description == s {
+			return server
+		}
+	}
+	return &Server{Url: s}
+}
+
+// OperationUrl returns the url of the operation on the server.
+func (s *Server) OperationUrl(p *Path) string {
+	return strings.TrimSuffix(s.Url, "/") + p.UriPath
 }
 
 type Path struct {
@@ -117,7 +217,34 @@ type Path struct {
 	CookieParameters []*Parameter
 	RequestBody      *RequestBody
 	Responses        []*Response
-	Security         []*SecurityRequirement
+	Security         []*SecurityRequirement // effective requirements, including the inherited ones
+	SecurityOrigin   SecurityOrigin
+
+	Source *Source
+}
+
+// SecurityOrigin is where the effective security requirements of the operation come from.
+type SecurityOrigin int
+
+const (
+	SecurityUndeclared SecurityOrigin = iota // declared neither in the operation nor in the document
+	SecurityDeclared                         // declared in the operation
+	SecurityInherited                        // inherited from the document
+	SecurityOverridden                       // declared as empty in the operation to remove the document requirements
+)
+
+// Secured reports whether the operation requires any security scheme.
+// Returns false if one of the alternatives is empty, which means the security is optional.
+func (p *Path) Secured() bool {
+	if len(p.Security) == 0 {
+		return false
+	}
+	for _, r := range p.Security {
+		if len(r.Schemes) == 0 {
+			return false
+		}
+	}
+	return true
 }
 
 func comparePath(p1, p2 *Path) bool {
@@ -170,9 +297,70 @@ type Parameter struct {
 	Required    bool
 	Deprecated  bool
 	Schema      *Schema
+
+	Style           string // empty if not specified
+	Explode         *bool  // nil if not specified
+	AllowEmptyValue bool
+	AllowReserved   bool
+	Example         interface{}
+	Examples        []*Example
+	Content         []*MediaTypeContent // instead of Schema
+
+	Source *Source
+}
+
+// Serialization styles of parameters.
+const (
+	StyleMatrix         = "matrix"
+	StyleLabel          = "label"
+	StyleForm           = "form"
+	StyleSimple         = "simple"
+	StyleSpaceDelimited = "spaceDelimited"
+	StylePipeDelimited  = "pipeDelimited"
+	StyleDeepObject     = "deepObject"
+)
+
+// SerializationStyle returns the style, or the default style of the location if not specified.
+func (p *Parameter) SerializationStyle() string {
+	if p.Style != "" {
+		return p.Style
+	}
+	switch p.In {
+	case "query", "cookie":
+		return StyleForm
+	default:
+		return StyleSimple
+	}
+}
+
+// Exploded returns the explode, or the default value of the style if not specified.
+func (p *Parameter) Exploded() bool {
+	if p.Explode != nil {
+		return *p.Explode
+	}
+	return p.SerializationStyle() == StyleForm
+}
+
+// ExplodeApplicable reports whether the serialization is defined for the style with the explode.
+// spaceDelimited and pipeDelimited are not defined with explode (n/a in the specification).
+func (p *Parameter) ExplodeApplicable() bool {
+	switch p.SerializationStyle() {
+	case StyleSpaceDelimited, StylePipeDelimited:
+		return !p.Exploded()
+	}
+	return true
+}
+
+type Example struct {
+	Name          string
+	Summary       string
+	Description   string
+	Value         interface{}
+	ExternalValue string
 }
 
 type Schema struct {
+	Ref         string // name of the referenced schema in components, empty if not a reference
 	Type        string
 	Format      string
 	Default     interface{}
@@ -204,6 +392,30 @@ type Schema struct {
 	// object
 	Required   []string
 	Properties map[string]*Schema
+
+	Example interface{}
+	XML     *XML // nil if not specified
+
+	Source *Source
+}
+
+// XML is the metadata to represent the schema in XML.
+type XML struct {
+	Name      string
+	Namespace string
+	Prefix    string
+	Attribute bool
+	Wrapped   bool
+}
+
+// IsFile reports whether the schema is the contents of a file (e.g. a file upload in multipart request body).
+func (s *Schema) IsFile() bool {
+	return s.Type == "string" && (s.Format == "binary" || s.Format == "base64")
+}
+
+// IsFileArray reports whether the schema is an array of files (e.g. multiple file uploads).
+func (s *Schema) IsFileArray() bool {
+	return s.Type == "array" && s.Items != nil && s.Items.IsFile()
 }
 
 func (s *Schema) MergedAllOf() *Schema {
@@ -231,6 +443,9 @@ func (s *Schema) MergedAllOf() *Schema {
 		if len(schema.Required) > 0 {
 			ret.Required = append(ret.Required, schema.Required...)
 		}
+		if schema.XML != nil {
+			ret.XML = schema.XML
+		}
 	}
 	return ret
 }
@@ -244,6 +459,75 @@ type RequestBody struct {
 type MediaTypeContent struct {
 	MediaType string
 	Schema    *Schema
+	Encoding  []*Encoding // sorted by the property names
+}
+
+const (
+	mediaTypeMultipartPrefix = "multipart/"
+	mediaTypeFormUrlencoded  = "application/x-www-form-urlencoded"
+	mediaTypeOctetStream     = "application/octet-stream"
+	mediaTypeTextPlain       = "text/plain"
+	mediaTypeJson            = "application/json"
+)
+
+// IsXml reports whether the media type is XML (application/xml, text/xml or +xml suffix).
+func (c *MediaTypeContent) IsXml() bool {
+	mt := c.mediaType()
+	return mt == "application/xml" || mt == "text/xml" || strings.HasSuffix(mt, "+xml")
+}
+
+// IsMultipart reports whether the media type is multipart/*.
+func (c *MediaTypeContent) IsMultipart() bool {
+	return strings.HasPrefix(c.mediaType(), mediaTypeMultipartPrefix)
+}
+
+// IsFormUrlencoded reports whether the media type is application/x-www-form-urlencoded.
+func (c *MediaTypeContent) IsFormUrlencoded() bool {
+	return c.mediaType() == mediaTypeFormUrlencoded
+}
+
+// mediaType returns the media type without the parameters.
+func (c *MediaTypeContent) mediaType() string {
+	mt, _, _ := strings.Cut(c.MediaType, ";")
+	return strings.ToLower(strings.TrimSpace(mt))
+}
+
+// FindEncoding returns the encoding of the property, or nil if not defined.
+func (c *MediaTypeContent) FindEncoding(property string) *Encoding {
+	for _, e := range c.Encoding {
+		if e.Property == property {
+			return e
+		}
+	}
+	return nil
+}
+
+// Encoding is the encoding of a property of multipart or application/x-www-form-urlencoded request body.
+type Encoding struct {
+	Property      string
+	ContentType   string // empty if not specified
+	Headers       []*Header
+	Style         string // empty if not specified
+	Explode       *bool  // nil if not specified
+	AllowReserved bool
+}
+
+// DefaultPartContentType returns the default Content-Type of the part of multipart request body for the property schema.
+func DefaultPartContentType(sc *Schema) string {
+	if sc == nil {
+		return mediaTypeOctetStream
+	}
+	switch sc.Type {
+	case "object":
+		return mediaTypeJson
+	case "array":
+		return DefaultPartContentType(sc.Items)
+	case "string":
+		if sc.IsFile() {
+			return mediaTypeOctetStream
+		}
+	}
+	return mediaTypeTextPlain
 }
 
 type Response struct {
@@ -251,6 +535,8 @@ type Response struct {
 	Description string
 	Conetnt     []*MediaTypeContent
 	Headers     []*Header
+
+	Source *Source
 }
 
 type Header struct {
@@ -272,7 +558,22 @@ type Tag struct {
 }
 
 type SecurityRequirement struct {
-	Schemes []*SecurityRequirementScheme
+	Schemes []*SecurityRequirementScheme // empty if anonymous access is allowed
+}
+
+// EffectiveSecurity returns the security requirements applied to the operation and their origin.
+// declared is nil if the operation does not declare the security, and global is the document requirements.
+func EffectiveSecurity(declared, global []*SecurityRequirement) ([]*SecurityRequirement, SecurityOrigin) {
+	if declared != nil {
+		if len(declared) == 0 && len(global) > 0 {
+			return declared, SecurityOverridden
+		}
+		return declared, SecurityDeclared
+	}
+	if global != nil {
+		return global, SecurityInherited
+	}
+	return nil, SecurityUndeclared
 }
 
 type SecurityRequirementScheme struct {
@@ -281,6 +582,7 @@ type SecurityRequirementScheme struct {
 }
 
 type Components struct {
+	Schemas         map[string]*Schema
 	SecuritySchemes []*SecurityScheme
 }
 
@@ -300,6 +602,72 @@ type SecurityScheme struct {
 	OAuthFlows *OAtuhFlows // oauth2
 }
 
+// FindSecurityScheme returns the security scheme defined in the components, or nil if not found.
+func (d *Document) FindSecurityScheme(key string) *SecurityScheme {
+	if d.Components == nil {
+		return nil
+	}
+	for _, s := range d.Components.SecuritySchemes {
+		if s.Key == key {
+			return s
+		}
+	}
+	return nil
+}
+
+// ScopeOperations returns the operations requiring the security scheme, grouped by the required scopes.
+// The operations requiring the scheme without any scope are grouped by the empty string.
+// The operations are ordered in the same way as the tags and the paths.
+func (d *Document) ScopeOperations(key string) map[string][]*Path {
+	ret := make(map[string][]*Path)
+	added := make(map[string]map[*Path]bool)
+	add := func(scope string, p *Path) {
+		if added[scope] == nil {
+			added[scope] = make(map[*Path]bool)
+		}
+		if !added[scope][p] {
+			added[scope][p] = true
+			ret[scope] = append(ret[scope], p)
+		}
+	}
+	for _, tag := range d.Tags {
+		for _, p := range d.TagPathMap[tag.Name] {
+			for _, r := range p.Security {
+				for _, s := range r.Schemes {
+					if s.Key != key {
+						continue
+					}
+					if len(s.Scopes) == 0 {
+						add("", p)
+					}
+					for _, scope := range s.Scopes {
+						add(scope, p)
+					}
+				}
+			}
+		}
+	}
+	return ret
+}
+
+// Scopes returns the scopes defined in all flows without duplicates.
+func (f *OAtuhFlows) Scopes() []*Scope {
+	ret := make([]*Scope, 0)
+	seen := make(map[string]bool)
+	for _, flow := range []*OAuthFlow{f.AuthorizatonCodeFlow, f.ImplicitFlow, f.ResourceOwnerPasswordCredentialsFlow, f.ClientCredentialsFlow} {
+		if flow == nil {
+			continue
+		}
+		for _, s := range flow.Scopes {
+			if !seen[s.Name] {
+				seen[s.Name] = true
+				ret = append(ret, s)
+			}
+		}
+	}
+	return ret
+}
+
 func (s *SecurityScheme) TypeStr() string {
 	switch s.Type {
 	case "apiKey":
@@ -263,3 +263,184 @@ func TestMergedAllOf_NoSideEffect(t *testing.T) {
 func ptr[T any](v T) *T {
 	return &v
 }
+
+func TestDefaultServer(t *testing.T) {
+	prod := &Server{Url: "https://api.example.com/v1/", Description: "production"}
+	stg := &Server{Url: "https://stg.example.com/v1", Description: "staging"}
+	doc := &Document{Servers: []*Server{prod, stg}}
+	tests := []struct {
+		doc  *Document
+		s    string
+		want *Server
+	}{
+		{doc, "", prod},
+		{doc, "staging", stg},
+		{doc, "https://stg.example.com/v1", stg},
+		{doc, "http://localhost:8080", &Server{Url: "http://localhost:8080"}},
+		{&Document{}, "", nil},
+	}
+	for _, test := range tests {
+		got := test.doc.DefaultServer(test.s)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+}
+
+func TestServerOperationUrl(t *testing.T) {
+	tests := []struct {
+		server *Server
+		want   string
+	}{
+		{&Server{Url: "https://api.example.com/v1/"}, "https://api.example.com/v1/pets/{petId}"},
+		{&Server{Url: "https://api.example.com"}, "https://api.example.com/pets/{petId}"},
+		{&Server{Url: "/"}, "/pets/{petId}"},
+	}
+	for _, test := range tests {
+		got := test.server.OperationUrl(&Path{UriPath: "/pets/{petId}"})
+		if got != test.want {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+}
+
+func TestMediaTypeContentKind(t *testing.T) {
+	tests := []struct {
+		mediaType      string
+		multipart      bool
+		formUrlencoded bool
+		xml            bool
+	}{
+		{"multipart/form-data", true, false, false},
+		{"multipart/mixed; boundary=xyz", true, false, false},
+		{"application/x-www-form-urlencoded", false, true, false},
+		{"Application/X-WWW-Form-Urlencoded; charset=utf-8", false, true, false},
+		{"application/json", false, false, false},
+		{"application/xml", false, false, true},
+		{"text/xml; charset=utf-8", false, false, true},
+		{"application/atom+xml", false, false, true},
+	}
+	for _, tt := range tests {
+		c := &MediaTypeContent{MediaType: tt.mediaType}
+		if got := c.IsMultipart(); got != tt.multipart {
+			t.Errorf("got=%v, want=%v", got, tt.multipart)
+		}
+		if got := c.IsFormUrlencoded(); got != tt.formUrlencoded {
+			t.Errorf("got=%v, want=%v", got, tt.formUrlencoded)
+		}
+		if got := c.IsXml(); got != tt.xml {
+			t.Errorf("got=%v, want=%v", got, tt.xml)
+		}
+	}
+}
+
+func TestDefaultPartContentType(t *testing.T) {
+	tests := []struct {
+		schema *Schema
+		want   string
+	}{
+		{&Schema{Type: "string"}, "text/plain"},
+		{&Schema{Type: "integer"}, "text/plain"},
+		{&Schema{Type: "string", Format: "binary"}, "application/octet-stream"},
+		{&Schema{Type: "string", Format: "base64"}, "application/octet-stream"},
+		{&Schema{Type: "object"}, "application/json"},
+		{&Schema{Type: "array", Items: &Schema{Type: "string", Format: "binary"}}, "application/octet-stream"},
+		{&Schema{Type: "array", Items: &Schema{Type: "object"}}, "application/json"},
+		{nil, "application/octet-stream"},
+	}
+	for _, tt := range tests {
+		if got := DefaultPartContentType(tt.schema); got != tt.want {
+			t.Errorf("got=%v, want=%v", got, tt.want)
+		}
+	}
+}
+
+func TestEffectiveSecurity(t *testing.T) {
+	apiKey := []*SecurityRequirement{{Schemes: []*SecurityRequirementScheme{{Key: "api_key"}}}}
+	oauth := []*SecurityRequirement{{Schemes: []*SecurityRequirementScheme{{Key: "oauth", Scopes: []string{"read"}}}}}
+	public := []*SecurityRequirement{}
+	tests := []struct {
+		declared   []*SecurityRequirement
+		global     []*SecurityRequirement
+		want       []*SecurityRequirement
+		wantOrigin SecurityOrigin
+	}{
+		{nil, nil, nil, SecurityUndeclared},
+		{nil, apiKey, apiKey, SecurityInherited},
+		{oauth, apiKey, oauth, SecurityDeclared},
+		{oauth, nil, oauth, SecurityDeclared},
+		{public, apiKey, public, SecurityOverridden},
+		{public, nil, public, SecurityDeclared},
+		{public, public, public, SecurityDeclared},
+	}
+	for _, tt := range tests {
+		got, gotOrigin := EffectiveSecurity(tt.declared, tt.global)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("got=%v, want=%v", got, tt.want)
+		}
+		if gotOrigin != tt.wantOrigin {
+			t.Errorf("got=%v, want=%v", gotOrigin, tt.wantOrigin)
+		}
+	}
+}
+
+func TestPathSecured(t *testing.T) {
+	apiKey := &SecurityRequirement{Schemes: []*SecurityRequirementScheme{{Key: "api_key"}}}
+	anonymous := &SecurityRequirement{Schemes: []*SecurityRequirementScheme{}}
+	tests := []struct {
+		security []*SecurityRequirement
+		want     bool
+	}{
+		{nil, false},
+		{[]*SecurityRequirement{}, false},
+		{[]*SecurityRequirement{apiKey}, true},
+		{[]*SecurityRequirement{anonymous, apiKey}, false},
+	}
+	for _, tt := range tests {
+		p := &Path{Security: tt.security}
+		if got := p.Secured(); got != tt.want {
+			t.Errorf("got=%v, want=%v", got, tt.want)
+		}
+	}
+}
+
+func TestScopeOperations(t *testing.T) {
+	requirement := func(key string, scopes ...string) *SecurityRequirement {
+		return &SecurityRequirement{Schemes: []*SecurityRequirementScheme{{Key: key, Scopes: scopes}}}
+	}
+	list := &Path{OperationId: "list", Security: []*SecurityRequirement{requirement("oauth", "read")}}
+	add := &Path{OperationId: "add", Security: []*SecurityRequirement{requirement("oauth", "read", "write"), requirement("oauth", "write")}}
+	key := &Path{OperationId: "key", Security: []*SecurityRequirement{requirement("api_key")}}
+	public := &Path{OperationId: "public"}
+	doc := &Document{
+		Tags: []*Tag{{Name: "b"}, {Name: "a"}},
+		TagPathMap: map[string][]*Path{
+			"a": {list, public},
+			"b": {add, key},
+		},
+	}
+	ids := func(ps []*Path) []string {
+		ret := make([]string, len(ps))
+		for i, p := range ps {
+			ret[i] = p.OperationId
+		}
+		return ret
+	}
+	tests := []struct {
+		key  string
+		want map[string][]string
+	}{
+		{"oauth", map[string][]string{"read": {"add", "list"}, "write": {"add"}}},
+		{"api_key", map[string][]string{"": {"key"}}},
+		{"unknown", map[string][]string{}},
+	}
+	for _, tt := range tests {
+		got := make(map[string][]string)
+		for scope, ps := range doc.ScopeOperations(tt.key) {
+			got[scope] = ids(ps)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("got=%v, want=%v", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package topi
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+const (
+	// max depth of the nested elements in the XML examples, to stop at circular schemas
+	maxXmlDepth = 8
+	// name of the root element used if the schema has neither xml.name nor the component name
+	defaultXmlRootName = "root"
+)
+
+// XmlExample returns an example XML document of the schema with sample values,
+// following the xml objects of the schema and its properties.
+// If read is true, writeOnly properties are omitted, otherwise readOnly properties are omitted.
+func (sc *Schema) XmlExample(read bool) string {
+	name := sc.Ref
+	if name == "" {
+		name = defaultXmlRootName
+	}
+	g := &xmlGenerator{read: read}
+	// the root array is always wrapped to keep the document well-formed
+	g.element(sc, name, "", 0, true)
+	return g.buf.String()
+}
+
+type xmlGenerator struct {
+	buf  strings.Builder
+	read bool
+}
+
+func (g *xmlGenerator) element(sc *Schema, name, indent string, depth int, root bool) {
+	sc = xmlTarget(sc)
+	tag, attrs := xmlTag(sc, name)
+
+	if depth >= maxXmlDepth {
+		g.line(indent, fmt.Sprintf("<%s%s/>", tag, attrs))
+		return
+	}
+
+	switch sc.Type {
+	case "array":
+		items := xmlTarget(sc.Items)
+		itemName := name
+		if root && items != nil && items.Ref != "" {
+			itemName = items.Ref
+		}
+		wrapped := root || (sc.XML != nil && sc.XML.Wrapped)
+		itemIndent := indent
+		if wrapped {
+			g.line(indent, fmt.Sprintf("<%s%s>", tag, attrs))
+			itemIndent = indent + "  "
+		}
+		if items != nil {
+			for i := 0; i < 2; i++ {
+				g.element(items, itemName, itemIndent, depth+1, false)
+			}
+		}
+		if wrapped {
+			g.line(indent, fmt.Sprintf("</%s>", tag))
+		}
+	case "object":
+		var children []string
+		for _, propName := range sc.propertyNames() {
+			prop := sc.Properties[propName]
+			if (g.read && prop.WriteOnly) || (!g.read && prop.ReadOnly) {
+				continue
+			}
+			if prop.XML != nil && prop.XML.Attribute {
+				attrName, _ := xmlTag(prop, propName)
+				attrs += fmt.Sprintf(` %s="%s"`, attrName, xmlEscape(xmlValue(prop)))
+				continue
+			}
+			children = append(children, propName)
+		}
+		if len(children) == 0 {
+			g.line(indent, fmt.Sprintf("<%s%s/>", tag, attrs))
+			return
+		}
+		g.line(indent, fmt.Sprintf("<%s%s>", tag, attrs))
+		for _, propName := range children {
+			g.element(sc.Properties[propName], propName, indent+"  ", depth+1, false)
+		}
+		g.line(indent, fmt.Sprintf("</%s>", tag))
+	default:
+		g.line(indent, fmt.Sprintf("<%s%s>%s</%s>", tag, attrs, xmlEscape(xmlValue(sc)), tag))
+	}
+}
+
+func (g *xmlGenerator) line(indent, s string) {
+	g.buf.WriteString(indent)
+	g.buf.WriteString(s)
+	g.buf.WriteString("\n")
+}
+
+// xmlTarget returns the schema used to generate the element: allOf is merged, and the first schema of oneOf is used.
+func xmlTarget(sc *Schema) *Schema {
+	if sc == nil {
+		return nil
+	}
+	if len(sc.AllOf) > 0 {
+		return sc.MergedAllOf()
+	}
+	if len(sc.OneOf) > 0 {
+		return xmlTarget(sc.OneOf[0])
+	}
+	return sc
+}
+
+// xmlTag returns the qualified name of the element and the namespace declaration if exists.
+func xmlTag(sc *Schema, name string) (string, string) {
+	if sc == nil || sc.XML == nil {
+		return name, ""
+	}
+	x := sc.XML
+	if x.Name != "" {
+		name = x.Name
+	}
+	if x.Prefix != "" {
+		name = x.Prefix + ":" + name
+	}
+	attrs := ""
+	if x.Namespace != "" && !x.Attribute {
+		if x.Prefix != "" {
+			attrs = fmt.Sprintf(` xmlns:%s="%s"`, x.Prefix, xmlEscape(x.Namespace))
+		} else {
+			attrs = fmt.Sprintf(` xmlns="%s"`, xmlEscape(x.Namespace))
+		}
+	}
+	return name, attrs
+}
+
+func xmlValue(sc *Schema) string {
+	if sc.Example != nil {
+		return fmt.Sprint(sc.Example)
+	}
+	if v, ok := sampleValue(sc); ok {
+		return v
+	}
+	return ""
+}
+
+func xmlEscape(s string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
@@ -0,0 +1,66 @@
+package topi
+
+import "testing"
+
+func TestSchemaXmlExample(t *testing.T) {
+	pet := &Schema{
+		Ref:  "Pet",
+		Type: "object",
+		Properties: map[string]*Schema{
+			"id":       {Type: "integer", Example: 42, XML: &XML{Attribute: true}},
+			"name":     {Type: "string", Example: "Tom & Jerry", XML: &XML{Name: "pet-name", Prefix: "p", Namespace: "https://example.com/pet"}},
+			"password": {Type: "string", WriteOnly: true},
+			"photoUrls": {Type: "array", XML: &XML{Wrapped: true}, Items: &Schema{
+				Type: "string", XML: &XML{Name: "photoUrl"},
+			}},
+			"tags": {Type: "array", Items: &Schema{Type: "string", Enum: []interface{}{"cat", "dog"}}},
+		},
+	}
+	tests := []struct {
+		schema *Schema
+		read   bool
+		want   string
+	}{
+		{pet, true, `<Pet id="42">
+  <p:pet-name xmlns:p="https://example.com/pet">Tom &amp; Jerry</p:pet-name>
+  <photoUrls>
+    <photoUrl>a</photoUrl>
+    <photoUrl>a</photoUrl>
+  </photoUrls>
+  <tags>cat</tags>
+  <tags>cat</tags>
+</Pet>
+`},
+		{&Schema{Type: "array", Items: pet}, false, `<root>
+  <Pet id="42">
+    <p:pet-name xmlns:p="https://example.com/pet">Tom &amp; Jerry</p:pet-name>
+    <password>a</password>
+    <photoUrls>
+      <photoUrl>a</photoUrl>
+      <photoUrl>a</photoUrl>
+    </photoUrls>
+    <tags>cat</tags>
+    <tags>cat</tags>
+  </Pet>
+  <Pet id="42">
+    <p:pet-name xmlns:p="https://example.com/pet">Tom &amp; Jerry</p:pet-name>
+    <password>a</password>
+    <photoUrls>
+      <photoUrl>a</photoUrl>
+      <photoUrl>a</photoUrl>
+    </photoUrls>
+    <tags>cat</tags>
+    <tags>cat</tags>
+  </Pet>
+</root>
+`},
+		{&Schema{Type: "object", XML: &XML{Name: "empty", Namespace: "urn:x"}}, true, `<empty xmlns="urn:x"/>
+`},
+	}
+	for _, tt := range tests {
+		got := tt.schema.XmlExample(tt.read)
+		if got != tt.want {
+			t.Errorf("got=%v, want=%v", got, tt.want)
+		}
+	}
+}
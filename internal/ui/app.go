@@ -1,37 +1,81 @@
 package ui
 
 import (
+	"fmt"
+	"net/http"
+	"os"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/lusingander/topi/internal/bookmark"
+	"github.com/lusingander/topi/internal/config"
+	"github.com/lusingander/topi/internal/lint"
+	"github.com/lusingander/topi/internal/oauth"
+	"github.com/lusingander/topi/internal/openapi"
+	"github.com/lusingander/topi/internal/recent"
+	"github.com/lusingander/topi/internal/remote"
 	"github.com/lusingander/topi/internal/topi"
+	"golang.org/x/term"
 )
 
 var (
+	headerStyle              lipgloss.Style
+	footerStyle              lipgloss.Style
+	statusbarFileNameStyle   lipgloss.Style
+	statusbarSpaceColorStyle lipgloss.Style
+	statusbarStaleStyle      lipgloss.Style
+	statusbarLowerStyle      lipgloss.Style
+)
+
+func loadAppStyles(t *theme) {
 	headerStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("65")).
-			Padding(0, 1)
+		Foreground(t.Header).
+		Padding(0, 1)
 
 	footerStyle = lipgloss.NewStyle()
 
 	statusbarFileNameStyle = lipgloss.NewStyle().
-				Background(lipgloss.Color("65")).
-				Padding(0, 1)
+		Background(t.Header).
+		Padding(0, 1)
 
 	statusbarSpaceColorStyle = lipgloss.NewStyle().
-					Background(lipgloss.Color("237"))
+		Background(t.StatusbarBg)
+
+	statusbarStaleStyle = lipgloss.NewStyle().
+		Background(t.Warning).
+		Padding(0, 1)
 
 	statusbarLowerStyle = lipgloss.NewStyle()
-)
+}
 
 type page interface {
 	crumb() string
 }
 
-type menuPage struct{}
+type specPage struct{}
+
+func (specPage) crumb() string { return topi.AppName }
+
+type menuPage struct {
+	spec string
+}
+
+func (p menuPage) crumb() string {
+	if p.spec != "" {
+		return p.spec
+	}
+	return topi.AppName
+}
+
+type searchPage struct{}
 
-func (menuPage) crumb() string { return topi.AppName }
+func (searchPage) crumb() string { return "search" }
+
+type problemsPage struct{}
+
+func (problemsPage) crumb() string { return "problems" }
 
 type infoPage struct{}
 
@@ -51,6 +95,24 @@ type pathPage struct{}
 
 func (pathPage) crumb() string { return "paths" }
 
+type securityPage struct{}
+
+func (securityPage) crumb() string { return "security" }
+
+type securitySchemePage struct {
+	key string
+}
+
+func (p securitySchemePage) crumb() string { return p.key }
+
+type bookmarksPage struct{}
+
+func (bookmarksPage) crumb() string { return "bookmarks" }
+
+type recentPage struct{}
+
+func (recentPage) crumb() string { return "recent" }
+
 type operationPage struct {
 	operationId string
 }
@@ -74,7 +136,8 @@ type creditsPage struct{}
 func (creditsPage) crumb() string { return "credits" }
 
 type pageStack struct {
-	stack []page
+	stack   []page
+	forward []page // pages to go forward, the last is the next
 }
 
 func (s pageStack) crumbs() []string {
@@ -93,6 +156,7 @@ func newPageStack(p page) *pageStack {
 
 func (s *pageStack) pushPage(p page) {
 	s.stack = append(s.stack, p)
+	s.forward = nil
 }
 
 func (s *pageStack) popPage() page {
@@ -105,47 +169,246 @@ func (s *pageStack) popPage() page {
 	return p
 }
 
+// backPage pops the current page and keeps it to go forward.
+func (s *pageStack) backPage() {
+	p := s.popPage()
+	if p == nil {
+		return
+	}
+	if reopenPageMsg(p) == nil {
+		s.forward = nil
+		return
+	}
+	s.forward = append(s.forward, p)
+}
+
+// forwardPage returns the page to go forward and removes it, or nil if there is no page.
+func (s *pageStack) forwardPage() page {
+	l := len(s.forward)
+	if l == 0 {
+		return nil
+	}
+	p := s.forward[l-1]
+	s.forward = s.forward[:l-1]
+	return p
+}
+
 func (s *pageStack) currentPage() page {
 	return s.stack[len(s.stack)-1]
 }
 
 type model struct {
-	doc *topi.Document
+	docs   []*topi.Document
+	docIdx int
+	doc    *topi.Document
+
+	lintConfig    *lint.Config
+	load          LoadFunc // nil if the specs can not be reloaded
+	bookmarkStore *bookmark.Store
+	recentStore   *recent.Store
+	oauthConfigs  map[string]oauth.Config
+	oauthClient   *http.Client            // used to request the tokens
+	tokenStores   map[string]*oauth.Store // by the path of the spec
+	startPageName string
+	themeName     string
+	themeConfig   config.ThemeConfig // colors overriding all themes
 
 	*pageStack
 
-	menuPage      menuPageModel
-	infoPage      infoPageModel
-	tagPage       tagPageModel
-	tagPathsPage  tagPathsPageModel
-	pathPage      pathPageModel
-	operationPage operationPageModel
-	helpMenuPage  helpMenuPageModel
-	helpPage      helpPageModel
-	aboutPage     aboutPageModel
-	creditsPage   creditsPageModel
+	specPage           specPageModel
+	menuPage           menuPageModel
+	searchPage         searchPageModel
+	infoPage           infoPageModel
+	tagPage            tagPageModel
+	tagPathsPage       tagPathsPageModel
+	pathPage           pathPageModel
+	securityPage       securityPageModel
+	securitySchemePage securitySchemePageModel
+	bookmarksPage      bookmarksPageModel
+	recentPage         recentPageModel
+	operationPage      operationPageModel
+	problemsPage       problemsPageModel
+	helpMenuPage       helpMenuPageModel
+	helpPage           helpPageModel
+	aboutPage          aboutPageModel
+	creditsPage        creditsPageModel
+
+	delegateKeys appDelegateKeyMap
+
+	jumps jumpList
+
+	statusMessage string // shown until the next key is pressed
 
 	width, height int
 }
 
+type appDelegateKeyMap struct {
+	quit        key.Binding
+	help        key.Binding
+	specMenu    key.Binding
+	theme       key.Binding
+	forward     key.Binding
+	jumpBack    key.Binding
+	jumpForward key.Binding
+}
+
+func newAppDelegateKeyMap() appDelegateKeyMap {
+	return appDelegateKeyMap{
+		quit:        keys.quit.binding("quit"),
+		help:        keys.help.binding("help"),
+		specMenu:    keys.specMenu.binding("select spec"),
+		theme:       keys.cycleTheme.binding("switch theme"),
+		forward:     keys.forward.binding("forward"),
+		jumpBack:    keys.jumpBack.binding("jump to older operation"),
+		jumpForward: keys.jumpForward.binding("jump to newer operation"),
+	}
+}
+
 var _ tea.Model = (*model)(nil)
 
-func newModel(doc *topi.Document) model {
-	startPage := menuPage{}
-	return model{
-		doc:           doc,
+func newModel(docs []*topi.Document, lintConfig *lint.Config, cfg *config.Config, oauthClient *http.Client, bookmarkStore *bookmark.Store, recentStore *recent.Store) model {
+	var startPage page = menuPage{}
+	if len(docs) > 1 {
+		startPage = specPage{}
+	}
+	m := model{
+		docs:          docs,
+		lintConfig:    lintConfig,
+		bookmarkStore: bookmarkStore,
+		recentStore:   recentStore,
+		oauthConfigs:  cfg.OAuth,
+		oauthClient:   oauthClient,
+		tokenStores:   make(map[string]*oauth.Store),
+		startPageName: cfg.StartPage,
+		themeName:     themeNameDark,
+		themeConfig:   cfg.Theme,
 		pageStack:     newPageStack(startPage),
-		infoPage:      newInfoPageModel(doc),
-		menuPage:      newMenuPageModel(),
-		tagPage:       newTagPageModel(doc),
-		tagPathsPage:  newTagPathsPageModel(doc),
-		pathPage:      newPathPageModel(doc),
-		operationPage: newOperationPageModel(doc),
+		specPage:      newSpecPageModel(docs),
+		menuPage:      newMenuPageModel(len(docs) > 1),
+		searchPage:    newSearchPageModel(docs),
 		helpMenuPage:  newHelpMenuPageModel(),
 		helpPage:      newHelpPageModel(),
 		aboutPage:     newAboutPageModel(),
 		creditsPage:   newCreditsPageModel(),
+		delegateKeys:  newAppDelegateKeyMap(),
+	}
+	m.setDocument(0)
+	return m
+}
+
+func (m *model) setDocument(i int) {
+	doc := m.docs[i]
+	m.docIdx = i
+	m.doc = doc
+	m.infoPage = newInfoPageModel(doc)
+	m.tagPage = newTagPageModel(doc)
+	bookmarks := newSpecBookmarks(m.bookmarkStore, doc)
+	m.tagPathsPage = newTagPathsPageModel(doc, bookmarks)
+	m.pathPage = newPathPageModel(doc, bookmarks)
+	m.securityPage = newSecurityPageModel(doc)
+	tokens := m.tokenStore(doc)
+	m.securitySchemePage = newSecuritySchemePageModel(doc, tokens, m.oauthConfigs, m.oauthClient)
+	m.bookmarksPage = newBookmarksPageModel(doc, bookmarks)
+	recent := newSpecRecent(m.recentStore, doc)
+	m.recentPage = newRecentPageModel(doc, recent, bookmarks)
+	m.operationPage = newOperationPageModel(doc, bookmarks, recent, tokens)
+	m.problemsPage = newProblemsPageModel(lint.Run(doc, m.lintConfig))
+	if m.width > 0 {
+		m.SetSize(m.width, m.height)
+	}
+}
+
+// tokenStore returns the tokens acquired for the spec, which are kept while the spec is switched or reloaded.
+func (m *model) tokenStore(doc *topi.Document) *oauth.Store {
+	s, ok := m.tokenStores[doc.Meta.FullPath]
+	if !ok {
+		s = oauth.NewStore()
+		m.tokenStores[doc.Meta.FullPath] = s
+	}
+	return s
+}
+
+func (m model) multiSpec() bool {
+	return len(m.docs) > 1
+}
+
+func (m *model) switchSpec() {
+	if !m.multiSpec() {
+		return
+	}
+	m.pageStack = newPageStack(specPage{})
+}
+
+// openSpec switches the spec and opens its menu page if the spec is not the current one.
+func (m *model) openSpec(i int) {
+	if i == m.docIdx {
+		return
+	}
+	m.setDocument(i)
+	m.pageStack = newPageStack(specPage{})
+	m.pushPage(menuPage{spec: m.doc.Meta.FileName})
+}
+
+// reloadSpec loads the current spec again to show the changes made in the editor, keeping the pages opened.
+func (m *model) reloadSpec() tea.Cmd {
+	if m.load == nil {
+		return nil
+	}
+	if m.doc.Meta.FullPath == openapi.StdinPath {
+		return showStatusMessage("spec read from stdin can not be reloaded")
+	}
+	doc, err := m.load(m.doc.Meta.FullPath)
+	if err != nil {
+		return showStatusMessage(fmt.Sprintf("failed to reload the spec: %s", err))
+	}
+	offset := m.operationPage.viewport.YOffset
+	m.docs[m.docIdx] = doc
+	m.specPage = newSpecPageModel(m.docs)
+	m.searchPage = newSearchPageModel(m.docs)
+	m.setDocument(m.docIdx)
+	for _, p := range m.stack {
+		switch p := p.(type) {
+		case tagPathsPage:
+			m.tagPathsPage, _ = m.tagPathsPage.Update(selectTagMsg(p))
+		case securityPage:
+			m.securityPage, _ = m.securityPage.Update(selectSecurityMenuMsg{})
+		case securitySchemePage:
+			m.securitySchemePage, _ = m.securitySchemePage.Update(selectSecuritySchemeMsg(p))
+		case operationPage:
+			m.operationPage.updateOperation(p.operationId)
+			m.operationPage.updateContent()
+			m.operationPage.viewport.SetYOffset(offset)
+		}
+	}
+	if n := len(doc.ValidationErrors); n > 0 {
+		return showStatusMessage(fmt.Sprintf("reloaded, %d validation errors (see Problems page)", n))
+	}
+	return showStatusMessage("reloaded")
+}
+
+// goForward opens the page closed by going back.
+func (m model) goForward() (model, tea.Cmd) {
+	p := m.forwardPage()
+	if p == nil {
+		return m, showStatusMessage("no page to go forward")
+	}
+	forward := m.forward
+	ret, cmd := m.Update(reopenPageMsg(p))
+	m = ret.(model)
+	m.forward = forward // cleared by pushing the page
+	return m, cmd
+}
+
+// jump opens the operation in the jump list moved by d from the current position.
+func (m *model) jump(d int) tea.Cmd {
+	e, ok := m.jumps.move(d)
+	if !ok {
+		if d < 0 {
+			return showStatusMessage("no older operation in the jump list")
+		}
+		return showStatusMessage("no newer operation in the jump list")
 	}
+	return jumpTo(e)
 }
 
 func (m *model) SetSize(w, h int) {
@@ -156,18 +419,37 @@ func (m *model) SetSize(w, h int) {
 	h = h - t - b
 	h = h - 3 // :(
 
+	m.specPage.SetSize(w, h)
 	m.menuPage.SetSize(w, h)
+	m.searchPage.SetSize(w, h)
 	m.infoPage.SetSize(w, h)
 	m.tagPage.SetSize(w, h)
 	m.tagPathsPage.SetSize(w, h)
 	m.pathPage.SetSize(w, h)
+	m.securityPage.SetSize(w, h)
+	m.securitySchemePage.SetSize(w, h)
+	m.bookmarksPage.SetSize(w, h)
+	m.recentPage.SetSize(w, h)
 	m.operationPage.SetSize(w, h)
+	m.problemsPage.SetSize(w, h)
 	m.helpMenuPage.SetSize(w, h)
 	m.helpPage.SetSize(w, h)
 	m.aboutPage.SetSize(w, h)
 	m.creditsPage.SetSize(w, h)
 }
 
+// cycleTheme switches to the next theme and renders all pages again.
+func (m *model) cycleTheme() {
+	name := nextThemeName(m.themeName)
+	t, _ := newTheme(name)
+	t.override(m.themeConfig)
+	applyTheme(t)
+	m.themeName = name
+	if m.width > 0 {
+		m.SetSize(m.width, m.height)
+	}
+}
+
 func (m *model) toggleHelp() {
 	switch m.currentPage().(type) {
 	case helpPage:
@@ -177,30 +459,98 @@ func (m *model) toggleHelp() {
 	}
 }
 
+// startPageCmd opens the start page after the menu page of the spec is opened.
+func (m model) startPageCmd() tea.Cmd {
+	switch m.startPageName {
+	case config.StartPageInfo:
+		return selectInfoMenu
+	case config.StartPageTags:
+		return selectTagMenu
+	case config.StartPagePaths:
+		return selectPathMenu
+	default:
+		return nil
+	}
+}
+
 func (m model) Init() tea.Cmd {
-	return nil
+	if m.multiSpec() {
+		return nil
+	}
+	return m.startPageCmd()
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c":
+		m.statusMessage = ""
+		switch {
+		case key.Matches(msg, m.delegateKeys.quit):
 			return m, tea.Quit
-		case "?":
+		case key.Matches(msg, m.delegateKeys.help):
 			return m, toggleHelp
+		case key.Matches(msg, m.delegateKeys.specMenu):
+			return m, selectSpecMenu
+		case key.Matches(msg, m.delegateKeys.theme):
+			m.cycleTheme()
+			return m, nil
+		case key.Matches(msg, m.delegateKeys.forward):
+			return m.goForward()
+		case key.Matches(msg, m.delegateKeys.jumpBack):
+			return m, m.jump(-1)
+		case key.Matches(msg, m.delegateKeys.jumpForward):
+			return m, m.jump(1)
 		}
 	case tea.WindowSizeMsg:
 		m.SetSize(msg.Width, msg.Height)
 	case toggleHelpMsg:
 		m.toggleHelp()
+	case selectSpecMenuMsg:
+		m.switchSpec()
+	case selectSpecMsg:
+		m.setDocument(msg.index)
+		m.pushPage(menuPage{spec: m.doc.Meta.FileName})
+		return m, m.startPageCmd()
+	case selectSearchMenuMsg:
+		m.pushPage(searchPage{})
+	case selectSearchResultMsg:
+		m.openSpec(msg.index)
+		return m, selectOperation(msg.operationId)
 	case selectInfoMenuMsg:
 		m.pushPage(infoPage{})
 	case selectTagMenuMsg:
 		m.pushPage(tagPage{})
 	case selectPathMenuMsg:
 		m.pushPage(pathPage{})
+	case selectSecurityMenuMsg:
+		m.pushPage(securityPage{})
+	case selectSecuritySchemeMsg:
+		m.pushPage(securitySchemePage(msg))
+	case selectBookmarksMenuMsg:
+		m.pushPage(bookmarksPage{})
+	case selectRecentMenuMsg:
+		m.pushPage(recentPage{})
+	case statusMessageMsg:
+		m.statusMessage = msg.message
+		return m, nil
+	case tokenAcquiredMsg:
+		if msg.err != nil {
+			m.statusMessage = fmt.Sprintf("failed to acquire the token of %s: %s", msg.key, msg.err)
+		} else {
+			m.statusMessage = fmt.Sprintf("acquired the token of %s", msg.key)
+		}
+		m.securitySchemePage.updateContent()
+		m.operationPage.updateContent()
+		return m, nil
+	case editorFinishedMsg:
+		if msg.err != nil {
+			m.statusMessage = fmt.Sprintf("failed to run the editor: %s", msg.err)
+			return m, nil
+		}
+		return m, m.reloadSpec()
+	case selectProblemsMenuMsg:
+		m.pushPage(problemsPage{})
 	case selectHelpMenuMsg:
 		m.pushPage(helpMenuPage{})
 	case selectHelpHelpMenuMsg:
@@ -213,13 +563,29 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.pushPage(tagPathsPage(msg))
 	case selectOperationMsg:
 		m.pushPage(operationPage(msg))
+		m.jumps.add(jumpListEntry{index: m.docIdx, operationId: msg.operationId})
+	case jumpMsg:
+		// replace the operation page not to stack the pages by jumping
+		if _, ok := m.currentPage().(operationPage); ok {
+			m.popPage()
+		}
+		m.openSpec(msg.index)
+		m.pushPage(operationPage{msg.operationId})
+		m.operationPage, cmd = m.operationPage.Update(selectOperationMsg{msg.operationId})
+		return m, cmd
 	case goBackMsg:
-		m.popPage()
+		m.backPage()
 	}
 	switch m.currentPage().(type) {
+	case specPage:
+		m.specPage, cmd = m.specPage.Update(msg)
+		return m, cmd
 	case menuPage:
 		m.menuPage, cmd = m.menuPage.Update(msg)
 		return m, cmd
+	case searchPage:
+		m.searchPage, cmd = m.searchPage.Update(msg)
+		return m, cmd
 	case infoPage:
 		m.infoPage, cmd = m.infoPage.Update(msg)
 		return m, cmd
@@ -232,9 +598,24 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case pathPage:
 		m.pathPage, cmd = m.pathPage.Update(msg)
 		return m, cmd
+	case securityPage:
+		m.securityPage, cmd = m.securityPage.Update(msg)
+		return m, cmd
+	case securitySchemePage:
+		m.securitySchemePage, cmd = m.securitySchemePage.Update(msg)
+		return m, cmd
+	case bookmarksPage:
+		m.bookmarksPage, cmd = m.bookmarksPage.Update(msg)
+		return m, cmd
+	case recentPage:
+		m.recentPage, cmd = m.recentPage.Update(msg)
+		return m, cmd
 	case operationPage:
 		m.operationPage, cmd = m.operationPage.Update(msg)
 		return m, cmd
+	case problemsPage:
+		m.problemsPage, cmd = m.problemsPage.Update(msg)
+		return m, cmd
 	case helpMenuPage:
 		m.helpMenuPage, cmd = m.helpMenuPage.Update(msg)
 		return m, cmd
@@ -261,8 +642,12 @@ func (m model) View() string {
 
 func (m model) content() string {
 	switch m.currentPage().(type) {
+	case specPage:
+		return m.specPage.View()
 	case menuPage:
 		return m.menuPage.View()
+	case searchPage:
+		return m.searchPage.View()
 	case infoPage:
 		return m.infoPage.View()
 	case tagPage:
@@ -271,8 +656,18 @@ func (m model) content() string {
 		return m.tagPathsPage.View()
 	case pathPage:
 		return m.pathPage.View()
+	case securityPage:
+		return m.securityPage.View()
+	case securitySchemePage:
+		return m.securitySchemePage.View()
+	case bookmarksPage:
+		return m.bookmarksPage.View()
+	case recentPage:
+		return m.recentPage.View()
 	case operationPage:
 		return m.operationPage.View()
+	case problemsPage:
+		return m.problemsPage.View()
 	case helpMenuPage:
 		return m.helpMenuPage.View()
 	case helpPage:
@@ -296,7 +691,10 @@ func (m model) appFooter() string {
 	if w == 0 {
 		return ""
 	}
-	name := statusbarFileNameStyle.Render(m.doc.Meta.FileName)
+	name := statusbarFileNameStyle.Render(m.statusbarFileNameString())
+	if m.doc.Meta.Stale {
+		name += statusbarStaleStyle.Render("stale")
+	}
 	statusbarInfo := m.statusbarInfoString()
 	sw := w - lipgloss.Width(name) - lipgloss.Width(statusbarInfo)
 	spaces := statusbarSpaceColorStyle.Render(strings.Repeat(" ", sw))
@@ -306,10 +704,21 @@ func (m model) appFooter() string {
 	return footerStyle.Render(u + "\n" + l)
 }
 
+func (m model) statusbarFileNameString() string {
+	if !m.multiSpec() {
+		return m.doc.Meta.FileName
+	}
+	return fmt.Sprintf("%s (%d/%d)", m.doc.Meta.FileName, m.docIdx+1, len(m.docs))
+}
+
 func (m model) statusbarInfoString() string {
 	switch m.currentPage().(type) {
+	case specPage:
+		return m.specPage.statusbarInfoString()
 	case menuPage:
 		return ""
+	case searchPage:
+		return m.searchPage.statusbarInfoString()
 	case infoPage:
 		return ""
 	case tagPage:
@@ -318,8 +727,18 @@ func (m model) statusbarInfoString() string {
 		return m.tagPathsPage.statusbarInfoString()
 	case pathPage:
 		return m.pathPage.statusbarInfoString()
+	case securityPage:
+		return m.securityPage.statusbarInfoString()
+	case securitySchemePage:
+		return ""
+	case bookmarksPage:
+		return m.bookmarksPage.statusbarInfoString()
+	case recentPage:
+		return m.recentPage.statusbarInfoString()
 	case operationPage:
 		return ""
+	case problemsPage:
+		return m.problemsPage.statusbarInfoString()
 	case helpMenuPage:
 		return ""
 	case helpPage:
@@ -334,19 +753,42 @@ func (m model) statusbarInfoString() string {
 }
 
 func (m model) statusMessageString() string {
+	if m.statusMessage != "" {
+		return m.statusMessage
+	}
 	switch m.currentPage().(type) {
+	case specPage:
+		return m.specPage.statusMessageString()
 	case menuPage:
+		if m.doc.Meta.Stale {
+			return fmt.Sprintf("Offline, showing the cached copy fetched at %s", m.doc.Meta.CachedAt.Local().Format("2006-01-02 15:04"))
+		}
+		if n := len(m.doc.ValidationErrors); n > 0 {
+			return fmt.Sprintf("%d validation errors (see Problems page)", n)
+		}
 		return ""
+	case searchPage:
+		return m.searchPage.statusMessageString()
 	case infoPage:
-		return ""
+		return m.infoPage.statusMessageString()
 	case tagPage:
 		return m.tagPage.statusMessageString()
 	case tagPathsPage:
 		return m.tagPathsPage.statusMessageString()
 	case pathPage:
 		return m.pathPage.statusMessageString()
-	case operationPage:
+	case securityPage:
+		return m.securityPage.statusMessageString()
+	case securitySchemePage:
 		return ""
+	case bookmarksPage:
+		return m.bookmarksPage.statusMessageString()
+	case recentPage:
+		return m.recentPage.statusMessageString()
+	case operationPage:
+		return m.operationPage.statusMessageString()
+	case problemsPage:
+		return m.problemsPage.statusMessageString()
 	case helpMenuPage:
 		return ""
 	case helpPage:
@@ -360,8 +802,85 @@ func (m model) statusMessageString() string {
 	}
 }
 
-func Start(doc *topi.Document) error {
-	m := newModel(doc)
-	p := tea.NewProgram(m, tea.WithAltScreen())
+// defaultServer is the url or description of the server to show the urls of the operations.
+var defaultServer string
+
+// applyConfig sets the settings of the config, must be called before the model is created.
+// Returns the name of the applied theme.
+func applyConfig(cfg *config.Config, themeName string) (string, error) {
+	t, err := newTheme(themeName)
+	if err != nil {
+		return "", err
+	}
+	t.override(cfg.Theme)
+	applyTheme(t)
+	keys = defaultKeyMap()
+	keys.override(cfg.Keymap)
+	markdownWrapWidth = cfg.MarkdownWrapWidth
+	defaultServer = cfg.DefaultServer
+	hideDeprecated = cfg.HideDeprecated
+	return t.name, nil
+}
+
+// loadBookmarkStore returns nil if the data directory is not available.
+func loadBookmarkStore() *bookmark.Store {
+	path, err := bookmark.DefaultPath()
+	if err != nil {
+		return nil
+	}
+	s, err := bookmark.Load(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %s: %s (starting with no bookmarks)\n", path, err)
+	}
+	return s
+}
+
+// loadRecentStore returns nil if the data directory is not available.
+func loadRecentStore(limit int) *recent.Store {
+	path, err := recent.DefaultPath()
+	if err != nil {
+		return nil
+	}
+	s, err := recent.Load(path, limit)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %s: %s (starting with no recent operations)\n", path, err)
+	}
+	return s
+}
+
+func programOptions() []tea.ProgramOption {
+	opts := []tea.ProgramOption{tea.WithAltScreen()}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		// the spec is read from stdin, read the keys from the terminal
+		opts = append(opts, tea.WithInputTTY())
+	}
+	return opts
+}
+
+// LoadFunc loads the spec from the path, used to reload the spec after editing.
+type LoadFunc func(path string) (*topi.Document, error)
+
+// Start runs the viewer. themeName is one of dark, light or auto (or empty).
+// If target is set, the viewer opens the target page first.
+func Start(docs []*topi.Document, lintConfig *lint.Config, cfg *config.Config, themeName string, target *Target, load LoadFunc) error {
+	themeName, err := applyConfig(cfg, themeName)
+	if err != nil {
+		return err
+	}
+	// the token endpoints are not the spec hosts, so only the transport settings are used
+	oauthClient, err := remote.NewClient(cfg.Remote.WithoutCredentials(), nil)
+	if err != nil {
+		return err
+	}
+	bookmarkStore := loadBookmarkStore()
+	recentStore := loadRecentStore(cfg.RecentLimit)
+	m := newModel(docs, lintConfig, cfg, oauthClient, bookmarkStore, recentStore)
+	m.themeName = themeName
+	m.load = load
+	m, err = m.openTarget(target)
+	if err != nil {
+		return err
+	}
+	p := tea.NewProgram(m, programOptions()...)
 	return p.Start()
 }
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lusingander/topi/internal/bookmark"
+	"github.com/lusingander/topi/internal/topi"
+)
+
+const bookmarkMarker = "★"
+
+// specBookmarks is the bookmarks of the current spec shared by the pages.
+type specBookmarks struct {
+	store *bookmark.Store // nil if bookmarks are not available
+	spec  string
+}
+
+func newSpecBookmarks(store *bookmark.Store, doc *topi.Document) *specBookmarks {
+	return &specBookmarks{
+		store: store,
+		spec:  bookmark.SpecKey(doc),
+	}
+}
+
+func (b *specBookmarks) contains(p *topi.Path) bool {
+	if b == nil || b.store == nil {
+		return false
+	}
+	return b.store.Contains(b.spec, p)
+}
+
+func (b *specBookmarks) list() []*bookmark.Bookmark {
+	if b.store == nil {
+		return nil
+	}
+	return b.store.List(b.spec)
+}
+
+func styledBookmarkMarker(bookmarked bool) string {
+	if !bookmarked {
+		return ""
+	}
+	return " " + bookmarkMarkerStyle.Render(bookmarkMarker)
+}
+
+// toggle adds or removes the bookmark of the operation, and returns the command to show the result.
+func (b *specBookmarks) toggle(p *topi.Path) tea.Cmd {
+	if b.store == nil {
+		return showStatusMessage("bookmarks are not available")
+	}
+	added, err := b.store.Toggle(b.spec, p)
+	if err != nil {
+		return showStatusMessage(fmt.Sprintf("failed to save bookmarks: %s", err))
+	}
+	if added {
+		return showStatusMessage(fmt.Sprintf("bookmarked: %s %s", p.Method, p.UriPath))
+	}
+	return showStatusMessage(fmt.Sprintf("removed bookmark: %s %s", p.Method, p.UriPath))
+}
@@ -5,69 +5,108 @@ import (
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/lusingander/topi/internal/topi"
 )
 
 var (
+	baseStyle                   lipgloss.Style
+	selectedColor               lipgloss.TerminalColor
+	listNormalTitleColorStyle   lipgloss.Style
+	listNormalItemStyle         lipgloss.Style
+	listNormalTitleStyle        lipgloss.Style
+	listNormalDescColorStyle    lipgloss.Style
+	listNormalDescStyle         lipgloss.Style
+	listSelectedTitleColorStyle lipgloss.Style
+	listSelectedItemStyle       lipgloss.Style
+	listSelectedTitleStyle      lipgloss.Style
+	listSelectedDescColorStyle  lipgloss.Style
+	listSelectedDescStyle       lipgloss.Style
+)
+
+var (
+	httpMethodGetColor                lipgloss.TerminalColor
+	httpMethodPostColor               lipgloss.TerminalColor
+	httpMethodPutColor                lipgloss.TerminalColor
+	httpMethodPatchColor              lipgloss.TerminalColor
+	httpMethodDeleteColor             lipgloss.TerminalColor
+	httpMethodSelectedGetColor        lipgloss.TerminalColor
+	httpMethodSelectedPostColor       lipgloss.TerminalColor
+	httpMethodSelectedPutColor        lipgloss.TerminalColor
+	httpMethodSelectedPatchColor      lipgloss.TerminalColor
+	httpMethodSelectedDeleteColor     lipgloss.TerminalColor
+	httpMethodDeprecatedColor         lipgloss.TerminalColor
+	httpMethodSelectedDeprecatedColor lipgloss.TerminalColor
+)
+
+var (
+	listStatusbarInfoStyle lipgloss.Style
+	bookmarkMarkerStyle    lipgloss.Style
+	securedMarkerStyle     lipgloss.Style
+)
+
+func loadCommonStyles(t *theme) {
 	baseStyle = lipgloss.NewStyle().Margin(1, 1)
 
-	selectedColor = lipgloss.Color("70")
+	selectedColor = t.Selected
 
 	listNormalTitleColorStyle = lipgloss.NewStyle().
-					Foreground(lipgloss.AdaptiveColor{Light: "#1a1a1a", Dark: "#dddddd"})
+		Foreground(t.ListTitle)
 
 	listNormalItemStyle = lipgloss.NewStyle().
-				Padding(0, 0, 0, 2)
+		Padding(0, 0, 0, 2)
 
 	listNormalTitleStyle = listNormalTitleColorStyle.Copy().
-				Padding(0, 0, 0, 2)
+		Padding(0, 0, 0, 2)
 
 	listNormalDescColorStyle = lipgloss.NewStyle().
-					Foreground(lipgloss.AdaptiveColor{Light: "#A49FA5", Dark: "#777777"})
+		Foreground(t.ListDesc)
 
 	listNormalDescStyle = listNormalDescColorStyle.Copy().
-				Padding(0, 0, 0, 2)
+		Padding(0, 0, 0, 2)
 
 	listSelectedTitleColorStyle = lipgloss.NewStyle().
-					Foreground(selectedColor)
+		Foreground(selectedColor)
 
 	listSelectedItemStyle = lipgloss.NewStyle().
-				Border(lipgloss.NormalBorder(), false, false, false, true).
-				BorderForeground(selectedColor).
-				Padding(0, 0, 0, 1)
+		Border(lipgloss.NormalBorder(), false, false, false, true).
+		BorderForeground(selectedColor).
+		Padding(0, 0, 0, 1)
 
 	listSelectedTitleStyle = listSelectedItemStyle.Copy().
-				Foreground(selectedColor)
+		Foreground(selectedColor)
 
 	listSelectedDescColorStyle = listSelectedTitleColorStyle.Copy().
-					Foreground(selectedColor)
+		Foreground(selectedColor)
 
 	listSelectedDescStyle = listSelectedItemStyle.Copy().
-				Foreground(selectedColor)
-)
+		Foreground(selectedColor)
 
-var (
-	httpMethodGetColor    = lipgloss.Color("33")
-	httpMethodPostColor   = lipgloss.Color("35")
-	httpMethodPutColor    = lipgloss.Color("148")
-	httpMethodPatchColor  = lipgloss.Color("218")
-	httpMethodDeleteColor = lipgloss.Color("172")
-
-	httpMethodSelectedGetColor    = lipgloss.Color("31")
-	httpMethodSelectedPostColor   = lipgloss.Color("29")
-	httpMethodSelectedPutColor    = lipgloss.Color("112")
-	httpMethodSelectedPatchColor  = lipgloss.Color("181")
-	httpMethodSelectedDeleteColor = lipgloss.Color("136")
-
-	httpMethodDeprecatedColor         = lipgloss.Color("246")
-	httpMethodSelectedDeprecatedColor = lipgloss.Color("243")
-)
+	httpMethodGetColor = t.MethodGet
+	httpMethodPostColor = t.MethodPost
+	httpMethodPutColor = t.MethodPut
+	httpMethodPatchColor = t.MethodPatch
+	httpMethodDeleteColor = t.MethodDelete
+
+	httpMethodSelectedGetColor = t.MethodSelectedGet
+	httpMethodSelectedPostColor = t.MethodSelectedPost
+	httpMethodSelectedPutColor = t.MethodSelectedPut
+	httpMethodSelectedPatchColor = t.MethodSelectedPatch
+	httpMethodSelectedDeleteColor = t.MethodSelectedDelete
+
+	httpMethodDeprecatedColor = t.MethodDeprecated
+	httpMethodSelectedDeprecatedColor = t.MethodSelectedDeprecated
 
-var (
 	listStatusbarInfoStyle = lipgloss.NewStyle().
-		Background(lipgloss.Color("247")).
-		Foreground(lipgloss.Color("238")).
+		Background(t.StatusbarInfoBg).
+		Foreground(t.StatusbarInfoFg).
 		Padding(0, 1)
-)
+
+	bookmarkMarkerStyle = lipgloss.NewStyle().
+		Foreground(t.Warning)
+
+	securedMarkerStyle = lipgloss.NewStyle().
+		Foreground(t.Muted)
+}
 
 func listStatusbarInfoString(l list.Model) string {
 	if l.FilterState() == list.Filtering && l.FilterValue() != "" {
@@ -87,3 +126,13 @@ func listStatusMessageString(l list.Model) string {
 	}
 	return ""
 }
+
+const securedMarker = "🔒"
+
+// styledSecuredMarker returns the padlock shown with the operation which requires any security scheme.
+func styledSecuredMarker(p *topi.Path) string {
+	if !p.Secured() {
+		return ""
+	}
+	return " " + securedMarkerStyle.Render(securedMarker)
+}
@@ -0,0 +1,244 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lusingander/topi/internal/topi"
+)
+
+// Target is the page to open at startup instead of the start page, set by the command line arguments.
+type Target struct {
+	Tag         string
+	OperationId string
+	Path        string // "METHOD /path"
+	Info        bool
+}
+
+func (t *Target) empty() bool {
+	return t == nil || (t.Tag == "" && t.OperationId == "" && t.Path == "" && !t.Info)
+}
+
+// TargetNotFoundError is returned if the target page is not found in the specs.
+type TargetNotFoundError struct {
+	Kind        string // tag, operation or path
+	Name        string
+	Tag         string // tag to find the operation in, empty if not set
+	Suggestions []string
+}
+
+func (e *TargetNotFoundError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s not found: %s", e.Kind, e.Name)
+	if e.Tag != "" {
+		fmt.Fprintf(&sb, " (tag: %s)", e.Tag)
+	}
+	if len(e.Suggestions) > 0 {
+		sb.WriteString("\ndid you mean:")
+		for _, s := range e.Suggestions {
+			fmt.Fprintf(&sb, "\n  %s", s)
+		}
+	}
+	return sb.String()
+}
+
+func methodPathString(p *topi.Path) string {
+	return fmt.Sprintf("%s %s", p.Method, p.UriPath)
+}
+
+func containsTag(doc *topi.Document, name string) bool {
+	for _, tag := range doc.Tags {
+		if tag.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// targetPaths returns the operations to find the target operation in, all operations or the operations of the tag.
+func (t *Target) targetPaths(doc *topi.Document) []*topi.Path {
+	if t.Tag != "" {
+		return doc.TagPathMap[t.Tag]
+	}
+	ret := make([]*topi.Path, 0)
+	for _, paths := range doc.TagPathMap {
+		ret = append(ret, paths...)
+	}
+	return ret
+}
+
+func (t *Target) operationName(p *topi.Path) string {
+	if t.OperationId != "" {
+		return p.OperationId
+	}
+	return methodPathString(p)
+}
+
+func (t *Target) matches(p *topi.Path) bool {
+	if t.OperationId != "" {
+		return p.OperationId == t.OperationId
+	}
+	method, path, ok := strings.Cut(strings.TrimSpace(t.Path), " ")
+	return ok && p.Method == strings.ToUpper(method) && p.UriPath == strings.TrimSpace(path)
+}
+
+// resolve returns the index of the first spec which has the target, and the operation of the target (nil if not set).
+func (t *Target) resolve(docs []*topi.Document) (int, *topi.Path, error) {
+	findOp := t.OperationId != "" || t.Path != ""
+	tags := make([]string, 0)
+	ops := make([]string, 0)
+	tagFound := false
+	for i, doc := range docs {
+		for _, tag := range doc.Tags {
+			tags = append(tags, tag.Name)
+		}
+		if t.Tag != "" && !containsTag(doc, t.Tag) {
+			continue
+		}
+		tagFound = true
+		if !findOp {
+			return i, nil, nil
+		}
+		for _, p := range t.targetPaths(doc) {
+			if t.matches(p) {
+				if p.OperationId == "" {
+					// pages are keyed by operationId, so the operation can not be opened without it
+					return 0, nil, fmt.Errorf("operationId is not defined: %s", methodPathString(p))
+				}
+				return i, p, nil
+			}
+			ops = append(ops, t.operationName(p))
+		}
+	}
+	if !tagFound {
+		return 0, nil, &TargetNotFoundError{
+			Kind:        "tag",
+			Name:        t.Tag,
+			Suggestions: suggestions(t.Tag, tags),
+		}
+	}
+	if t.OperationId != "" {
+		return 0, nil, &TargetNotFoundError{
+			Kind:        "operation",
+			Name:        t.OperationId,
+			Tag:         t.Tag,
+			Suggestions: suggestions(t.OperationId, ops),
+		}
+	}
+	return 0, nil, &TargetNotFoundError{
+		Kind:        "path",
+		Name:        t.Path,
+		Tag:         t.Tag,
+		Suggestions: suggestions(t.Path, ops),
+	}
+}
+
+const maxSuggestions = 5
+
+// suggestions returns the candidates similar to s, the candidates containing s first and then the closer ones by edit distance.
+func suggestions(s string, candidates []string) []string {
+	type scored struct {
+		candidate string
+		score     int
+	}
+	lower := strings.ToLower(s)
+	limit := len(s)/3 + 1
+	ss := make([]scored, 0)
+	seen := make(map[string]bool)
+	for _, c := range candidates {
+		if c == "" || seen[c] {
+			continue
+		}
+		seen[c] = true
+		cl := strings.ToLower(c)
+		if strings.Contains(cl, lower) {
+			ss = append(ss, scored{c, 0})
+			continue
+		}
+		if d := editDistance(lower, cl); d <= limit {
+			ss = append(ss, scored{c, d})
+		}
+	}
+	sort.SliceStable(ss, func(i, j int) bool {
+		if ss[i].score != ss[j].score {
+			return ss[i].score < ss[j].score
+		}
+		return ss[i].candidate < ss[j].candidate
+	})
+	ret := make([]string, 0)
+	for i := 0; i < len(ss) && i < maxSuggestions; i++ {
+		ret = append(ret, ss[i].candidate)
+	}
+	return ret
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func min(ns ...int) int {
+	ret := ns[0]
+	for _, n := range ns[1:] {
+		if n < ret {
+			ret = n
+		}
+	}
+	return ret
+}
+
+// targetMsgs returns the messages to open the pages to the target in order.
+func (t *Target) targetMsgs(m model, docIdx int, op *topi.Path) []tea.Msg {
+	msgs := make([]tea.Msg, 0)
+	if m.multiSpec() {
+		msgs = append(msgs, selectSpecMsg{docIdx})
+	}
+	switch {
+	case t.Tag != "":
+		msgs = append(msgs, selectTagMenuMsg{}, selectTagMsg{t.Tag})
+	case op != nil:
+		msgs = append(msgs, selectPathMenuMsg{})
+	case t.Info:
+		msgs = append(msgs, selectInfoMenuMsg{})
+	}
+	if op != nil {
+		msgs = append(msgs, selectOperationMsg{op.OperationId})
+	}
+	return msgs
+}
+
+// openTarget opens the pages to the target instead of the start page.
+func (m model) openTarget(t *Target) (model, error) {
+	if t.empty() {
+		return m, nil
+	}
+	docIdx, op, err := t.resolve(m.docs)
+	if err != nil {
+		return m, err
+	}
+	m.startPageName = ""
+	for _, msg := range t.targetMsgs(m, docIdx, op) {
+		ret, _ := m.Update(msg)
+		m = ret.(model)
+	}
+	return m, nil
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lusingander/topi/internal/topi"
+)
+
+func TestSuggestions(t *testing.T) {
+	candidates := []string{"getPetById", "listPets", "createPet", "deletePet", "getStore"}
+	tests := []struct {
+		s    string
+		want []string
+	}{
+		{"getPet", []string{"getPetById"}},
+		{"pets", []string{"listPets"}},
+		{"deletPet", []string{"deletePet"}},
+		{"updateOrder", []string{}},
+	}
+	for _, test := range tests {
+		if got := suggestions(test.s, candidates); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+}
+
+func TestTargetResolve(t *testing.T) {
+	list := &topi.Path{Method: "GET", UriPath: "/pets", OperationId: "listPets"}
+	get := &topi.Path{Method: "GET", UriPath: "/pets/{id}", OperationId: "getPet"}
+	store := &topi.Path{Method: "GET", UriPath: "/stores", OperationId: "listStores"}
+	noId := &topi.Path{Method: "POST", UriPath: "/stores"}
+	doc1 := topi.NewDocument(&topi.Meta{}, &topi.Info{}, map[string][]*topi.Path{"pets": {list, get}}, nil, nil)
+	doc2 := topi.NewDocument(&topi.Meta{}, &topi.Info{}, map[string][]*topi.Path{"stores": {store, noId}}, nil, nil)
+	docs := []*topi.Document{doc1, doc2}
+
+	tests := []struct {
+		target  *Target
+		wantIdx int
+		wantOp  *topi.Path
+		wantErr string
+	}{
+		{&Target{OperationId: "getPet"}, 0, get, ""},
+		{&Target{Path: "get /stores"}, 1, store, ""},
+		{&Target{Tag: "stores"}, 1, nil, ""},
+		{&Target{Tag: "pets", OperationId: "listPets"}, 0, list, ""},
+		{&Target{Tag: "store"}, 0, nil, "tag not found: store\ndid you mean:\n  stores"},
+		{&Target{OperationId: "getPets"}, 0, nil, "operation not found: getPets\ndid you mean:\n  getPet\n  listPets"},
+		{&Target{Tag: "stores", OperationId: "getPet"}, 0, nil, "operation not found: getPet (tag: stores)"},
+		{&Target{Path: "GET /pets/{petId}"}, 0, nil, "path not found: GET /pets/{petId}\ndid you mean:\n  GET /pets/{id}"},
+		{&Target{Path: "post /stores"}, 0, nil, "operationId is not defined: POST /stores"},
+	}
+	for _, test := range tests {
+		idx, op, err := test.target.resolve(docs)
+		if test.wantErr != "" {
+			if err == nil || err.Error() != test.wantErr {
+				t.Errorf("got=%v, want=%v", err, test.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			continue
+		}
+		if idx != test.wantIdx || op != test.wantOp {
+			t.Errorf("got=(%v, %v), want=(%v, %v)", idx, op, test.wantIdx, test.wantOp)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lusingander/topi/internal/diff"
+)
+
+type diffPathsPage struct{}
+
+func (diffPathsPage) crumb() string { return "diff" }
+
+type diffOperationPage struct {
+	key string
+}
+
+func (p diffOperationPage) crumb() string { return p.key }
+
+type diffModel struct {
+	result *diff.Result
+
+	*pageStack
+
+	diffPathsPage     diffPathsPageModel
+	diffOperationPage diffOperationPageModel
+
+	width, height int
+}
+
+var _ tea.Model = (*diffModel)(nil)
+
+func newDiffModel(result *diff.Result) diffModel {
+	return diffModel{
+		result:            result,
+		pageStack:         newPageStack(diffPathsPage{}),
+		diffPathsPage:     newDiffPathsPageModel(result),
+		diffOperationPage: newDiffOperationPageModel(result),
+	}
+}
+
+func (m *diffModel) SetSize(w, h int) {
+	m.width, m.height = w, h
+
+	t, r, b, l := baseStyle.GetMargin()
+	w = w - r - l
+	h = h - t - b
+	h = h - 3 // :(
+
+	m.diffPathsPage.SetSize(w, h)
+	m.diffOperationPage.SetSize(w, h)
+}
+
+func (m diffModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m diffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		}
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+	case selectDiffOperationMsg:
+		m.pushPage(diffOperationPage(msg))
+	case goBackMsg:
+		m.popPage()
+	}
+	switch m.currentPage().(type) {
+	case diffPathsPage:
+		m.diffPathsPage, cmd = m.diffPathsPage.Update(msg)
+		return m, cmd
+	case diffOperationPage:
+		m.diffOperationPage, cmd = m.diffOperationPage.Update(msg)
+		return m, cmd
+	default:
+		return m, nil
+	}
+}
+
+func (m diffModel) View() string {
+	header := m.appHeader()
+	content := baseStyle.Render(m.content())
+	footer := m.appFooter()
+	return lipgloss.JoinVertical(lipgloss.Top, header, content, footer)
+}
+
+func (m diffModel) content() string {
+	switch m.currentPage().(type) {
+	case diffPathsPage:
+		return m.diffPathsPage.View()
+	case diffOperationPage:
+		return m.diffOperationPage.View()
+	default:
+		return "error... :("
+	}
+}
+
+func (m diffModel) appHeader() string {
+	bd := strings.Join(m.crumbs(), " > ")
+	return headerStyle.Render(bd)
+}
+
+func (m diffModel) appFooter() string {
+	w := m.width
+	if w == 0 {
+		return ""
+	}
+	names := fmt.Sprintf("%s -> %s", m.result.Old.Meta.FileName, m.result.New.Meta.FileName)
+	name := statusbarFileNameStyle.Render(names)
+	statusbarInfo := m.statusbarInfoString()
+	sw := w - lipgloss.Width(name) - lipgloss.Width(statusbarInfo)
+	spaces := statusbarSpaceColorStyle.Render(strings.Repeat(" ", sw))
+	u := name + spaces + statusbarInfo
+	statusMessage := m.statusMessageString()
+	l := statusbarLowerStyle.Render(statusMessage)
+	return footerStyle.Render(u + "\n" + l)
+}
+
+func (m diffModel) statusbarInfoString() string {
+	switch m.currentPage().(type) {
+	case diffPathsPage:
+		return m.diffPathsPage.statusbarInfoString()
+	case diffOperationPage:
+		return ""
+	default:
+		return "error... :("
+	}
+}
+
+func (m diffModel) statusMessageString() string {
+	switch m.currentPage().(type) {
+	case diffPathsPage:
+		return m.diffPathsPage.statusMessageString()
+	case diffOperationPage:
+		return ""
+	default:
+		return "error... :("
+	}
+}
+
+func StartDiff(result *diff.Result) error {
+	m := newDiffModel(result)
+	p := tea.NewProgram(m, programOptions()...)
+	return p.Start()
+}
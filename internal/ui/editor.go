@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lusingander/topi/internal/topi"
+)
+
+// editorCommand returns the command to open the file of the source at the line by the editor, like `$EDITOR +line file`.
+// The editor may contain arguments (e.g. "code --wait").
+func editorCommand(editor string, src *topi.Source) (*exec.Cmd, error) {
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		return nil, errors.New("$EDITOR is not set")
+	}
+	if !src.Local {
+		return nil, fmt.Errorf("cannot edit %s", src.File)
+	}
+	if src.Line > 0 {
+		args = append(args, fmt.Sprintf("+%d", src.Line))
+	}
+	args = append(args, src.File)
+	return exec.Command(args[0], args[1:]...), nil
+}
+
+// editSource suspends the viewer and opens the source in $EDITOR.
+func editSource(src *topi.Source) tea.Cmd {
+	if src == nil {
+		return showStatusMessage("source is unknown")
+	}
+	cmd, err := editorCommand(os.Getenv("EDITOR"), src)
+	if err != nil {
+		return showStatusMessage(err.Error())
+	}
+	return tea.ExecProcess(cmd, editorFinished)
+}
+
+// sourceString returns the source for display, with the path relative to the current directory if possible.
+func sourceString(src *topi.Source) string {
+	file := src.File
+	if src.Local {
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, file); err == nil && !strings.HasPrefix(rel, "..") {
+				file = rel
+			}
+		}
+	}
+	if src.Line > 0 {
+		file = fmt.Sprintf("%s:%d", file, src.Line)
+	}
+	if src.Pointer == "" {
+		return file
+	}
+	return fmt.Sprintf("%s #%s", file, src.Pointer)
+}
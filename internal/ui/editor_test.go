@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lusingander/topi/internal/topi"
+)
+
+func TestEditorCommand(t *testing.T) {
+	tests := []struct {
+		editor  string
+		src     *topi.Source
+		want    []string
+		wantErr bool
+	}{
+		{"vim", &topi.Source{File: "/api/pet.yaml", Line: 12, Local: true}, []string{"vim", "+12", "/api/pet.yaml"}, false},
+		{"code --wait", &topi.Source{File: "/api/pet.yaml", Line: 3, Local: true}, []string{"code", "--wait", "+3", "/api/pet.yaml"}, false},
+		{"vim", &topi.Source{File: "/api/pet.yaml", Local: true}, []string{"vim", "/api/pet.yaml"}, false},
+		{"", &topi.Source{File: "/api/pet.yaml", Line: 12, Local: true}, nil, true},
+		{"vim", &topi.Source{File: "https://example.com/pet.yaml", Line: 12}, nil, true},
+	}
+	for _, tt := range tests {
+		cmd, err := editorCommand(tt.editor, tt.src)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("error is expected: %v", tt.editor)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(cmd.Args, tt.want) {
+			t.Errorf("got=%v, want=%v", cmd.Args, tt.want)
+		}
+	}
+}
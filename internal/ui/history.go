@@ -0,0 +1,83 @@
+package ui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// reopenPageMsg returns the message to open the page again by going forward.
+// Returns nil if the page can not be opened again by a message.
+func reopenPageMsg(p page) tea.Msg {
+	switch p := p.(type) {
+	case searchPage:
+		return selectSearchMenuMsg{}
+	case problemsPage:
+		return selectProblemsMenuMsg{}
+	case infoPage:
+		return selectInfoMenuMsg{}
+	case tagPage:
+		return selectTagMenuMsg{}
+	case tagPathsPage:
+		return selectTagMsg(p)
+	case pathPage:
+		return selectPathMenuMsg{}
+	case securityPage:
+		return selectSecurityMenuMsg{}
+	case securitySchemePage:
+		return selectSecuritySchemeMsg(p)
+	case bookmarksPage:
+		return selectBookmarksMenuMsg{}
+	case recentPage:
+		return selectRecentMenuMsg{}
+	case operationPage:
+		return selectOperationMsg(p)
+	case helpMenuPage:
+		return selectHelpMenuMsg{}
+	case helpPage:
+		return selectHelpHelpMenuMsg{}
+	case aboutPage:
+		return selectAboutMenuMsg{}
+	case creditsPage:
+		return selectCreditsMenuMsg{}
+	default:
+		// specPage and menuPage are changed by switching specs
+		return nil
+	}
+}
+
+const jumpListSize = 100
+
+type jumpListEntry struct {
+	index       int // index of the spec
+	operationId string
+}
+
+// jumpList is the operations opened in the session, to jump back and forth between them like the jump list of vim.
+type jumpList struct {
+	entries []jumpListEntry
+	pos     int // index of the current entry
+}
+
+// add appends the opened operation as the newest entry, and moves the current position to it.
+func (l *jumpList) add(e jumpListEntry) {
+	entries := make([]jumpListEntry, 0, len(l.entries)+1)
+	for _, entry := range l.entries {
+		if entry != e {
+			entries = append(entries, entry)
+		}
+	}
+	entries = append(entries, e)
+	if len(entries) > jumpListSize {
+		entries = entries[len(entries)-jumpListSize:]
+	}
+	l.entries = entries
+	l.pos = len(entries) - 1
+}
+
+// move moves the current position by d, and returns the entry at the position.
+// Returns false if the position is out of the list.
+func (l *jumpList) move(d int) (jumpListEntry, bool) {
+	pos := l.pos + d
+	if pos < 0 || pos >= len(l.entries) {
+		return jumpListEntry{}, false
+	}
+	l.pos = pos
+	return l.entries[pos], true
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPageStackForward(t *testing.T) {
+	s := newPageStack(menuPage{})
+	s.pushPage(pathPage{})
+	s.pushPage(operationPage{"listPets"})
+
+	s.backPage()
+	s.backPage()
+	if got, want := s.forwardPage(), page(pathPage{}); got != want {
+		t.Errorf("got=%v, want=%v", got, want)
+	}
+	if got, want := s.forwardPage(), page(operationPage{"listPets"}); got != want {
+		t.Errorf("got=%v, want=%v", got, want)
+	}
+
+	// pushing a new page clears the pages to go forward
+	s.pushPage(tagPage{})
+	s.backPage()
+	s.pushPage(infoPage{})
+	if got := s.forwardPage(); got != nil {
+		t.Errorf("got=%v, want=nil", got)
+	}
+}
+
+func TestJumpList(t *testing.T) {
+	a := jumpListEntry{0, "a"}
+	b := jumpListEntry{0, "b"}
+	c := jumpListEntry{1, "c"}
+
+	l := jumpList{}
+	l.add(a)
+	l.add(b)
+	l.add(c)
+	if _, ok := l.move(1); ok {
+		t.Errorf("move(1) must fail at the newest entry")
+	}
+	if got, _ := l.move(-1); got != b {
+		t.Errorf("got=%v, want=%v", got, b)
+	}
+	if got, _ := l.move(-1); got != a {
+		t.Errorf("got=%v, want=%v", got, a)
+	}
+	if _, ok := l.move(-1); ok {
+		t.Errorf("move(-1) must fail at the oldest entry")
+	}
+
+	// opening the operation again moves it to the newest
+	l.add(b)
+	if want := []jumpListEntry{a, c, b}; !reflect.DeepEqual(l.entries, want) {
+		t.Errorf("got=%v, want=%v", l.entries, want)
+	}
+	if got, _ := l.move(-1); got != c {
+		t.Errorf("got=%v, want=%v", got, c)
+	}
+}
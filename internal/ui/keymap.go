@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/lusingander/topi/internal/config"
+)
+
+type actionKeys []string
+
+func (k actionKeys) binding(desc string) key.Binding {
+	return key.NewBinding(
+		key.WithKeys(k...),
+		key.WithHelp(k[0], desc),
+	)
+}
+
+type keyMap struct {
+	quit        actionKeys
+	help        actionKeys
+	specMenu    actionKeys
+	cycleTheme  actionKeys
+	back        actionKeys
+	forward     actionKeys
+	jumpBack    actionKeys
+	jumpForward actionKeys
+	selectItem  actionKeys
+	nextItem    actionKeys
+	prevItem    actionKeys
+	openBrowser actionKeys
+	toggle      actionKeys
+	bookmark    actionKeys
+	edit        actionKeys
+	raw         actionKeys
+	token       actionKeys
+}
+
+func defaultKeyMap() keyMap {
+	return keyMap{
+		quit:        actionKeys{"ctrl+c"},
+		help:        actionKeys{"?"},
+		specMenu:    actionKeys{"ctrl+o"},
+		cycleTheme:  actionKeys{"ctrl+t"},
+		back:        actionKeys{"backspace", "ctrl+h", "alt+left"},
+		forward:     actionKeys{"alt+right"},
+		jumpBack:    actionKeys{"ctrl+p"},
+		jumpForward: actionKeys{"ctrl+n"},
+		selectItem:  actionKeys{"enter"},
+		nextItem:    actionKeys{"tab"},
+		prevItem:    actionKeys{"shift+tab"},
+		openBrowser: actionKeys{"x"},
+		toggle:      actionKeys{"t"},
+		bookmark:    actionKeys{"m"},
+		edit:        actionKeys{"e"},
+		raw:         actionKeys{"r"},
+		token:       actionKeys{"a"},
+	}
+}
+
+// keys is the key bindings for all pages, must be set before the page models are created.
+var keys = defaultKeyMap()
+
+// override replaces the keys set in the config.
+func (m *keyMap) override(c config.KeymapConfig) {
+	overrideKeys(&m.quit, c.Quit)
+	overrideKeys(&m.help, c.Help)
+	overrideKeys(&m.specMenu, c.SpecMenu)
+	overrideKeys(&m.cycleTheme, c.CycleTheme)
+	overrideKeys(&m.back, c.Back)
+	overrideKeys(&m.forward, c.Forward)
+	overrideKeys(&m.jumpBack, c.JumpBack)
+	overrideKeys(&m.jumpForward, c.JumpForward)
+	overrideKeys(&m.selectItem, c.Select)
+	overrideKeys(&m.nextItem, c.NextItem)
+	overrideKeys(&m.prevItem, c.PrevItem)
+	overrideKeys(&m.openBrowser, c.OpenBrowser)
+	overrideKeys(&m.toggle, c.Toggle)
+	overrideKeys(&m.bookmark, c.Bookmark)
+	overrideKeys(&m.edit, c.Edit)
+	overrideKeys(&m.raw, c.Raw)
+	overrideKeys(&m.token, c.Token)
+}
+
+func overrideKeys(k *actionKeys, ks []string) {
+	if len(ks) > 0 {
+		*k = ks
+	}
+}
@@ -3,17 +3,27 @@ package ui
 import (
 	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/glamour/ansi"
+	"github.com/charmbracelet/lipgloss"
 )
 
+// markdownWrapWidth is the max width to wrap descriptions, 0 means the width of the window.
+var markdownWrapWidth int
+
 func markdownRenderer(w int) (*glamour.TermRenderer, error) {
+	if markdownWrapWidth > 0 && w > markdownWrapWidth {
+		w = markdownWrapWidth
+	}
 	return glamour.NewTermRenderer(
 		glamour.WithStyles(glamourStyleConfig),
 		glamour.WithWordWrap(w),
+		glamour.WithColorProfile(lipgloss.ColorProfile()),
 	)
 }
 
-var (
-	glamourStyleConfig = ansi.StyleConfig{
+var glamourStyleConfig ansi.StyleConfig
+
+func newGlamourStyleConfig(t *theme) ansi.StyleConfig {
+	return ansi.StyleConfig{
 		Document: ansi.StyleBlock{
 			StylePrimitive: ansi.StylePrimitive{
 				BlockPrefix: "",
@@ -36,7 +46,7 @@ var (
 		},
 		BlockQuote: ansi.StyleBlock{
 			StylePrimitive: ansi.StylePrimitive{
-				Color: ptr("246"),
+				Color: ptr(colorString(t.Muted)),
 			},
 			Indent:      ptr[uint](1),
 			IndentToken: ptr("| "),
@@ -47,7 +57,7 @@ var (
 		Heading: ansi.StyleBlock{
 			StylePrimitive: ansi.StylePrimitive{
 				BlockSuffix: "\n",
-				Color:       ptr("70"),
+				Color:       ptr(colorString(t.Primary)),
 			},
 		},
 		H1: ansi.StyleBlock{
@@ -90,7 +100,7 @@ var (
 			Bold: ptr(true),
 		},
 		HorizontalRule: ansi.StylePrimitive{
-			Color:  ptr("242"),
+			Color:  ptr(colorString(t.MarkdownRule)),
 			Format: "----",
 		},
 		Item: ansi.StylePrimitive{
@@ -105,121 +115,39 @@ var (
 			Unticked:       "[ ] ",
 		},
 		Link: ansi.StylePrimitive{
-			Color:       ptr("33"),
+			Color:       ptr(colorString(t.Link)),
 			Underline:   ptr(true),
 			BlockPrefix: "(",
 			BlockSuffix: ")",
 		},
 		LinkText: ansi.StylePrimitive{
-			Color: ptr("195"),
+			Color: ptr(colorString(t.MarkdownLinkText)),
 		},
 		Image: ansi.StylePrimitive{
 			Underline:   ptr(true),
-			Color:       ptr("33"),
+			Color:       ptr(colorString(t.Link)),
 			BlockPrefix: "(",
 			BlockSuffix: ")",
 		},
 		ImageText: ansi.StylePrimitive{
-			Color: ptr("195"),
+			Color: ptr(colorString(t.MarkdownLinkText)),
 		},
 		Code: ansi.StyleBlock{
 			StylePrimitive: ansi.StylePrimitive{
 				Prefix:          " ",
 				Suffix:          " ",
-				Color:           ptr("203"),
-				BackgroundColor: ptr("236"),
+				Color:           ptr(colorString(t.CodeFg)),
+				BackgroundColor: ptr(colorString(t.CodeBg)),
 			},
 		},
 		CodeBlock: ansi.StyleCodeBlock{
 			StyleBlock: ansi.StyleBlock{
 				StylePrimitive: ansi.StylePrimitive{
-					Color: ptr("244"),
+					Color: ptr(colorString(t.MarkdownCodeBlock)),
 				},
 				Margin: ptr[uint](0),
 			},
-			Chroma: &ansi.Chroma{
-				Text: ansi.StylePrimitive{
-					Color: ptr("#C4C4C4"),
-				},
-				Error: ansi.StylePrimitive{
-					Color:           ptr("#F1F1F1"),
-					BackgroundColor: ptr("#F05B5B"),
-				},
-				Comment: ansi.StylePrimitive{
-					Color: ptr("#676767"),
-				},
-				CommentPreproc: ansi.StylePrimitive{
-					Color: ptr("#FF875F"),
-				},
-				Keyword: ansi.StylePrimitive{
-					Color: ptr("#00AAFF"),
-				},
-				KeywordReserved: ansi.StylePrimitive{
-					Color: ptr("#FF5FD2"),
-				},
-				KeywordNamespace: ansi.StylePrimitive{
-					Color: ptr("#FF5F87"),
-				},
-				KeywordType: ansi.StylePrimitive{
-					Color: ptr("#6E6ED8"),
-				},
-				Operator: ansi.StylePrimitive{
-					Color: ptr("#EF8080"),
-				},
-				Punctuation: ansi.StylePrimitive{
-					Color: ptr("#E8E8A8"),
-				},
-				Name: ansi.StylePrimitive{
-					Color: ptr("#C4C4C4"),
-				},
-				NameBuiltin: ansi.StylePrimitive{
-					Color: ptr("#FF8EC7"),
-				},
-				NameTag: ansi.StylePrimitive{
-					Color: ptr("#B083EA"),
-				},
-				NameAttribute: ansi.StylePrimitive{
-					Color: ptr("#7A7AE6"),
-				},
-				NameClass: ansi.StylePrimitive{
-					Color:     ptr("#F1F1F1"),
-					Underline: ptr(true),
-					Bold:      ptr(true),
-				},
-				NameDecorator: ansi.StylePrimitive{
-					Color: ptr("#FFFF87"),
-				},
-				NameFunction: ansi.StylePrimitive{
-					Color: ptr("#00D787"),
-				},
-				LiteralNumber: ansi.StylePrimitive{
-					Color: ptr("#6EEFC0"),
-				},
-				LiteralString: ansi.StylePrimitive{
-					Color: ptr("#C69669"),
-				},
-				LiteralStringEscape: ansi.StylePrimitive{
-					Color: ptr("#AFFFD7"),
-				},
-				GenericDeleted: ansi.StylePrimitive{
-					Color: ptr("#FD5B5B"),
-				},
-				GenericEmph: ansi.StylePrimitive{
-					Italic: ptr(true),
-				},
-				GenericInserted: ansi.StylePrimitive{
-					Color: ptr("#00D787"),
-				},
-				GenericStrong: ansi.StylePrimitive{
-					Bold: ptr(true),
-				},
-				GenericSubheading: ansi.StylePrimitive{
-					Color: ptr("#777777"),
-				},
-				Background: ansi.StylePrimitive{
-					BackgroundColor: ptr("#373737"),
-				},
-			},
+			Chroma: t.chroma,
 		},
 		Table: ansi.StyleTable{
 			StyleBlock: ansi.StyleBlock{
@@ -233,4 +161,176 @@ var (
 			BlockPrefix: " ",
 		},
 	}
-)
+}
+
+func darkChroma() *ansi.Chroma {
+	return &ansi.Chroma{
+		Text: ansi.StylePrimitive{
+			Color: ptr("#C4C4C4"),
+		},
+		Error: ansi.StylePrimitive{
+			Color:           ptr("#F1F1F1"),
+			BackgroundColor: ptr("#F05B5B"),
+		},
+		Comment: ansi.StylePrimitive{
+			Color: ptr("#676767"),
+		},
+		CommentPreproc: ansi.StylePrimitive{
+			Color: ptr("#FF875F"),
+		},
+		Keyword: ansi.StylePrimitive{
+			Color: ptr("#00AAFF"),
+		},
+		KeywordReserved: ansi.StylePrimitive{
+			Color: ptr("#FF5FD2"),
+		},
+		KeywordNamespace: ansi.StylePrimitive{
+			Color: ptr("#FF5F87"),
+		},
+		KeywordType: ansi.StylePrimitive{
+			Color: ptr("#6E6ED8"),
+		},
+		Operator: ansi.StylePrimitive{
+			Color: ptr("#EF8080"),
+		},
+		Punctuation: ansi.StylePrimitive{
+			Color: ptr("#E8E8A8"),
+		},
+		Name: ansi.StylePrimitive{
+			Color: ptr("#C4C4C4"),
+		},
+		NameBuiltin: ansi.StylePrimitive{
+			Color: ptr("#FF8EC7"),
+		},
+		NameTag: ansi.StylePrimitive{
+			Color: ptr("#B083EA"),
+		},
+		NameAttribute: ansi.StylePrimitive{
+			Color: ptr("#7A7AE6"),
+		},
+		NameClass: ansi.StylePrimitive{
+			Color:     ptr("#F1F1F1"),
+			Underline: ptr(true),
+			Bold:      ptr(true),
+		},
+		NameDecorator: ansi.StylePrimitive{
+			Color: ptr("#FFFF87"),
+		},
+		NameFunction: ansi.StylePrimitive{
+			Color: ptr("#00D787"),
+		},
+		LiteralNumber: ansi.StylePrimitive{
+			Color: ptr("#6EEFC0"),
+		},
+		LiteralString: ansi.StylePrimitive{
+			Color: ptr("#C69669"),
+		},
+		LiteralStringEscape: ansi.StylePrimitive{
+			Color: ptr("#AFFFD7"),
+		},
+		GenericDeleted: ansi.StylePrimitive{
+			Color: ptr("#FD5B5B"),
+		},
+		GenericEmph: ansi.StylePrimitive{
+			Italic: ptr(true),
+		},
+		GenericInserted: ansi.StylePrimitive{
+			Color: ptr("#00D787"),
+		},
+		GenericStrong: ansi.StylePrimitive{
+			Bold: ptr(true),
+		},
+		GenericSubheading: ansi.StylePrimitive{
+			Color: ptr("#777777"),
+		},
+		Background: ansi.StylePrimitive{
+			BackgroundColor: ptr("#373737"),
+		},
+	}
+}
+
+func lightChroma() *ansi.Chroma {
+	return &ansi.Chroma{
+		Text: ansi.StylePrimitive{
+			Color: ptr("#24292E"),
+		},
+		Error: ansi.StylePrimitive{
+			Color:           ptr("#F1F1F1"),
+			BackgroundColor: ptr("#CB2431"),
+		},
+		Comment: ansi.StylePrimitive{
+			Color: ptr("#6A737D"),
+		},
+		CommentPreproc: ansi.StylePrimitive{
+			Color: ptr("#D73A49"),
+		},
+		Keyword: ansi.StylePrimitive{
+			Color: ptr("#D73A49"),
+		},
+		KeywordReserved: ansi.StylePrimitive{
+			Color: ptr("#D73A49"),
+		},
+		KeywordNamespace: ansi.StylePrimitive{
+			Color: ptr("#D73A49"),
+		},
+		KeywordType: ansi.StylePrimitive{
+			Color: ptr("#6F42C1"),
+		},
+		Operator: ansi.StylePrimitive{
+			Color: ptr("#D73A49"),
+		},
+		Punctuation: ansi.StylePrimitive{
+			Color: ptr("#24292E"),
+		},
+		Name: ansi.StylePrimitive{
+			Color: ptr("#24292E"),
+		},
+		NameBuiltin: ansi.StylePrimitive{
+			Color: ptr("#005CC5"),
+		},
+		NameTag: ansi.StylePrimitive{
+			Color: ptr("#22863A"),
+		},
+		NameAttribute: ansi.StylePrimitive{
+			Color: ptr("#6F42C1"),
+		},
+		NameClass: ansi.StylePrimitive{
+			Color:     ptr("#6F42C1"),
+			Underline: ptr(true),
+			Bold:      ptr(true),
+		},
+		NameDecorator: ansi.StylePrimitive{
+			Color: ptr("#E36209"),
+		},
+		NameFunction: ansi.StylePrimitive{
+			Color: ptr("#6F42C1"),
+		},
+		LiteralNumber: ansi.StylePrimitive{
+			Color: ptr("#005CC5"),
+		},
+		LiteralString: ansi.StylePrimitive{
+			Color: ptr("#032F62"),
+		},
+		LiteralStringEscape: ansi.StylePrimitive{
+			Color: ptr("#22863A"),
+		},
+		GenericDeleted: ansi.StylePrimitive{
+			Color: ptr("#B31D28"),
+		},
+		GenericEmph: ansi.StylePrimitive{
+			Italic: ptr(true),
+		},
+		GenericInserted: ansi.StylePrimitive{
+			Color: ptr("#22863A"),
+		},
+		GenericStrong: ansi.StylePrimitive{
+			Bold: ptr(true),
+		},
+		GenericSubheading: ansi.StylePrimitive{
+			Color: ptr("#6A737D"),
+		},
+		Background: ansi.StylePrimitive{
+			BackgroundColor: ptr("#F6F8FA"),
+		},
+	}
+}
@@ -71,3 +71,99 @@ type goBackMsg struct{}
 func goBack() tea.Msg {
 	return goBackMsg{}
 }
+
+type selectSpecMenuMsg struct{}
+
+func selectSpecMenu() tea.Msg {
+	return selectSpecMenuMsg{}
+}
+
+type selectSpecMsg struct {
+	index int
+}
+
+func selectSpec(index int) tea.Cmd {
+	return func() tea.Msg { return selectSpecMsg{index} }
+}
+
+type selectSearchMenuMsg struct{}
+
+func selectSearchMenu() tea.Msg {
+	return selectSearchMenuMsg{}
+}
+
+type selectSearchResultMsg struct {
+	index       int
+	operationId string
+}
+
+func selectSearchResult(index int, operationId string) tea.Cmd {
+	return func() tea.Msg { return selectSearchResultMsg{index, operationId} }
+}
+
+type selectDiffOperationMsg struct {
+	key string
+}
+
+func selectDiffOperation(key string) tea.Cmd {
+	return func() tea.Msg { return selectDiffOperationMsg{key} }
+}
+
+type selectProblemsMenuMsg struct{}
+
+func selectProblemsMenu() tea.Msg {
+	return selectProblemsMenuMsg{}
+}
+
+type selectSecurityMenuMsg struct{}
+
+func selectSecurityMenu() tea.Msg {
+	return selectSecurityMenuMsg{}
+}
+
+type selectSecuritySchemeMsg struct {
+	key string
+}
+
+func selectSecurityScheme(key string) tea.Cmd {
+	return func() tea.Msg { return selectSecuritySchemeMsg{key} }
+}
+
+type selectBookmarksMenuMsg struct{}
+
+func selectBookmarksMenu() tea.Msg {
+	return selectBookmarksMenuMsg{}
+}
+
+type selectRecentMenuMsg struct{}
+
+func selectRecentMenu() tea.Msg {
+	return selectRecentMenuMsg{}
+}
+
+type jumpMsg jumpListEntry
+
+func jumpTo(e jumpListEntry) tea.Cmd {
+	return func() tea.Msg { return jumpMsg(e) }
+}
+
+type statusMessageMsg struct {
+	message string
+}
+
+func showStatusMessage(message string) tea.Cmd {
+	return func() tea.Msg { return statusMessageMsg{message} }
+}
+
+type tokenAcquiredMsg struct {
+	key string
+	err error
+}
+
+type editorFinishedMsg struct {
+	err error
+}
+
+func editorFinished(err error) tea.Msg {
+	return editorFinishedMsg{err}
+}
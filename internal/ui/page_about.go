@@ -12,24 +12,32 @@ import (
 )
 
 var (
+	aboutPageTitleStyle       lipgloss.Style
+	aboutPageTitleBarStyle    lipgloss.Style
+	aboutPageUrlStyle         lipgloss.Style
+	aboutPageSelectedUrlStyle lipgloss.Style
+	aboutPageItemStyle        lipgloss.Style
+)
+
+func loadAboutPageStyles(t *theme) {
 	aboutPageTitleStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("70")).
-				Bold(true)
+		Foreground(t.Primary).
+		Bold(true)
 
 	aboutPageTitleBarStyle = lipgloss.NewStyle().
-				Padding(0, 2, 1)
+		Padding(0, 2, 1)
 
 	aboutPageUrlStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("33")).
-				Underline(true)
+		Foreground(t.Link).
+		Underline(true)
 
 	aboutPageSelectedUrlStyle = lipgloss.NewStyle().
-					Background(lipgloss.Color("250")).
-					Foreground(lipgloss.Color("56"))
+		Background(t.SelectedLinkBg).
+		Foreground(t.SelectedLinkFg)
 
 	aboutPageItemStyle = lipgloss.NewStyle().
-				Padding(1, 2)
-)
+		Padding(1, 2)
+}
 
 type aboutPageSelectableItems int
 
@@ -63,22 +71,10 @@ type aboutPageDelegateKeyMap struct {
 
 func newAboutPageDelegateKeyMap() aboutPageDelegateKeyMap {
 	return aboutPageDelegateKeyMap{
-		back: key.NewBinding(
-			key.WithKeys("backspace", "ctrl+h"),
-			key.WithHelp("backspace", "back"),
-		),
-		tab: key.NewBinding(
-			key.WithKeys("tab"),
-			key.WithHelp("tab", "select next item"),
-		),
-		shiftTab: key.NewBinding(
-			key.WithKeys("shift+tab"),
-			key.WithHelp("shift+tab", "select prev item"),
-		),
-		openBrowser: key.NewBinding(
-			key.WithKeys("x"),
-			key.WithHelp("x", "open in browser"),
-		),
+		back:        keys.back.binding("back"),
+		tab:         keys.nextItem.binding("select next item"),
+		shiftTab:    keys.prevItem.binding("select prev item"),
+		openBrowser: keys.openBrowser.binding("open in browser"),
 	}
 }
 
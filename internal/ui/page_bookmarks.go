@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lusingander/topi/internal/bookmark"
+	"github.com/lusingander/topi/internal/topi"
+)
+
+type bookmarksPageModel struct {
+	doc           *topi.Document
+	bookmarks     *specBookmarks
+	list          list.Model
+	delegateKeys  bookmarksPageDelegateKeyMap
+	notFound      int
+	width, height int
+}
+
+func newBookmarksPageModel(doc *topi.Document, bookmarks *specBookmarks) bookmarksPageModel {
+	m := bookmarksPageModel{
+		doc:       doc,
+		bookmarks: bookmarks,
+	}
+	m.delegateKeys = newBookmarksPageDelegateKeyMap()
+	delegate := newPathPageListDelegate(bookmarks)
+	m.list = list.New(nil, delegate, 0, 0)
+	m.list.SetShowTitle(false)
+	m.list.SetShowHelp(false)
+	m.list.SetShowStatusBar(false)
+	m.list.SetShowFilter(false)
+	m.list.SetShowPagination(false)
+	m.list.KeyMap.Quit.Unbind()
+	return m
+}
+
+type bookmarksPageDelegateKeyMap struct {
+	enter    key.Binding
+	back     key.Binding
+	bookmark key.Binding
+}
+
+func newBookmarksPageDelegateKeyMap() bookmarksPageDelegateKeyMap {
+	return bookmarksPageDelegateKeyMap{
+		enter:    keys.selectItem.binding("select"),
+		back:     keys.back.binding("back"),
+		bookmark: keys.bookmark.binding("remove bookmark"),
+	}
+}
+
+func (m *bookmarksPageModel) SetSize(w, h int) {
+	m.width, m.height = w, h
+	m.list.SetSize(w, h)
+}
+
+func (m *bookmarksPageModel) updateList() {
+	paths, notFound := bookmark.Resolve(m.doc, m.bookmarks.list())
+	items := make([]list.Item, len(paths))
+	for i, path := range paths {
+		items[i] = pathPageListItem{path}
+	}
+	m.notFound = len(notFound)
+	m.list.SetItems(items)
+}
+
+func (m *bookmarksPageModel) removeBookmark() tea.Cmd {
+	item, ok := m.list.SelectedItem().(pathPageListItem)
+	if !ok {
+		return nil
+	}
+	cmd := m.bookmarks.toggle(item.path)
+	m.updateList()
+	return cmd
+}
+
+func (m *bookmarksPageModel) reset() {
+	m.list.ResetSelected()
+	m.list.ResetFilter()
+}
+
+func (m bookmarksPageModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m bookmarksPageModel) Update(msg tea.Msg) (bookmarksPageModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.delegateKeys.back):
+			if m.list.FilterState() != list.Filtering {
+				return m, goBack
+			}
+		case key.Matches(msg, m.delegateKeys.enter):
+			if m.list.FilterState() != list.Filtering {
+				if item, ok := m.list.SelectedItem().(pathPageListItem); ok {
+					return m, selectOperation(item.path.OperationId)
+				}
+				return m, nil
+			}
+		case key.Matches(msg, m.delegateKeys.bookmark):
+			if m.list.FilterState() != list.Filtering {
+				return m, m.removeBookmark()
+			}
+		}
+	case selectBookmarksMenuMsg:
+		m.updateList()
+		m.reset()
+		return m, nil
+	case goBackMsg:
+		// bookmarks may be changed on the operation page
+		m.updateList()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m bookmarksPageModel) View() string {
+	return m.list.View()
+}
+
+func (m bookmarksPageModel) statusbarInfoString() string {
+	return listStatusbarInfoString(m.list)
+}
+
+func (m bookmarksPageModel) statusMessageString() string {
+	if s := listStatusMessageString(m.list); s != "" {
+		return s
+	}
+	if len(m.list.Items()) == 0 && m.notFound == 0 {
+		return fmt.Sprintf("No bookmarks, press %s on an operation to add", keys.bookmark[0])
+	}
+	if m.notFound > 0 {
+		return fmt.Sprintf("%d bookmarks not found in this spec", m.notFound)
+	}
+	return ""
+}
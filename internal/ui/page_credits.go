@@ -13,23 +13,31 @@ import (
 )
 
 var (
+	creditsPageContentStyle        lipgloss.Style
+	creditsPageRepositoryNameStyle lipgloss.Style
+	creditsPageUrlStyle            lipgloss.Style
+	creditsPageSeparatorColorStyle lipgloss.Style
+	creditsPageSeparator           string
+)
+
+func loadCreditsPageStyles(t *theme) {
 	creditsPageContentStyle = lipgloss.NewStyle().
-				Padding(0, 2)
+		Padding(0, 2)
 
 	creditsPageRepositoryNameStyle = lipgloss.NewStyle().
-					Foreground(lipgloss.Color("70")).
-					Bold(true)
+		Foreground(t.Primary).
+		Bold(true)
 
 	creditsPageUrlStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("33")).
-				Underline(true)
+		Foreground(t.Link).
+		Underline(true)
 
 	creditsPageSeparatorColorStyle = lipgloss.NewStyle().
-					Foreground(lipgloss.Color("240"))
+		Foreground(t.Separator)
 
 	creditsPageSeparator = creditsPageSeparatorColorStyle.
-				Render("----------------------------------------")
-)
+		Render("----------------------------------------")
+}
 
 type creditsPageModel struct {
 	viewport      viewport.Model
@@ -65,14 +73,8 @@ type creditsPageDelegateKeyMap struct {
 
 func newCreditsPageDelegateKeyMap() creditsPageDelegateKeyMap {
 	return creditsPageDelegateKeyMap{
-		back: key.NewBinding(
-			key.WithKeys("backspace", "ctrl+h"),
-			key.WithHelp("backspace", "back"),
-		),
-		toggle: key.NewBinding(
-			key.WithKeys("t"),
-			key.WithHelp("t", "toggle"),
-		),
+		back:   keys.back.binding("back"),
+		toggle: keys.toggle.binding("toggle"),
 	}
 }
 
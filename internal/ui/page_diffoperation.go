@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lusingander/topi/internal/diff"
+	"github.com/lusingander/topi/internal/topi"
+)
+
+var (
+	diffOperationPageSideHeaderStyle lipgloss.Style
+	diffOperationPageNoneStyle       lipgloss.Style
+	diffOperationPageChangesStyle    lipgloss.Style
+)
+
+func loadDiffOperationPageStyles(t *theme) {
+	diffOperationPageSideHeaderStyle = lipgloss.NewStyle().
+		Foreground(t.Primary).
+		Underline(true).
+		Padding(0, 2)
+
+	diffOperationPageNoneStyle = lipgloss.NewStyle().
+		Foreground(t.Muted).
+		Padding(1, 2)
+
+	diffOperationPageChangesStyle = operationPageItemStyle.Copy().
+		Margin(0, 0, 0, 2)
+}
+
+type diffOperationPageModel struct {
+	result        *diff.Result
+	operation     *diff.OperationDiff
+	viewport      viewport.Model
+	delegateKeys  diffOperationPageDelegateKeyMap
+	width, height int
+}
+
+func newDiffOperationPageModel(result *diff.Result) diffOperationPageModel {
+	m := diffOperationPageModel{
+		result: result,
+	}
+	m.delegateKeys = newDiffOperationPageDelegateKeyMap()
+	m.viewport = viewport.New(0, 0)
+	return m
+}
+
+type diffOperationPageDelegateKeyMap struct {
+	back key.Binding
+}
+
+func newDiffOperationPageDelegateKeyMap() diffOperationPageDelegateKeyMap {
+	return diffOperationPageDelegateKeyMap{
+		back: keys.back.binding("back"),
+	}
+}
+
+func (m *diffOperationPageModel) SetSize(w, h int) {
+	m.width, m.height = w, h
+	m.viewport.Width, m.viewport.Height = w, h
+	m.updateContent()
+}
+
+func (m *diffOperationPageModel) reset() {
+	m.viewport.GotoTop()
+}
+
+func (m *diffOperationPageModel) updateContent() {
+	op := m.operation
+	if op == nil {
+		return
+	}
+
+	var content strings.Builder
+
+	sectionHeader := operationPageSectionHeaderStyle.Render("Changes")
+	content.WriteString(operationPageItemStyle.Render(sectionHeader))
+
+	changes := make([]string, len(op.Changes))
+	for i, c := range op.Changes {
+		s := styledChangeType(c.Type, c.String())
+		if c.Breaking() {
+			s += diffBreakingMarkerStyle.Render("Breaking")
+		}
+		changes[i] = s
+	}
+	content.WriteString(diffOperationPageChangesStyle.Render(strings.Join(changes, "\n")))
+
+	content.WriteString(operationPageSeparator)
+
+	w := m.width / 2
+	left := m.styledSide("Old", op.Old, w)
+	right := m.styledSide("New", op.New, w)
+	content.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, left, right))
+
+	m.viewport.SetContent(content.String())
+}
+
+func (diffOperationPageModel) styledSide(title string, op *topi.Path, width int) string {
+	h := diffOperationPageSideHeaderStyle.Render(title)
+	var body string
+	if op == nil {
+		body = diffOperationPageNoneStyle.Render("(none)")
+	} else {
+		body = styledOperation(op, operationStyleOptions{}, width)
+	}
+	return lipgloss.NewStyle().Width(width).Render(h + "\n" + body)
+}
+
+func (m diffOperationPageModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m diffOperationPageModel) Update(msg tea.Msg) (diffOperationPageModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.delegateKeys.back):
+			return m, goBack
+		}
+	case selectDiffOperationMsg:
+		m.reset()
+		m.operation = m.result.FindOperation(msg.key)
+		m.updateContent()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+func (m diffOperationPageModel) View() string {
+	return m.viewport.View()
+}
@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lusingander/topi/internal/diff"
+)
+
+type diffPathsPageModel struct {
+	result        *diff.Result
+	list          list.Model
+	delegateKeys  diffPathsPageDelegateKeyMap
+	width, height int
+}
+
+func newDiffPathsPageModel(result *diff.Result) diffPathsPageModel {
+	m := diffPathsPageModel{
+		result: result,
+	}
+	m.delegateKeys = newDiffPathsPageDelegateKeyMap()
+	delegate := newDiffPathsPageListDelegate()
+	items := make([]list.Item, len(result.Operations))
+	for i, op := range result.Operations {
+		items[i] = diffPathsPageListItem{op}
+	}
+	m.list = list.New(items, delegate, 0, 0)
+	m.list.SetShowTitle(false)
+	m.list.SetShowHelp(false)
+	m.list.SetShowStatusBar(false)
+	m.list.SetShowFilter(false)
+	m.list.SetShowPagination(false)
+	m.list.KeyMap.Quit.Unbind()
+	return m
+}
+
+type diffPathsPageDelegateKeyMap struct {
+	enter key.Binding
+}
+
+func newDiffPathsPageDelegateKeyMap() diffPathsPageDelegateKeyMap {
+	return diffPathsPageDelegateKeyMap{
+		enter: keys.selectItem.binding("select"),
+	}
+}
+
+func (m *diffPathsPageModel) SetSize(w, h int) {
+	m.width, m.height = w, h
+	m.list.SetSize(w, h)
+}
+
+func (m diffPathsPageModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m diffPathsPageModel) Update(msg tea.Msg) (diffPathsPageModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.delegateKeys.enter):
+			if m.list.FilterState() != list.Filtering && len(m.list.VisibleItems()) > 0 {
+				op := m.list.SelectedItem().(diffPathsPageListItem).op
+				return m, selectDiffOperation(op.Key())
+			}
+		}
+	}
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m diffPathsPageModel) View() string {
+	if len(m.result.Operations) == 0 {
+		return listNormalTitleStyle.Render("No changes")
+	}
+	return m.list.View()
+}
+
+func (m diffPathsPageModel) statusbarInfoString() string {
+	return listStatusbarInfoString(m.list)
+}
+
+func (m diffPathsPageModel) statusMessageString() string {
+	if s := listStatusMessageString(m.list); s != "" {
+		return s
+	}
+	breaking := 0
+	for _, op := range m.result.Operations {
+		if op.Breaking() {
+			breaking++
+		}
+	}
+	return fmt.Sprintf("%d changed operations, %d with breaking changes", len(m.result.Operations), breaking)
+}
@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lusingander/topi/internal/diff"
+)
+
+var (
+	diffAddedColorStyle     lipgloss.Style
+	diffRemovedColorStyle   lipgloss.Style
+	diffChangedColorStyle   lipgloss.Style
+	diffBreakingMarkerStyle lipgloss.Style
+)
+
+func loadDiffPathsPageStyles(t *theme) {
+	diffAddedColorStyle = lipgloss.NewStyle().
+		Foreground(t.Success)
+
+	diffRemovedColorStyle = lipgloss.NewStyle().
+		Foreground(t.Error)
+
+	diffChangedColorStyle = lipgloss.NewStyle().
+		Foreground(t.Warning)
+
+	diffBreakingMarkerStyle = lipgloss.NewStyle().
+		Foreground(t.Alert).
+		Bold(true).
+		Margin(0, 0, 0, 2)
+}
+
+func styledChangeType(t diff.ChangeType, s string) string {
+	switch t {
+	case diff.Added:
+		return diffAddedColorStyle.Render(s)
+	case diff.Removed:
+		return diffRemovedColorStyle.Render(s)
+	case diff.Changed:
+		return diffChangedColorStyle.Render(s)
+	default:
+		return s
+	}
+}
+
+type diffPathsPageListItem struct {
+	op *diff.OperationDiff
+}
+
+var _ list.Item = (*diffPathsPageListItem)(nil)
+
+func (i diffPathsPageListItem) FilterValue() string {
+	return i.op.UriPath
+}
+
+func (i diffPathsPageListItem) styledTitle(selected bool) string {
+	path := i.op.New
+	if path == nil {
+		path = i.op.Old
+	}
+	title := pathPageListItem{path}.styledTitle(selected)
+	title = fmt.Sprintf("%s %s", styledChangeType(i.op.Type, i.op.Type.Symbol()), title)
+	if i.op.Breaking() {
+		title += diffBreakingMarkerStyle.Render("Breaking")
+	}
+	return title
+}
+
+func (i diffPathsPageListItem) styledDesc(selected bool, width int) string {
+	var desc string
+	switch i.op.Type {
+	case diff.Changed:
+		desc = fmt.Sprintf("%d changes", len(i.op.Changes))
+	default:
+		desc = i.op.Type.String()
+	}
+	desc = truncateWithTail(desc, uint(width))
+	if selected {
+		desc = listSelectedDescColorStyle.Render(desc)
+	} else {
+		desc = listNormalDescColorStyle.Render(desc)
+	}
+	return desc
+}
+
+type diffPathsPageListDelegate struct{}
+
+var _ list.ItemDelegate = (*diffPathsPageListDelegate)(nil)
+
+func newDiffPathsPageListDelegate() diffPathsPageListDelegate {
+	return diffPathsPageListDelegate{}
+}
+
+func (d diffPathsPageListDelegate) Height() int {
+	return 2
+}
+
+func (d diffPathsPageListDelegate) Spacing() int {
+	return 1
+}
+
+func (d diffPathsPageListDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
+	return nil
+}
+
+func (d diffPathsPageListDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	i := item.(diffPathsPageListItem)
+	selected := index == m.Index()
+
+	width := m.Width() - listNormalTitleStyle.GetPaddingLeft() - listNormalTitleStyle.GetPaddingRight()
+
+	title := i.styledTitle(selected)
+	desc := i.styledDesc(selected, width)
+
+	if selected {
+		title = listSelectedItemStyle.Render(title)
+		desc = listSelectedItemStyle.Render(desc)
+	} else {
+		title = listNormalItemStyle.Render(title)
+		desc = listNormalItemStyle.Render(desc)
+	}
+
+	fmt.Fprintf(w, "%s\n%s", title, desc)
+}
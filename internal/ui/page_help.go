@@ -20,6 +20,8 @@ const helpPageContent = `# Help page
 
 ## Keybindings
 
+the keys below are the defaults, and some of them can be changed in the config file (~/.config/topi/config.yaml)
+
 ### Common
 
 common keybindings for all pages
@@ -27,8 +29,13 @@ common keybindings for all pages
 |Key|Description|
 |-|-|
 |Backspace|back to perv page|
+|Alt+Right|forward to next page|
+|Ctrl+p|jump to older operation|
+|Ctrl+n|jump to newer operation|
 |Ctrl+c|quit|
 |?|show help page (this page)|
+|Ctrl+o|switch spec (if multiple specs are loaded)|
+|Ctrl+t|switch theme (dark / light)|
 
 ### List page
 
@@ -45,6 +52,7 @@ keybindings for list-syle pages
 |/|Enter filtering mode|
 |Enter|(default) select item, (filtering) apply filter|
 |Esc|(filtering) cancel filter, (filter applied) remove filter|
+|m|(path list) toggle bookmark|
 
 ### Document page
 
@@ -58,8 +66,14 @@ keybindings for document pages
 |b|page up|
 |d|half page down|
 |u|half page up|
-|Tab|select link|
+|Tab|select link, (security scheme page) select operation|
 |x|open selecting link|
+|Enter|(security scheme page) open selecting operation|
+|a|(security scheme page) acquire token|
+|m|(operation page) toggle bookmark|
+|e|(operation page) open the source in $EDITOR|
+|r|(operation / info page) toggle raw source view|
+|t|(raw source view) toggle dereferenced / as written|
 
 specific to the credits page
 
@@ -87,10 +101,7 @@ type helpPageDelegateKeyMap struct {
 
 func newHelpPageDelegateKeyMap() helpPageDelegateKeyMap {
 	return helpPageDelegateKeyMap{
-		back: key.NewBinding(
-			key.WithKeys("backspace", "ctrl+h"),
-			key.WithHelp("backspace", "back"),
-		),
+		back: keys.back.binding("back"),
 	}
 }
 
@@ -55,14 +55,8 @@ type helpMenuPageDelegateKeyMap struct {
 
 func newHelpMenuPageDelegateKeyMap() helpMenuPageDelegateKeyMap {
 	return helpMenuPageDelegateKeyMap{
-		back: key.NewBinding(
-			key.WithKeys("backspace", "ctrl+h"),
-			key.WithHelp("backspace", "back"),
-		),
-		enter: key.NewBinding(
-			key.WithKeys("enter"),
-			key.WithHelp("enter", "select"),
-		),
+		back:  keys.back.binding("back"),
+		enter: keys.selectItem.binding("select"),
 	}
 }
 
@@ -7,74 +7,97 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/lusingander/topi/internal/topi"
 )
 
 var (
+	infoPageTitleStyle                             lipgloss.Style
+	infoPageVersionStyle                           lipgloss.Style
+	infoPageTitleBarStyle                          lipgloss.Style
+	infoPageDescriptionStyle                       lipgloss.Style
+	infoPageUrlStyle                               lipgloss.Style
+	infoPageSelectedUrlStyle                       lipgloss.Style
+	infoPageSectionHeaderStyle                     lipgloss.Style
+	infoPageSectionSubHeaderStyle                  lipgloss.Style
+	infoPageAuthenticationItemStyle                lipgloss.Style
+	infoPageAuthenticationItemDescriptionStyle     lipgloss.Style
+	infoPageAuthenticationItemKeyColorStyle        lipgloss.Style
+	infoPageAuthenticationItemValueColorStyle      lipgloss.Style
+	infoPageAuthenticationOAuthFlowStyle           lipgloss.Style
+	infoPageAuthenticationOAuthScopesStyle         lipgloss.Style
+	infoPageAuthenticationOAuthScopeNameColorStyle lipgloss.Style
+	infoPageAuthenticationOAuthScopeDescColorStyle lipgloss.Style
+	infoPageItemStyle                              lipgloss.Style
+)
+
+var (
+	infoPageSeparator string
+)
+
+func loadInfoPageStyles(t *theme) {
 	infoPageTitleStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("70")).
-				Bold(true)
+		Foreground(t.Primary).
+		Bold(true)
 
 	infoPageVersionStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("70"))
+		Foreground(t.Primary)
 
 	infoPageTitleBarStyle = lipgloss.NewStyle().
-				Padding(0, 2, 1)
+		Padding(0, 2, 1)
 
 	infoPageDescriptionStyle = lipgloss.NewStyle().
-					Padding(1, 2)
+		Padding(1, 2)
 
 	infoPageUrlStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("33")).
-				Underline(true)
+		Foreground(t.Link).
+		Underline(true)
 
 	infoPageSelectedUrlStyle = lipgloss.NewStyle().
-					Background(lipgloss.Color("250")).
-					Foreground(lipgloss.Color("56"))
+		Background(t.SelectedLinkBg).
+		Foreground(t.SelectedLinkFg)
 
 	infoPageSectionHeaderStyle = lipgloss.NewStyle().
-					Foreground(lipgloss.Color("70")).
-					Underline(true)
+		Foreground(t.Primary).
+		Underline(true)
 
 	infoPageSectionSubHeaderStyle = infoPageSectionHeaderStyle.Copy().
-					Margin(0, 0, 0, 1)
+		Margin(0, 0, 0, 1)
+
+	infoPageItemStyle = lipgloss.NewStyle().
+		Padding(1, 2)
 
 	infoPageAuthenticationItemStyle = infoPageItemStyle.Copy().
-					Margin(0, 0, 0, 2)
+		Margin(0, 0, 0, 2)
 
 	infoPageAuthenticationItemDescriptionStyle = infoPageAuthenticationItemStyle.Copy().
-							PaddingBottom(0)
+		PaddingBottom(0)
 
 	infoPageAuthenticationItemKeyColorStyle = lipgloss.NewStyle().
-						Foreground(lipgloss.Color("143"))
+		Foreground(t.Key)
 
 	infoPageAuthenticationItemValueColorStyle = lipgloss.NewStyle().
-							Foreground(lipgloss.Color("167"))
+		Foreground(t.Value)
 
 	infoPageAuthenticationOAuthFlowStyle = lipgloss.NewStyle().
-						Foreground(lipgloss.Color("70"))
+		Foreground(t.Primary)
 
 	infoPageAuthenticationOAuthScopesStyle = lipgloss.NewStyle().
-						MarginLeft(2)
+		MarginLeft(2)
 
 	infoPageAuthenticationOAuthScopeNameColorStyle = lipgloss.NewStyle().
-							Foreground(lipgloss.Color("167")).
-							Underline(true)
+		Foreground(t.Value).
+		Underline(true)
 
 	infoPageAuthenticationOAuthScopeDescColorStyle = lipgloss.NewStyle().
-							Foreground(lipgloss.Color("246"))
+		Foreground(t.Muted)
 
-	infoPageItemStyle = lipgloss.NewStyle().
-				Padding(1, 2)
-)
-
-var (
 	infoPageSeparator = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240")).
+		Foreground(t.Separator).
 		Padding(1, 2).
 		Render("----------")
-)
+}
 
 type infoPageSelectableItems int
 
@@ -94,6 +117,7 @@ type infoPageModel struct {
 	width, height int
 
 	selected infoPageSelectableItems
+	raw      rawSourceView
 }
 
 func newInfoPageModel(doc *topi.Document) infoPageModel {
@@ -110,26 +134,18 @@ type infoPageDelegateKeyMap struct {
 	tab         key.Binding
 	shiftTab    key.Binding
 	openBrowser key.Binding
+	raw         key.Binding
+	toggle      key.Binding
 }
 
 func newInfoPageDelegateKeyMap() infoPageDelegateKeyMap {
 	return infoPageDelegateKeyMap{
-		back: key.NewBinding(
-			key.WithKeys("backspace", "ctrl+h"),
-			key.WithHelp("backspace", "back"),
-		),
-		tab: key.NewBinding(
-			key.WithKeys("tab"),
-			key.WithHelp("tab", "select next item"),
-		),
-		shiftTab: key.NewBinding(
-			key.WithKeys("shift+tab"),
-			key.WithHelp("shift+tab", "select prev item"),
-		),
-		openBrowser: key.NewBinding(
-			key.WithKeys("x"),
-			key.WithHelp("x", "open in browser"),
-		),
+		back:        keys.back.binding("back"),
+		tab:         keys.nextItem.binding("select next item"),
+		shiftTab:    keys.prevItem.binding("select prev item"),
+		openBrowser: keys.openBrowser.binding("open in browser"),
+		raw:         keys.raw.binding("raw source"),
+		toggle:      keys.toggle.binding("toggle dereferenced"),
 	}
 }
 
@@ -141,11 +157,16 @@ func (m *infoPageModel) SetSize(w, h int) {
 
 func (m *infoPageModel) reset() {
 	m.selected = infoPageSelectableNotSelected
+	m.raw = rawSourceView{}
 	m.viewport.GotoTop()
 }
 
 func (m *infoPageModel) updateContent() {
 	info := m.doc.Info
+	if m.raw.enabled {
+		m.viewport.SetContent(styledRawSource(m.doc, info.Source, m.raw.deref, m.width))
+		return
+	}
 	r, _ := markdownRenderer(m.width - 10)
 
 	var content strings.Builder
@@ -229,6 +250,33 @@ func (m *infoPageModel) updateContent() {
 		content.WriteString(infoPageItemStyle.Render(url))
 	}
 
+	if server := m.doc.DefaultServer(defaultServer); server != nil {
+		h := infoPageSectionHeaderStyle.Render("Servers")
+		content.WriteString(infoPageItemStyle.Render(h))
+
+		servers := m.doc.Servers
+		if !containsServer(server, servers) {
+			servers = append([]*topi.Server{server}, servers...)
+		}
+		ss := make([]string, len(servers))
+		for i, s := range servers {
+			ss[i] = infoPageAuthenticationItemKeyColorStyle.Render(s.Url)
+			if s.Description != "" {
+				ss[i] += " - " + infoPageAuthenticationItemValueColorStyle.Render(s.Description)
+			}
+			if s == server {
+				ss[i] += infoPageVersionStyle.Render(" (default)")
+			}
+		}
+		content.WriteString(infoPageAuthenticationItemStyle.Render(strings.Join(ss, "\n")))
+	}
+
+	if len(m.doc.Security) > 0 {
+		h := infoPageSectionHeaderStyle.Render("Security Requirements")
+		content.WriteString(infoPageItemStyle.Render(h))
+		content.WriteString(infoPageAuthenticationItemStyle.Render(styledSecurityRequirements(m.doc.Security)))
+	}
+
 	if m.doc.Components != nil {
 		schemes := m.doc.Components.SecuritySchemes
 		if len(schemes) > 0 {
@@ -239,111 +287,24 @@ func (m *infoPageModel) updateContent() {
 				h := infoPageSectionSubHeaderStyle.Render(fmt.Sprintf("%s (%s)", scheme.Key, scheme.TypeStr()))
 				content.WriteString(infoPageItemStyle.Render(h))
 
-				if scheme.Description != "" {
-					desc, _ := r.Render(scheme.Description)
-					desc = infoPageAuthenticationItemDescriptionStyle.Render(desc)
-					content.WriteString(desc)
-				}
-
-				switch scheme.Type {
-				case "apiKey":
-					nameKey := infoPageAuthenticationItemKeyColorStyle.Render("Parameter name:")
-					nameValue := infoPageAuthenticationItemValueColorStyle.Render(scheme.Name)
-					inKey := infoPageAuthenticationItemKeyColorStyle.Render("Parameter in:")
-					inValue := infoPageAuthenticationItemValueColorStyle.Render(scheme.In)
-					values := fmt.Sprintf("%s %s\n%s %s", nameKey, nameValue, inKey, inValue)
-					content.WriteString(infoPageAuthenticationItemStyle.Render(values))
-				case "http":
-					schemeKey := infoPageAuthenticationItemKeyColorStyle.Render("HTTP Authorization Scheme:")
-					schemeValue := infoPageAuthenticationItemValueColorStyle.Render(scheme.Scheme)
-					values := fmt.Sprintf("%s %s", schemeKey, schemeValue)
-					if scheme.BearerFormat != "" {
-						formatKey := infoPageAuthenticationItemKeyColorStyle.Render("Bearer format:")
-						formatValue := infoPageAuthenticationItemValueColorStyle.Render(scheme.BearerFormat)
-						values += fmt.Sprintf("\n%s %s", formatKey, formatValue)
-					}
-					content.WriteString(infoPageAuthenticationItemStyle.Render(values))
-				case "oauth2":
-					if scheme.OAuthFlows.AuthorizatonCodeFlow != nil {
-						f := scheme.OAuthFlows.AuthorizatonCodeFlow
-						flow := infoPageAuthenticationOAuthFlowStyle.Render("[Authorization Code Flow]")
-						content.WriteString(infoPageAuthenticationItemStyle.Render(flow))
-						authKey := infoPageAuthenticationItemKeyColorStyle.Render("Authorization URL:")
-						authValue := infoPageAuthenticationItemValueColorStyle.Render(f.AuthorizationURL)
-						tokenKey := infoPageAuthenticationItemKeyColorStyle.Render("Token URL:")
-						tokenValue := infoPageAuthenticationItemValueColorStyle.Render(f.TokenURL)
-						values := fmt.Sprintf("%s %s\n%s %s", authKey, authValue, tokenKey, tokenValue)
-						if f.RefreshURL != "" {
-							refKey := infoPageAuthenticationItemKeyColorStyle.Render("Reflesh URL:")
-							refValue := infoPageAuthenticationItemValueColorStyle.Render(f.RefreshURL)
-							values += fmt.Sprintf("\n%s %s", refKey, refValue)
-						}
-						scopesKey := infoPageAuthenticationItemKeyColorStyle.Render("Scopes:")
-						scopesValue := infoPageAuthenticationOAuthScopesStyle.Render(styledOAuthScopes(f.Scopes))
-						values += fmt.Sprintf("\n%s\n%s", scopesKey, scopesValue)
-						content.WriteString(infoPageAuthenticationItemStyle.Render(values))
-					}
-					if scheme.OAuthFlows.ImplicitFlow != nil {
-						f := scheme.OAuthFlows.ImplicitFlow
-						flow := infoPageAuthenticationOAuthFlowStyle.Render("[Implicit Flow]")
-						content.WriteString(infoPageAuthenticationItemStyle.Render(flow))
-						authKey := infoPageAuthenticationItemKeyColorStyle.Render("Authorization URL:")
-						authValue := infoPageAuthenticationItemValueColorStyle.Render(f.AuthorizationURL)
-						values := fmt.Sprintf("%s %s", authKey, authValue)
-						if f.RefreshURL != "" {
-							refKey := infoPageAuthenticationItemKeyColorStyle.Render("Reflesh URL:")
-							refValue := infoPageAuthenticationItemValueColorStyle.Render(f.RefreshURL)
-							values += fmt.Sprintf("\n%s %s", refKey, refValue)
-						}
-						scopesKey := infoPageAuthenticationItemKeyColorStyle.Render("Scopes:")
-						scopesValue := infoPageAuthenticationOAuthScopesStyle.Render(styledOAuthScopes(f.Scopes))
-						values += fmt.Sprintf("\n%s\n%s", scopesKey, scopesValue)
-						content.WriteString(infoPageAuthenticationItemStyle.Render(values))
-					}
-					if scheme.OAuthFlows.ResourceOwnerPasswordCredentialsFlow != nil {
-						f := scheme.OAuthFlows.ResourceOwnerPasswordCredentialsFlow
-						flow := infoPageAuthenticationOAuthFlowStyle.Render("[Resource Owner Password Credentials Flow]")
-						content.WriteString(infoPageAuthenticationItemStyle.Render(flow))
-						tokenKey := infoPageAuthenticationItemKeyColorStyle.Render("Token URL:")
-						tokenValue := infoPageAuthenticationItemValueColorStyle.Render(f.TokenURL)
-						values := fmt.Sprintf("%s %s", tokenKey, tokenValue)
-						if f.RefreshURL != "" {
-							refKey := infoPageAuthenticationItemKeyColorStyle.Render("Reflesh URL:")
-							refValue := infoPageAuthenticationItemValueColorStyle.Render(f.RefreshURL)
-							values += fmt.Sprintf("\n%s %s", refKey, refValue)
-						}
-						scopesKey := infoPageAuthenticationItemKeyColorStyle.Render("Scopes:")
-						scopesValue := infoPageAuthenticationOAuthScopesStyle.Render(styledOAuthScopes(f.Scopes))
-						values += fmt.Sprintf("\n%s\n%s", scopesKey, scopesValue)
-						content.WriteString(infoPageAuthenticationItemStyle.Render(values))
-					}
-					if scheme.OAuthFlows.ClientCredentialsFlow != nil {
-						f := scheme.OAuthFlows.ClientCredentialsFlow
-						flow := infoPageAuthenticationOAuthFlowStyle.Render("[Client Credentials Flow]")
-						content.WriteString(infoPageAuthenticationItemStyle.Render(flow))
-						tokenKey := infoPageAuthenticationItemKeyColorStyle.Render("Token URL:")
-						tokenValue := infoPageAuthenticationItemValueColorStyle.Render(f.TokenURL)
-						values := fmt.Sprintf("%s %s", tokenKey, tokenValue)
-						if f.RefreshURL != "" {
-							refKey := infoPageAuthenticationItemKeyColorStyle.Render("Reflesh URL:")
-							refValue := infoPageAuthenticationItemValueColorStyle.Render(f.RefreshURL)
-							values += fmt.Sprintf("\n%s %s", refKey, refValue)
-						}
-						scopesKey := infoPageAuthenticationItemKeyColorStyle.Render("Scopes:")
-						scopesValue := infoPageAuthenticationOAuthScopesStyle.Render(styledOAuthScopes(f.Scopes))
-						values += fmt.Sprintf("\n%s\n%s", scopesKey, scopesValue)
-						content.WriteString(infoPageAuthenticationItemStyle.Render(values))
-					}
-				case "openIdConnect":
-					urlKey := infoPageAuthenticationItemKeyColorStyle.Render("Connect URL:")
-					urlValue := infoPageAuthenticationItemValueColorStyle.Render(scheme.OpenIdConnectUrl)
-					values := fmt.Sprintf("%s %s", urlKey, urlValue)
-					content.WriteString(infoPageAuthenticationItemStyle.Render(values))
-				}
+				content.WriteString(styledSecuritySchemeDetails(scheme, r))
 			}
 		}
 	}
 
+	if len(m.doc.ValidationErrors) > 0 {
+		h := infoPageSectionHeaderStyle.Render(fmt.Sprintf("Validation (%d errors)", len(m.doc.ValidationErrors)))
+		content.WriteString(infoPageItemStyle.Render(h))
+
+		errs := make([]string, len(m.doc.ValidationErrors))
+		for i, e := range m.doc.ValidationErrors {
+			loc := infoPageAuthenticationItemKeyColorStyle.Render(e.Location())
+			msg := infoPageAuthenticationItemValueColorStyle.Render(e.Message)
+			errs[i] = fmt.Sprintf("%s\n  %s", loc, msg)
+		}
+		content.WriteString(infoPageAuthenticationItemStyle.Render(strings.Join(errs, "\n")))
+	}
+
 	content.WriteString(infoPageSeparator)
 
 	openAPIVersion := infoPageVersionStyle.Render(fmt.Sprintf("OpenAPI Version: %s", info.OpenAPIVersion))
@@ -352,6 +313,125 @@ func (m *infoPageModel) updateContent() {
 	m.viewport.SetContent(content.String())
 }
 
+// styledSecuritySchemeDetails returns the description and the type specific fields of the security scheme.
+func styledSecuritySchemeDetails(scheme *topi.SecurityScheme, r *glamour.TermRenderer) string {
+	var content strings.Builder
+
+	if scheme.Description != "" {
+		desc, _ := r.Render(scheme.Description)
+		desc = infoPageAuthenticationItemDescriptionStyle.Render(desc)
+		content.WriteString(desc)
+	}
+
+	switch scheme.Type {
+	case "apiKey":
+		nameKey := infoPageAuthenticationItemKeyColorStyle.Render("Parameter name:")
+		nameValue := infoPageAuthenticationItemValueColorStyle.Render(scheme.Name)
+		inKey := infoPageAuthenticationItemKeyColorStyle.Render("Parameter in:")
+		inValue := infoPageAuthenticationItemValueColorStyle.Render(scheme.In)
+		values := fmt.Sprintf("%s %s\n%s %s", nameKey, nameValue, inKey, inValue)
+		content.WriteString(infoPageAuthenticationItemStyle.Render(values))
+	case "http":
+		schemeKey := infoPageAuthenticationItemKeyColorStyle.Render("HTTP Authorization Scheme:")
+		schemeValue := infoPageAuthenticationItemValueColorStyle.Render(scheme.Scheme)
+		values := fmt.Sprintf("%s %s", schemeKey, schemeValue)
+		if scheme.BearerFormat != "" {
+			formatKey := infoPageAuthenticationItemKeyColorStyle.Render("Bearer format:")
+			formatValue := infoPageAuthenticationItemValueColorStyle.Render(scheme.BearerFormat)
+			values += fmt.Sprintf("\n%s %s", formatKey, formatValue)
+		}
+		content.WriteString(infoPageAuthenticationItemStyle.Render(values))
+	case "oauth2":
+		if scheme.OAuthFlows != nil {
+			if scheme.OAuthFlows.AuthorizatonCodeFlow != nil {
+				f := scheme.OAuthFlows.AuthorizatonCodeFlow
+				flow := infoPageAuthenticationOAuthFlowStyle.Render("[Authorization Code Flow]")
+				content.WriteString(infoPageAuthenticationItemStyle.Render(flow))
+				authKey := infoPageAuthenticationItemKeyColorStyle.Render("Authorization URL:")
+				authValue := infoPageAuthenticationItemValueColorStyle.Render(f.AuthorizationURL)
+				tokenKey := infoPageAuthenticationItemKeyColorStyle.Render("Token URL:")
+				tokenValue := infoPageAuthenticationItemValueColorStyle.Render(f.TokenURL)
+				values := fmt.Sprintf("%s %s\n%s %s", authKey, authValue, tokenKey, tokenValue)
+				if f.RefreshURL != "" {
+					refKey := infoPageAuthenticationItemKeyColorStyle.Render("Reflesh URL:")
+					refValue := infoPageAuthenticationItemValueColorStyle.Render(f.RefreshURL)
+					values += fmt.Sprintf("\n%s %s", refKey, refValue)
+				}
+				scopesKey := infoPageAuthenticationItemKeyColorStyle.Render("Scopes:")
+				scopesValue := infoPageAuthenticationOAuthScopesStyle.Render(styledOAuthScopes(f.Scopes))
+				values += fmt.Sprintf("\n%s\n%s", scopesKey, scopesValue)
+				content.WriteString(infoPageAuthenticationItemStyle.Render(values))
+			}
+			if scheme.OAuthFlows.ImplicitFlow != nil {
+				f := scheme.OAuthFlows.ImplicitFlow
+				flow := infoPageAuthenticationOAuthFlowStyle.Render("[Implicit Flow]")
+				content.WriteString(infoPageAuthenticationItemStyle.Render(flow))
+				authKey := infoPageAuthenticationItemKeyColorStyle.Render("Authorization URL:")
+				authValue := infoPageAuthenticationItemValueColorStyle.Render(f.AuthorizationURL)
+				values := fmt.Sprintf("%s %s", authKey, authValue)
+				if f.RefreshURL != "" {
+					refKey := infoPageAuthenticationItemKeyColorStyle.Render("Reflesh URL:")
+					refValue := infoPageAuthenticationItemValueColorStyle.Render(f.RefreshURL)
+					values += fmt.Sprintf("\n%s %s", refKey, refValue)
+				}
+				scopesKey := infoPageAuthenticationItemKeyColorStyle.Render("Scopes:")
+				scopesValue := infoPageAuthenticationOAuthScopesStyle.Render(styledOAuthScopes(f.Scopes))
+				values += fmt.Sprintf("\n%s\n%s", scopesKey, scopesValue)
+				content.WriteString(infoPageAuthenticationItemStyle.Render(values))
+			}
+			if scheme.OAuthFlows.ResourceOwnerPasswordCredentialsFlow != nil {
+				f := scheme.OAuthFlows.ResourceOwnerPasswordCredentialsFlow
+				flow := infoPageAuthenticationOAuthFlowStyle.Render("[Resource Owner Password Credentials Flow]")
+				content.WriteString(infoPageAuthenticationItemStyle.Render(flow))
+				tokenKey := infoPageAuthenticationItemKeyColorStyle.Render("Token URL:")
+				tokenValue := infoPageAuthenticationItemValueColorStyle.Render(f.TokenURL)
+				values := fmt.Sprintf("%s %s", tokenKey, tokenValue)
+				if f.RefreshURL != "" {
+					refKey := infoPageAuthenticationItemKeyColorStyle.Render("Reflesh URL:")
+					refValue := infoPageAuthenticationItemValueColorStyle.Render(f.RefreshURL)
+					values += fmt.Sprintf("\n%s %s", refKey, refValue)
+				}
+				scopesKey := infoPageAuthenticationItemKeyColorStyle.Render("Scopes:")
+				scopesValue := infoPageAuthenticationOAuthScopesStyle.Render(styledOAuthScopes(f.Scopes))
+				values += fmt.Sprintf("\n%s\n%s", scopesKey, scopesValue)
+				content.WriteString(infoPageAuthenticationItemStyle.Render(values))
+			}
+			if scheme.OAuthFlows.ClientCredentialsFlow != nil {
+				f := scheme.OAuthFlows.ClientCredentialsFlow
+				flow := infoPageAuthenticationOAuthFlowStyle.Render("[Client Credentials Flow]")
+				content.WriteString(infoPageAuthenticationItemStyle.Render(flow))
+				tokenKey := infoPageAuthenticationItemKeyColorStyle.Render("Token URL:")
+				tokenValue := infoPageAuthenticationItemValueColorStyle.Render(f.TokenURL)
+				values := fmt.Sprintf("%s %s", tokenKey, tokenValue)
+				if f.RefreshURL != "" {
+					refKey := infoPageAuthenticationItemKeyColorStyle.Render("Reflesh URL:")
+					refValue := infoPageAuthenticationItemValueColorStyle.Render(f.RefreshURL)
+					values += fmt.Sprintf("\n%s %s", refKey, refValue)
+				}
+				scopesKey := infoPageAuthenticationItemKeyColorStyle.Render("Scopes:")
+				scopesValue := infoPageAuthenticationOAuthScopesStyle.Render(styledOAuthScopes(f.Scopes))
+				values += fmt.Sprintf("\n%s\n%s", scopesKey, scopesValue)
+				content.WriteString(infoPageAuthenticationItemStyle.Render(values))
+			}
+		}
+	case "openIdConnect":
+		urlKey := infoPageAuthenticationItemKeyColorStyle.Render("Connect URL:")
+		urlValue := infoPageAuthenticationItemValueColorStyle.Render(scheme.OpenIdConnectUrl)
+		values := fmt.Sprintf("%s %s", urlKey, urlValue)
+		content.WriteString(infoPageAuthenticationItemStyle.Render(values))
+	}
+	return content.String()
+}
+
+func containsServer(server *topi.Server, servers []*topi.Server) bool {
+	for _, s := range servers {
+		if s == server {
+			return true
+		}
+	}
+	return false
+}
+
 func styledOAuthScopes(scopes []*topi.Scope) string {
 	ss := make([]string, len(scopes))
 	for i, scope := range scopes {
@@ -416,17 +496,29 @@ func (m infoPageModel) Update(msg tea.Msg) (infoPageModel, tea.Cmd) {
 		switch {
 		case key.Matches(msg, m.delegateKeys.back):
 			return m, goBack
-		case key.Matches(msg, m.delegateKeys.tab):
+		case key.Matches(msg, m.delegateKeys.tab) && !m.raw.enabled:
 			m.selectItem(false)
 			m.updateContent()
 			return m, nil
-		case key.Matches(msg, m.delegateKeys.shiftTab):
+		case key.Matches(msg, m.delegateKeys.shiftTab) && !m.raw.enabled:
 			m.selectItem(true)
 			m.updateContent()
 			return m, nil
-		case key.Matches(msg, m.delegateKeys.openBrowser):
+		case key.Matches(msg, m.delegateKeys.openBrowser) && !m.raw.enabled:
 			m.openInBrowser() // todo: handle error
 			return m, nil
+		case key.Matches(msg, m.delegateKeys.raw):
+			m.raw.enabled = !m.raw.enabled
+			m.selected = infoPageSelectableNotSelected
+			m.viewport.GotoTop()
+			m.updateContent()
+			return m, nil
+		case key.Matches(msg, m.delegateKeys.toggle):
+			if m.raw.enabled {
+				m.raw.deref = !m.raw.deref
+				m.updateContent()
+				return m, nil
+			}
 		}
 	case selectInfoMenuMsg:
 		m.reset()
@@ -438,6 +530,10 @@ func (m infoPageModel) Update(msg tea.Msg) (infoPageModel, tea.Cmd) {
 	return m, cmd
 }
 
+func (m infoPageModel) statusMessageString() string {
+	return m.raw.statusMessageString()
+}
+
 func (m infoPageModel) View() string {
 	return m.viewport.View()
 }
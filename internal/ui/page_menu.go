@@ -7,10 +7,15 @@ import (
 )
 
 const (
-	menuPageInfoMenu  = "Info"
-	menuPageTagsMenu  = "Tags"
-	menuPagePathsMenu = "Paths"
-	menuPageHelpMenu  = "Help"
+	menuPageInfoMenu      = "Info"
+	menuPageTagsMenu      = "Tags"
+	menuPagePathsMenu     = "Paths"
+	menuPageSecurityMenu  = "Security"
+	menuPageBookmarksMenu = "Bookmarks"
+	menuPageRecentMenu    = "Recent"
+	menuPageSearchMenu    = "Search"
+	menuPageProblemsMenu  = "Problems"
+	menuPageHelpMenu      = "Help"
 )
 
 var menuPageItems = []list.Item{
@@ -26,6 +31,26 @@ var menuPageItems = []list.Item{
 		title:       menuPagePathsMenu,
 		description: "Show all paths",
 	},
+	menuPageListItem{
+		title:       menuPageSecurityMenu,
+		description: "Show security schemes",
+	},
+	menuPageListItem{
+		title:       menuPageBookmarksMenu,
+		description: "Show bookmarked paths",
+	},
+	menuPageListItem{
+		title:       menuPageRecentMenu,
+		description: "Show recently viewed paths",
+	},
+	menuPageListItem{
+		title:       menuPageSearchMenu,
+		description: "Search paths in all specs",
+	},
+	menuPageListItem{
+		title:       menuPageProblemsMenu,
+		description: "Show problems of the spec",
+	},
 	menuPageListItem{
 		title:       menuPageHelpMenu,
 		description: "Show help menus",
@@ -38,11 +63,18 @@ type menuPageModel struct {
 	width, height int
 }
 
-func newMenuPageModel() menuPageModel {
+func newMenuPageModel(multiSpec bool) menuPageModel {
 	m := menuPageModel{}
 	m.delegateKeys = newMenuPageDelegateKeyMap()
 	delegate := newMenuPageListDelegate()
-	m.list = list.New(menuPageItems, delegate, 0, 0)
+	items := make([]list.Item, 0)
+	for _, item := range menuPageItems {
+		if item.(menuPageListItem).title == menuPageSearchMenu && !multiSpec {
+			continue
+		}
+		items = append(items, item)
+	}
+	m.list = list.New(items, delegate, 0, 0)
 	m.list.SetShowTitle(false)
 	m.list.SetShowHelp(false)
 	m.list.SetShowStatusBar(false)
@@ -55,14 +87,13 @@ func newMenuPageModel() menuPageModel {
 
 type menuPageDelegateKeyMap struct {
 	enter key.Binding
+	back  key.Binding
 }
 
 func newMenuPageDelegateKeyMap() menuPageDelegateKeyMap {
 	return menuPageDelegateKeyMap{
-		enter: key.NewBinding(
-			key.WithKeys("enter"),
-			key.WithHelp("enter", "select"),
-		),
+		enter: keys.selectItem.binding("select"),
+		back:  keys.back.binding("back"),
 	}
 }
 
@@ -88,10 +119,22 @@ func (m menuPageModel) Update(msg tea.Msg) (menuPageModel, tea.Cmd) {
 				return m, selectTagMenu
 			case menuPagePathsMenu:
 				return m, selectPathMenu
+			case menuPageSecurityMenu:
+				return m, selectSecurityMenu
+			case menuPageBookmarksMenu:
+				return m, selectBookmarksMenu
+			case menuPageRecentMenu:
+				return m, selectRecentMenu
+			case menuPageSearchMenu:
+				return m, selectSearchMenu
+			case menuPageProblemsMenu:
+				return m, selectProblemsMenu
 			case menuPageHelpMenu:
 				return m, selectHelpMenu
 			}
 			return m, nil
+		case key.Matches(msg, m.delegateKeys.back):
+			return m, goBack
 		}
 	}
 	var cmd tea.Cmd
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
@@ -9,107 +10,162 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/lusingander/topi/internal/oauth"
 	"github.com/lusingander/topi/internal/topi"
 	"github.com/muesli/reflow/padding"
 )
 
 var (
+	operationPageMethodStyle                            lipgloss.Style
+	operationPageMethodGetStyle                         lipgloss.Style
+	operationPageMethodPostStyle                        lipgloss.Style
+	operationPageMethodPutStyle                         lipgloss.Style
+	operationPageMethodPatchStyle                       lipgloss.Style
+	operationPageMethodDeleteStyle                      lipgloss.Style
+	operationPageMethodDeprecatedStyle                  lipgloss.Style
+	operationPageDeprecatedMarkerStyle                  lipgloss.Style
+	operationPageFileMarkerStyle                        lipgloss.Style
+	operationPageServerUrlStyle                         lipgloss.Style
+	operationPageSourceStyle                            lipgloss.Style
+	operationPageSectionHeaderStyle                     lipgloss.Style
+	operationPageSectionSubHeaderStyle                  lipgloss.Style
+	opearationPageRequestBodyMediaTypeColorStyle        lipgloss.Style
+	operationPageParameterItemsStyle                    lipgloss.Style
+	operationPageParameterRequiredMarkerColorStyle      lipgloss.Style
+	operationPageParameterTypeColorStyle                lipgloss.Style
+	operationPageParameterDeprecatedNameStyle           lipgloss.Style
+	operationPageParameterPropertyKeyStyle              lipgloss.Style
+	operationPageParameterPropertyValueStyle            lipgloss.Style
+	operationPageSectionSubHeaderSuccessStatusCodeStyle lipgloss.Style
+	operationPageSectionSubHeaderErrorStatusCodeStyle   lipgloss.Style
+	operationPageSectionSubHeaderDefaultStatusCodeStyle lipgloss.Style
+	operationPageSecuritySchemeScopeStyle               lipgloss.Style
+	operationPageSecurityOriginStyle                    lipgloss.Style
+	operationPageSchemaIndentColorStyle                 lipgloss.Style
+	operationPageSchemaOneOfMarkerColorStyle            lipgloss.Style
+	operationPageItemStyle                              lipgloss.Style
+)
+
+var (
+	operationPageSeparator string
+)
+
+func loadOperationPageStyles(t *theme) {
 	operationPageMethodStyle = lipgloss.NewStyle().
-					Bold(true)
+		Bold(true)
 
 	operationPageMethodGetStyle = operationPageMethodStyle.Copy().
-					Foreground(httpMethodGetColor)
+		Foreground(httpMethodGetColor)
 
 	operationPageMethodPostStyle = operationPageMethodStyle.Copy().
-					Foreground(httpMethodPostColor)
+		Foreground(httpMethodPostColor)
 
 	operationPageMethodPutStyle = operationPageMethodStyle.Copy().
-					Foreground(httpMethodPutColor)
+		Foreground(httpMethodPutColor)
 
 	operationPageMethodPatchStyle = operationPageMethodStyle.Copy().
-					Foreground(httpMethodPatchColor)
+		Foreground(httpMethodPatchColor)
 
 	operationPageMethodDeleteStyle = operationPageMethodStyle.Copy().
-					Foreground(httpMethodDeleteColor)
+		Foreground(httpMethodDeleteColor)
 
 	operationPageMethodDeprecatedStyle = operationPageMethodStyle.Copy().
-						Foreground(httpMethodDeprecatedColor)
+		Foreground(httpMethodDeprecatedColor)
 
 	operationPageDeprecatedMarkerStyle = lipgloss.NewStyle().
-						Foreground(lipgloss.Color("208")).
-						Bold(true).
-						Margin(0, 0, 0, 2)
+		Foreground(t.Alert).
+		Bold(true).
+		Margin(0, 0, 0, 2)
+
+	operationPageFileMarkerStyle = lipgloss.NewStyle().
+		Foreground(t.Info).
+		Bold(true).
+		Margin(0, 0, 0, 2)
+
+	operationPageServerUrlStyle = lipgloss.NewStyle().
+		Foreground(t.Muted)
+
+	operationPageSourceStyle = lipgloss.NewStyle().
+		Foreground(t.Muted)
 
 	operationPageSectionHeaderStyle = lipgloss.NewStyle().
-					Foreground(lipgloss.Color("70")).
-					Underline(true)
+		Foreground(t.Primary).
+		Underline(true)
 
 	operationPageSectionSubHeaderStyle = operationPageSectionHeaderStyle.Copy().
-						Margin(0, 0, 0, 1)
+		Margin(0, 0, 0, 1)
 
 	opearationPageRequestBodyMediaTypeColorStyle = lipgloss.NewStyle().
-							Foreground(lipgloss.Color("70"))
+		Foreground(t.Primary)
+
+	operationPageItemStyle = lipgloss.NewStyle().
+		Padding(1, 2)
 
 	operationPageParameterItemsStyle = operationPageItemStyle.Copy().
-						Margin(0, 0, 0, 2)
+		Margin(0, 0, 0, 2)
 
 	operationPageParameterRequiredMarkerColorStyle = lipgloss.NewStyle().
-							Foreground(lipgloss.Color("168"))
+		Foreground(t.Error)
 
 	operationPageParameterTypeColorStyle = lipgloss.NewStyle().
-						Foreground(lipgloss.Color("246"))
+		Foreground(t.Muted)
 
 	operationPageParameterDeprecatedNameStyle = lipgloss.NewStyle().
-							Foreground(lipgloss.Color("246")).
-							Strikethrough(true)
+		Foreground(t.Muted).
+		Strikethrough(true)
 
 	operationPageParameterPropertyKeyStyle = lipgloss.NewStyle().
-						Foreground(lipgloss.Color("143"))
+		Foreground(t.Key)
 
 	operationPageParameterPropertyValueStyle = lipgloss.NewStyle().
-							Foreground(lipgloss.Color("167"))
+		Foreground(t.Value)
 
 	operationPageSectionSubHeaderSuccessStatusCodeStyle = operationPageSectionSubHeaderStyle.Copy().
-								Foreground(lipgloss.Color("77"))
+		Foreground(t.Success)
 
 	operationPageSectionSubHeaderErrorStatusCodeStyle = operationPageSectionSubHeaderStyle.Copy().
-								Foreground(lipgloss.Color("168"))
+		Foreground(t.Error)
 
 	operationPageSectionSubHeaderDefaultStatusCodeStyle = operationPageSectionSubHeaderStyle.Copy().
-								Foreground(lipgloss.Color("32"))
+		Foreground(t.Info)
 
 	operationPageSecuritySchemeScopeStyle = lipgloss.NewStyle().
-						Foreground(lipgloss.Color("203")).
-						Background(lipgloss.Color("236"))
+		Foreground(t.CodeFg).
+		Background(t.CodeBg)
+
+	operationPageSecurityOriginStyle = lipgloss.NewStyle().
+		Foreground(t.Muted)
 
 	operationPageSchemaIndentColorStyle = lipgloss.NewStyle().
-						Foreground(lipgloss.Color("238"))
+		Foreground(t.Indent)
 
 	operationPageSchemaOneOfMarkerColorStyle = lipgloss.NewStyle().
-							Foreground(lipgloss.Color("246"))
+		Foreground(t.Muted)
 
-	operationPageItemStyle = lipgloss.NewStyle().
-				Padding(1, 2)
-)
-
-var (
 	operationPageSeparator = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240")).
+		Foreground(t.Separator).
 		Padding(1, 2).
 		Render("----------")
-)
+}
 
 type operationPageModel struct {
 	doc           *topi.Document
+	bookmarks     *specBookmarks
+	recent        *specRecent
+	tokens        *oauth.Store
 	operation     *topi.Path
+	raw           rawSourceView
 	viewport      viewport.Model
 	delegateKeys  operationPageDelegateKeyMap
 	width, height int
 }
 
-func newOperationPageModel(doc *topi.Document) operationPageModel {
+func newOperationPageModel(doc *topi.Document, bookmarks *specBookmarks, recent *specRecent, tokens *oauth.Store) operationPageModel {
 	m := operationPageModel{
 		doc:       doc,
+		bookmarks: bookmarks,
+		recent:    recent,
+		tokens:    tokens,
 		operation: nil,
 	}
 	m.delegateKeys = newOperationPageDelegateKeyMap()
@@ -118,15 +174,20 @@ func newOperationPageModel(doc *topi.Document) operationPageModel {
 }
 
 type operationPageDelegateKeyMap struct {
-	back key.Binding
+	back     key.Binding
+	bookmark key.Binding
+	edit     key.Binding
+	raw      key.Binding
+	toggle   key.Binding
 }
 
 func newOperationPageDelegateKeyMap() operationPageDelegateKeyMap {
 	return operationPageDelegateKeyMap{
-		back: key.NewBinding(
-			key.WithKeys("backspace", "ctrl+h"),
-			key.WithHelp("backspace", "back"),
-		),
+		back:     keys.back.binding("back"),
+		bookmark: keys.bookmark.binding("bookmark"),
+		edit:     keys.edit.binding("edit"),
+		raw:      keys.raw.binding("raw source"),
+		toggle:   keys.toggle.binding("toggle dereferenced"),
 	}
 }
 
@@ -137,6 +198,7 @@ func (m *operationPageModel) SetSize(w, h int) {
 }
 
 func (m *operationPageModel) reset() {
+	m.raw = rawSourceView{}
 	m.viewport.GotoTop()
 }
 
@@ -150,23 +212,55 @@ func (m *operationPageModel) updateContent() {
 	if op == nil {
 		return
 	}
+	if m.raw.enabled {
+		m.viewport.SetContent(styledRawSource(m.doc, op.Source, m.raw.deref, m.width))
+		return
+	}
+	opts := operationStyleOptions{
+		server:     m.doc.DefaultServer(defaultServer),
+		bookmarked: m.bookmarks.contains(op),
+		source:     true,
+		tokens:     m.tokens,
+	}
+	m.viewport.SetContent(styledOperation(op, opts, m.width))
+}
+
+// operationStyleOptions is the state of the viewer shown with the operation.
+type operationStyleOptions struct {
+	server     *topi.Server // nil not to show the url
+	bookmarked bool
+	source     bool         // show where the elements are defined
+	tokens     *oauth.Store // nil not to show the acquired tokens
+}
 
-	r, _ := markdownRenderer(m.width - 10)
+func styledOperation(op *topi.Path, opts operationStyleOptions, width int) string {
+	r, _ := markdownRenderer(width - 10)
 
 	var content strings.Builder
 
-	method := m.styledMethod()
+	method := styledMethod(op)
 	path := op.UriPath
 	mp := fmt.Sprintf("%s %s", method, path)
 	if op.Deprecated {
 		mp += operationPageDeprecatedMarkerStyle.Render("Deprecated")
 	}
+	if opts.bookmarked {
+		mp += " " + bookmarkMarkerStyle.Render(bookmarkMarker)
+	}
 	content.WriteString(operationPageItemStyle.Render(mp))
 
 	if op.Summary != "" {
 		summary := op.Summary
 		content.WriteString(operationPageItemStyle.Render(summary))
 	}
+	if opts.server != nil {
+		url := operationPageServerUrlStyle.Render(opts.server.OperationUrl(op))
+		content.WriteString(operationPageItemStyle.Render(url))
+	}
+	if opts.source && op.Source != nil {
+		src := operationPageSourceStyle.Render("Source: " + sourceString(op.Source))
+		content.WriteString(operationPageItemStyle.Render(src))
+	}
 	content.WriteString(operationPageSeparator)
 
 	if op.Description != "" {
@@ -177,12 +271,22 @@ func (m *operationPageModel) updateContent() {
 		content.WriteString(operationPageSeparator)
 	}
 
-	if len(op.Security) > 0 {
-		requestSectionHeader := operationPageSectionHeaderStyle.Render("Security Requirements")
-		content.WriteString(operationPageItemStyle.Render(requestSectionHeader))
+	if len(op.Security) > 0 || op.SecurityOrigin == topi.SecurityOverridden {
+		securitySectionHeader := operationPageSectionHeaderStyle.Render("Security Requirements")
+		if label := securityOriginLabel(op.SecurityOrigin); label != "" {
+			securitySectionHeader += "  " + operationPageSecurityOriginStyle.Render(label)
+		}
+		content.WriteString(operationPageItemStyle.Render(securitySectionHeader))
 
-		rs := styledSecurityRequirements(op.Security)
-		content.WriteString(operationPageParameterItemsStyle.Render(rs))
+		if len(op.Security) > 0 {
+			rs := styledSecurityRequirements(op.Security)
+			content.WriteString(operationPageParameterItemsStyle.Render(rs))
+		}
+		if tokens := styledAcquiredTokens(op.Security, opts.tokens, width); tokens != "" {
+			tokensHeader := operationPageSectionSubHeaderStyle.Render("Acquired tokens")
+			content.WriteString(operationPageItemStyle.Render(tokensHeader))
+			content.WriteString(operationPageParameterItemsStyle.Render(tokens))
+		}
 	}
 
 	requestSectionHeader := operationPageSectionHeaderStyle.Render("Request")
@@ -191,33 +295,47 @@ func (m *operationPageModel) updateContent() {
 	if len(op.PathParameters) > 0 {
 		pathParamSectionHeader := operationPageSectionSubHeaderStyle.Render("Path parameters")
 		content.WriteString(operationPageItemStyle.Render(pathParamSectionHeader))
-		content.WriteString(operationPageParameterItemsStyle.Render(m.styledParams(op.PathParameters)))
+		content.WriteString(operationPageParameterItemsStyle.Render(styledParams(op.PathParameters, sourceBase(op.Source, opts))))
 	}
 
 	if len(op.QueryParameters) > 0 {
 		queryParamSectionHeader := operationPageSectionSubHeaderStyle.Render("Query parameters")
 		content.WriteString(operationPageItemStyle.Render(queryParamSectionHeader))
-		content.WriteString(operationPageParameterItemsStyle.Render(m.styledParams(op.QueryParameters)))
+		content.WriteString(operationPageParameterItemsStyle.Render(styledParams(op.QueryParameters, sourceBase(op.Source, opts))))
 	}
 
 	if len(op.HeaderParameters) > 0 {
 		headerParamSectionHeader := operationPageSectionSubHeaderStyle.Render("Header parameters")
 		content.WriteString(operationPageItemStyle.Render(headerParamSectionHeader))
-		content.WriteString(operationPageParameterItemsStyle.Render(m.styledParams(op.HeaderParameters)))
+		content.WriteString(operationPageParameterItemsStyle.Render(styledParams(op.HeaderParameters, sourceBase(op.Source, opts))))
 	}
 
 	if len(op.CookieParameters) > 0 {
 		cookieParamSectionHeader := operationPageSectionSubHeaderStyle.Render("Cookie parameters")
 		content.WriteString(operationPageItemStyle.Render(cookieParamSectionHeader))
-		content.WriteString(operationPageParameterItemsStyle.Render(m.styledParams(op.CookieParameters)))
+		content.WriteString(operationPageParameterItemsStyle.Render(styledParams(op.CookieParameters, sourceBase(op.Source, opts))))
 	}
 
 	if op.RequestBody != nil && len(op.RequestBody.Conetnt) > 0 {
 		for _, c := range op.RequestBody.Conetnt {
+			if c.Schema == nil {
+				continue
+			}
 			requestBodyMediaType := opearationPageRequestBodyMediaTypeColorStyle.Render(fmt.Sprintf("[%s]", c.MediaType))
 			requestBodySectionHeader := operationPageSectionSubHeaderStyle.Render("Request body")
-			content.WriteString(operationPageItemStyle.Render(fmt.Sprintf("%s  %s", requestBodySectionHeader, requestBodyMediaType)))
-			content.WriteString(operationPageParameterItemsStyle.Render(styledSchema(c.Schema, 0, false)))
+			header := fmt.Sprintf("%s  %s", requestBodySectionHeader, requestBodyMediaType)
+			if src := styledSource(sourceBase(op.Source, opts), c.Schema.Source); src != "" {
+				header += "  " + src
+			}
+			content.WriteString(operationPageItemStyle.Render(header))
+			content.WriteString(operationPageParameterItemsStyle.Render(styledSchema(c.Schema, 0, schemaStyleOptions{read: false, xml: c.IsXml()})))
+
+			if enc := styledEncoding(c); enc != "" {
+				encodingHeader := operationPageSectionSubHeaderStyle.Render("Encoding")
+				content.WriteString(operationPageItemStyle.Render(encodingHeader))
+				content.WriteString(operationPageParameterItemsStyle.Render(enc))
+			}
+			content.WriteString(styledXmlExample(c, false, width))
 		}
 	}
 
@@ -239,11 +357,14 @@ func (m *operationPageModel) updateContent() {
 			desc = response.Description // fixme: render as md, consider width
 		}
 		content.WriteString(operationPageItemStyle.Render(fmt.Sprintf("%s  %s", statusCode, desc)))
+		if src := styledSource(sourceBase(op.Source, opts), response.Source); src != "" {
+			content.WriteString(operationPageParameterItemsStyle.Render(src))
+		}
 
 		if len(response.Headers) > 0 {
 			requestHeadersHeader := operationPageSectionSubHeaderStyle.Render("Response headers")
 			content.WriteString(operationPageItemStyle.Render(requestHeadersHeader))
-			content.WriteString(operationPageParameterItemsStyle.Render(m.styledHeaders(response.Headers)))
+			content.WriteString(operationPageParameterItemsStyle.Render(styledHeaders(response.Headers)))
 		}
 
 		for _, c := range response.Conetnt {
@@ -252,17 +373,188 @@ func (m *operationPageModel) updateContent() {
 			}
 			requestBodyMediaTypeHeader := operationPageSectionSubHeaderStyle.Render("Response schema")
 			requestBodyMediaType := opearationPageRequestBodyMediaTypeColorStyle.Render(fmt.Sprintf("[%s]", c.MediaType))
-			content.WriteString(operationPageItemStyle.Render(fmt.Sprintf("%s  %s", requestBodyMediaTypeHeader, requestBodyMediaType)))
-			content.WriteString(operationPageParameterItemsStyle.Render(styledSchema(c.Schema, 0, true)))
+			header := fmt.Sprintf("%s  %s", requestBodyMediaTypeHeader, requestBodyMediaType)
+			if src := styledSource(sourceBase(response.Source, opts), c.Schema.Source); src != "" {
+				header += "  " + src
+			}
+			content.WriteString(operationPageItemStyle.Render(header))
+			content.WriteString(operationPageParameterItemsStyle.Render(styledSchema(c.Schema, 0, schemaStyleOptions{read: true, xml: c.IsXml()})))
+			content.WriteString(styledXmlExample(c, true, width))
+		}
+	}
+
+	return content.String()
+}
+
+// styledEncoding returns the encoding of each part of multipart or application/x-www-form-urlencoded request body.
+// For multipart, the parts without the encoding are also shown with the default content type.
+func styledEncoding(c *topi.MediaTypeContent) string {
+	var names []string
+	switch {
+	case c.IsMultipart():
+		names = encodingPropertyNames(c)
+	case c.IsFormUrlencoded():
+		for _, e := range c.Encoding {
+			names = append(names, e.Property)
+		}
+	default:
+		return ""
+	}
+	if len(names) == 0 {
+		return ""
+	}
+
+	nameAreaWidth := 0
+	for _, name := range names {
+		if w := len(name); nameAreaWidth < w {
+			nameAreaWidth = w
+		}
+	}
+	nameAreaWidth += 2 // buf
+	descIndent := strings.Repeat(" ", nameAreaWidth)
+
+	props := schemaProperties(c.Schema)
+
+	strs := make([]string, 0)
+	for _, name := range names {
+		e := c.FindEncoding(name)
+
+		var s strings.Builder
+		s.WriteString(padding.String(name, uint(nameAreaWidth)))
+		switch {
+		case e != nil && e.ContentType != "":
+			s.WriteString(operationPageParameterTypeColorStyle.Render(e.ContentType))
+		case c.IsMultipart():
+			ct := topi.DefaultPartContentType(props[name])
+			s.WriteString(operationPageParameterTypeColorStyle.Render(ct + " (default)"))
+		}
+		if sc := props[name]; sc != nil && (sc.IsFile() || sc.IsFileArray()) {
+			s.WriteString(" ")
+			s.WriteString(operationPageFileMarkerStyle.Render("File upload"))
+		}
+		strs = append(strs, s.String())
+
+		if e == nil {
+			continue
+		}
+		if len(e.Headers) > 0 {
+			hs := make([]string, len(e.Headers))
+			for i, h := range e.Headers {
+				hs[i] = h.Name
+				if h.Parameter.Schema != nil {
+					hs[i] += " " + h.Parameter.Schema.TypeString()
+				}
+			}
+			k := operationPageParameterPropertyKeyStyle.Render("Headers:")
+			v := operationPageParameterPropertyValueStyle.Render(strings.Join(hs, ", "))
+			strs = append(strs, fmt.Sprintf("%s%s %s", descIndent, k, v))
+		}
+		if e.Style != "" || e.Explode != nil {
+			p := &topi.Parameter{In: "query", Style: e.Style, Explode: e.Explode} // encoding is serialized as the query parameters
+			k := operationPageParameterPropertyKeyStyle.Render("Style:")
+			v := operationPageParameterPropertyValueStyle.Render(styleString(p))
+			strs = append(strs, fmt.Sprintf("%s%s %s", descIndent, k, v))
+		}
+		if e.AllowReserved {
+			k := operationPageParameterPropertyKeyStyle.Render("Options:")
+			v := operationPageParameterPropertyValueStyle.Render("allowReserved")
+			strs = append(strs, fmt.Sprintf("%s%s %s", descIndent, k, v))
+		}
+	}
+	return strings.Join(strs, "\n")
+}
+
+// encodingPropertyNames returns the names of the properties of the schema and the encoding.
+func encodingPropertyNames(c *topi.MediaTypeContent) []string {
+	props := make(map[string]*topi.Schema)
+	for name, sc := range schemaProperties(c.Schema) {
+		props[name] = sc
+	}
+	for _, e := range c.Encoding {
+		if _, ok := props[e.Property]; !ok {
+			props[e.Property] = nil
+		}
+	}
+	return sortedPropertyNames(props)
+}
+
+func schemaProperties(sc *topi.Schema) map[string]*topi.Schema {
+	if sc == nil {
+		return nil
+	}
+	if len(sc.AllOf) > 0 {
+		sc = sc.MergedAllOf()
+	}
+	return sc.Properties
+}
+
+// styledXml returns the line of the xml object of the property, or nothing if the property has no xml object.
+func styledXml(prop *topi.Schema, nameAreaWidth, indentLevel int) []string {
+	values := xmlValues(prop.XML)
+	if prop.Type == "array" && prop.Items != nil {
+		if items := xmlValues(prop.Items.XML); len(items) > 0 {
+			values = append(values, fmt.Sprintf("items(%s)", strings.Join(items, ", ")))
 		}
 	}
+	if len(values) == 0 {
+		return nil
+	}
+	_, scl := schemaIndent(indentLevel)
+	descIndent := strings.Repeat(" ", nameAreaWidth+scl)
+	k := operationPageParameterPropertyKeyStyle.Render("XML:")
+	v := operationPageParameterPropertyValueStyle.Render(strings.Join(values, ", "))
+	return []string{fmt.Sprintf("%s%s %s", descIndent, k, v)}
+}
+
+func xmlValues(x *topi.XML) []string {
+	if x == nil {
+		return nil
+	}
+	values := make([]string, 0)
+	if x.Name != "" {
+		values = append(values, "name="+x.Name)
+	}
+	if x.Prefix != "" {
+		values = append(values, "prefix="+x.Prefix)
+	}
+	if x.Namespace != "" {
+		values = append(values, "namespace="+x.Namespace)
+	}
+	if x.Attribute {
+		values = append(values, "attribute")
+	}
+	if x.Wrapped {
+		values = append(values, "wrapped")
+	}
+	return values
+}
+
+// styledXmlExample returns the example XML document of the schema if the media type is XML.
+func styledXmlExample(c *topi.MediaTypeContent, read bool, width int) string {
+	if !c.IsXml() {
+		return ""
+	}
+	example := c.Schema.XmlExample(read)
+	var content strings.Builder
+	exampleHeader := operationPageSectionSubHeaderStyle.Render("Example")
+	content.WriteString(operationPageItemStyle.Render(exampleHeader))
 
-	m.viewport.SetContent(content.String())
+	r, _ := markdownRenderer(width - 10)
+	code, err := r.Render(fmt.Sprintf("```xml\n%s```\n", example))
+	if err != nil {
+		code = example
+	}
+	content.WriteString(operationPageItemStyle.Render(code))
+	return content.String()
 }
 
 func styledSecurityRequirements(requirements []*topi.SecurityRequirement) string {
 	ss := make([]string, len(requirements))
 	for i, requirement := range requirements {
+		if len(requirement.Schemes) == 0 {
+			ss[i] = "(anonymous)"
+			continue
+		}
 		schemes := make([]string, len(requirement.Schemes))
 		for j, scheme := range requirement.Schemes {
 			schemes[j] = styledSecurityScheme(scheme)
@@ -272,6 +564,46 @@ func styledSecurityRequirements(requirements []*topi.SecurityRequirement) string
 	return strings.Join(ss, "\n or\n")
 }
 
+// styledAcquiredTokens returns the Authorization headers sent with the tokens acquired for the schemes of the requirements.
+func styledAcquiredTokens(requirements []*topi.SecurityRequirement, tokens *oauth.Store, width int) string {
+	if tokens == nil {
+		return ""
+	}
+	ss := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, requirement := range requirements {
+		for _, scheme := range requirement.Schemes {
+			if seen[scheme.Key] {
+				continue
+			}
+			seen[scheme.Key] = true
+			t := tokens.Get(scheme.Key)
+			if t == nil {
+				continue
+			}
+			key := operationPageSecurityOriginStyle.Render(fmt.Sprintf("(%s)", scheme.Key))
+			w := width - lipgloss.Width(key) - 12
+			if w < 20 {
+				w = 20
+			}
+			header := truncateWithTail("Authorization: "+t.AuthorizationHeader(), uint(w))
+			ss = append(ss, fmt.Sprintf("%s  %s", header, key))
+		}
+	}
+	return strings.Join(ss, "\n")
+}
+
+func securityOriginLabel(origin topi.SecurityOrigin) string {
+	switch origin {
+	case topi.SecurityInherited:
+		return "inherited"
+	case topi.SecurityOverridden:
+		return "public (overrides global)"
+	default:
+		return ""
+	}
+}
+
 func styledSecurityScheme(sc *topi.SecurityRequirementScheme) string {
 	s := sc.Key
 	if len(sc.Scopes) > 0 {
@@ -284,7 +616,26 @@ func styledSecurityScheme(sc *topi.SecurityRequirementScheme) string {
 	return s
 }
 
-func (operationPageModel) styledParams(params []*topi.Parameter) string {
+// sourceBase returns the source of the element which contains the elements shown, or nil not to show the sources.
+func sourceBase(src *topi.Source, opts operationStyleOptions) *topi.Source {
+	if !opts.source {
+		return nil
+	}
+	if src == nil {
+		return &topi.Source{} // show all sources since the elements are not known to be in it
+	}
+	return src
+}
+
+// styledSource returns the source of the element if it is defined out of the base (e.g. in another file via $ref).
+func styledSource(base, src *topi.Source) string {
+	if base == nil || src == nil || base.Contains(src) {
+		return ""
+	}
+	return operationPageSourceStyle.Render("Defined in " + sourceString(src))
+}
+
+func styledParams(params []*topi.Parameter, base *topi.Source) string {
 	strs := make([]string, 0)
 
 	nameAreaWidth := 0
@@ -297,13 +648,16 @@ func (operationPageModel) styledParams(params []*topi.Parameter) string {
 	nameAreaWidth += 2 // requred marker + buf
 
 	for _, param := range params {
-		ss := styledSingleParam(param.Schema, param.Name, param.Description, param.Required, param.Deprecated, nameAreaWidth, 0)
+		ss := styledSingleParam(param.Schema, param, param.Name, param.Description, param.Required, param.Deprecated, nameAreaWidth, 0)
 		strs = append(strs, ss...)
+		if src := styledSource(base, param.Source); src != "" {
+			strs = append(strs, strings.Repeat(" ", nameAreaWidth)+src)
+		}
 	}
 	return strings.Join(strs, "\n")
 }
 
-func (operationPageModel) styledHeaders(headers []*topi.Header) string {
+func styledHeaders(headers []*topi.Header) string {
 	strs := make([]string, 0)
 
 	nameAreaWidth := 0
@@ -316,15 +670,29 @@ func (operationPageModel) styledHeaders(headers []*topi.Header) string {
 	nameAreaWidth += 2 // requred marker + buf
 
 	for _, header := range headers {
-		ss := styledSingleParam(header.Parameter.Schema, header.Name, header.Parameter.Description, header.Parameter.Required, header.Parameter.Deprecated, nameAreaWidth, 0)
+		ss := styledSingleParam(header.Parameter.Schema, nil, header.Name, header.Parameter.Description, header.Parameter.Required, header.Parameter.Deprecated, nameAreaWidth, 0)
 		strs = append(strs, ss...)
 	}
 	return strings.Join(strs, "\n")
 }
 
-func styledSchema(sc *topi.Schema, indentLevel int, read bool) string {
+// styleString returns the serialization style and the explode, "n/a" if the explode is not defined for the style.
+func styleString(p *topi.Parameter) string {
+	if !p.ExplodeApplicable() {
+		return fmt.Sprintf("%s, explode: n/a", p.SerializationStyle())
+	}
+	return fmt.Sprintf("%s, explode: %t", p.SerializationStyle(), p.Exploded())
+}
+
+// schemaStyleOptions is the options to render the schema tree.
+type schemaStyleOptions struct {
+	read bool // omit writeOnly properties if true, readOnly properties otherwise
+	xml  bool // show the xml objects of the properties
+}
+
+func styledSchema(sc *topi.Schema, indentLevel int, opts schemaStyleOptions) string {
 	if len(sc.AllOf) > 0 {
-		return styledSchema(sc.MergedAllOf(), indentLevel, read)
+		return styledSchema(sc.MergedAllOf(), indentLevel, opts)
 	}
 	if sc.Type == "object" {
 
@@ -338,8 +706,9 @@ func styledSchema(sc *topi.Schema, indentLevel int, read bool) string {
 		nameAreaWidth += 2 // requred marker + buf
 
 		strs := make([]string, 0)
-		for name, prop := range sc.Properties { // fixme: fix order
-			if read {
+		for _, name := range sortedPropertyNames(sc.Properties) {
+			prop := sc.Properties[name]
+			if opts.read {
 				if prop.WriteOnly {
 					continue
 				}
@@ -349,13 +718,16 @@ func styledSchema(sc *topi.Schema, indentLevel int, read bool) string {
 				}
 			}
 			required := containsString(name, sc.Required)
-			ss := styledSingleParam(prop, name, prop.Description, required, prop.Deprecated, nameAreaWidth, indentLevel)
+			ss := styledSingleParam(prop, nil, name, prop.Description, required, prop.Deprecated, nameAreaWidth, indentLevel)
 			strs = append(strs, ss...)
+			if opts.xml {
+				strs = append(strs, styledXml(prop, nameAreaWidth, indentLevel)...)
+			}
 
 			if len(prop.AllOf) > 0 {
 				merged := prop.MergedAllOf()
-				if merged.Type == "object" || (merged.Type == "array" && merged.Items.Type == "object") {
-					s := styledSchema(merged, indentLevel+1, read)
+				if merged.Type == "object" || merged.IsObjectArray() {
+					s := styledSchema(merged, indentLevel+1, opts)
 					strs = append(strs, s)
 				}
 			}
@@ -365,31 +737,31 @@ func styledSchema(sc *topi.Schema, indentLevel int, read bool) string {
 						schemaIndent, _ := schemaIndent(indentLevel + 1) // indent++
 						marker := fmt.Sprintf("object[%d]", i+1)
 						s := schemaIndent + operationPageSchemaOneOfMarkerColorStyle.Render(marker)
-						t := styledSchema(schema, indentLevel+2, read) // indent++++
+						t := styledSchema(schema, indentLevel+2, opts) // indent++++
 						strs = append(strs, s, t)
 					}
 				}
 			}
 			if prop.Type == "object" {
-				ss := styledProperties(prop, indentLevel+1, read)
+				ss := styledProperties(prop, indentLevel+1, opts)
 				strs = append(strs, ss...)
 			}
-			if prop.Type == "array" && prop.Items.Type == "object" {
-				ss := styledProperties(prop.Items, indentLevel+1, read)
+			if prop.IsObjectArray() {
+				ss := styledProperties(prop.Items, indentLevel+1, opts)
 				strs = append(strs, ss...)
 			}
 		}
 		return strings.Join(strs, "\n")
 	}
-	if sc.Type == "array" && sc.Items.Type == "object" {
-		s := schemaTypeString(sc)
-		t := styledSchema(sc.Items, indentLevel+1, read)
+	if sc.IsObjectArray() {
+		s := sc.TypeString()
+		t := styledSchema(sc.Items, indentLevel+1, opts)
 		return strings.Join([]string{s, t}, "\n")
 	}
-	return schemaTypeString(sc)
+	return sc.TypeString()
 }
 
-func styledProperties(sc *topi.Schema, indentLevel int, read bool) []string {
+func styledProperties(sc *topi.Schema, indentLevel int, opts schemaStyleOptions) []string {
 	strs := make([]string, 0)
 	props := sc.Properties
 
@@ -402,8 +774,9 @@ func styledProperties(sc *topi.Schema, indentLevel int, read bool) []string {
 	}
 	nameAreaWidth += 2 // requred marker + buf
 
-	for name, prop := range props { // fixme: fix order
-		if read {
+	for _, name := range sortedPropertyNames(props) {
+		prop := props[name]
+		if opts.read {
 			if prop.WriteOnly {
 				continue
 			}
@@ -413,15 +786,18 @@ func styledProperties(sc *topi.Schema, indentLevel int, read bool) []string {
 			}
 		}
 		required := containsString(name, sc.Required)
-		ss := styledSingleParam(prop, name, prop.Description, required, prop.Deprecated, nameAreaWidth, indentLevel)
+		ss := styledSingleParam(prop, nil, name, prop.Description, required, prop.Deprecated, nameAreaWidth, indentLevel)
 		strs = append(strs, ss...)
+		if opts.xml {
+			strs = append(strs, styledXml(prop, nameAreaWidth, indentLevel)...)
+		}
 
 		if prop.Type == "object" {
-			ss := styledProperties(prop, indentLevel+1, read)
+			ss := styledProperties(prop, indentLevel+1, opts)
 			strs = append(strs, ss...)
 		}
-		if prop.Type == "array" && prop.Items.Type == "object" {
-			ss := styledProperties(prop.Items, indentLevel+1, read)
+		if prop.IsObjectArray() {
+			ss := styledProperties(prop.Items, indentLevel+1, opts)
 			strs = append(strs, ss...)
 		}
 	}
@@ -429,7 +805,8 @@ func styledProperties(sc *topi.Schema, indentLevel int, read bool) []string {
 	return strs
 }
 
-func styledSingleParam(schema *topi.Schema, name, description string, required, deprecated bool, nameAreaWidth, indentLevel int) []string {
+// styledSingleParam returns the lines of the parameter or the property. param is nil for properties and headers.
+func styledSingleParam(schema *topi.Schema, param *topi.Parameter, name, description string, required, deprecated bool, nameAreaWidth, indentLevel int) []string {
 	strs := make([]string, 0)
 
 	schemaIndent, scl := schemaIndent(indentLevel)
@@ -448,10 +825,15 @@ func styledSingleParam(schema *topi.Schema, name, description string, required,
 	s.WriteString(padding.String(name, uint(nameAreaWidth)))
 
 	if schema != nil {
-		schemaType := schemaTypeString(schema)
+		schemaType := schema.TypeString()
 		s.WriteString(operationPageParameterTypeColorStyle.Render(schemaType))
 	}
 
+	if schema != nil && (schema.IsFile() || schema.IsFileArray()) {
+		s.WriteString(" ")
+		s.WriteString(operationPageFileMarkerStyle.Render("File upload"))
+	}
+
 	if deprecated {
 		s.WriteString(" ")
 		s.WriteString(operationPageDeprecatedMarkerStyle.Render("Deprecated"))
@@ -479,22 +861,22 @@ func styledSingleParam(schema *topi.Schema, name, description string, required,
 		if len(schema.Enum) > 0 {
 			var s strings.Builder
 			k := operationPageParameterPropertyKeyStyle.Render("Enum:")
-			v := operationPageParameterPropertyValueStyle.Render(sliceString(schema.Enum))
+			v := operationPageParameterPropertyValueStyle.Render(topi.SliceString(schema.Enum))
 			s.WriteString(descIndent)
 			s.WriteString(fmt.Sprintf("%s %s", k, v))
 			strs = append(strs, s.String())
 		}
 
-		if schema.Type == "array" && len(schema.Items.Enum) > 0 {
+		if schema.Type == "array" && schema.Items != nil && len(schema.Items.Enum) > 0 {
 			var s strings.Builder
 			k := operationPageParameterPropertyKeyStyle.Render("Items Enum:")
-			v := operationPageParameterPropertyValueStyle.Render(sliceString(schema.Items.Enum))
+			v := operationPageParameterPropertyValueStyle.Render(topi.SliceString(schema.Items.Enum))
 			s.WriteString(descIndent)
 			s.WriteString(fmt.Sprintf("%s %s", k, v))
 			strs = append(strs, s.String())
 		}
 
-		constraints := schemaConstraintStrings(schema)
+		constraints := schema.ConstraintStrings()
 		if len(constraints) > 0 {
 			var s strings.Builder
 			k := operationPageParameterPropertyKeyStyle.Render("Constraints:")
@@ -504,17 +886,83 @@ func styledSingleParam(schema *topi.Schema, name, description string, required,
 			strs = append(strs, s.String())
 		}
 	}
+
+	if param != nil {
+		for _, kv := range parameterProperties(param) {
+			k := operationPageParameterPropertyKeyStyle.Render(kv[0] + ":")
+			v := operationPageParameterPropertyValueStyle.Render(kv[1])
+			strs = append(strs, fmt.Sprintf("%s%s %s", descIndent, k, v))
+		}
+	}
 	return strs
 }
 
+// parameterProperties returns the pairs of the keys and values of the parameter specific properties to show.
+func parameterProperties(param *topi.Parameter) [][2]string {
+	ret := make([][2]string, 0)
+
+	for _, c := range param.Content {
+		v := fmt.Sprintf("[%s]", c.MediaType)
+		if c.Schema != nil {
+			v += " " + c.Schema.TypeString()
+		}
+		ret = append(ret, [2]string{"Content", v})
+	}
+
+	complex := param.Schema != nil && (param.Schema.Type == "array" || param.Schema.Type == "object")
+	if param.Style != "" || param.Explode != nil || complex {
+		ret = append(ret, [2]string{"Style", styleString(param)})
+	}
+	if example := param.SerializationExample(); example != "" {
+		ret = append(ret, [2]string{"Serialized", example})
+	}
+
+	options := make([]string, 0)
+	if param.AllowEmptyValue {
+		options = append(options, "allowEmptyValue")
+	}
+	if param.AllowReserved {
+		options = append(options, "allowReserved")
+	}
+	if len(options) > 0 {
+		ret = append(ret, [2]string{"Options", strings.Join(options, ", ")})
+	}
+
+	if param.Example != nil {
+		ret = append(ret, [2]string{"Example", exampleString(param.Example)})
+	}
+	for _, e := range param.Examples {
+		v := exampleString(e.Value)
+		if e.Value == nil && e.ExternalValue != "" {
+			v = e.ExternalValue
+		}
+		if e.Summary != "" {
+			v += " (" + e.Summary + ")"
+		}
+		ret = append(ret, [2]string{fmt.Sprintf("Example %s", e.Name), v})
+	}
+	return ret
+}
+
+func exampleString(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	bs, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(bs)
+}
+
 func schemaIndent(indentLevel int) (string, int) {
 	schemaIndent := strings.Repeat(">>", indentLevel)
 	return operationPageSchemaIndentColorStyle.Render(schemaIndent), len(schemaIndent)
 }
 
-func (m operationPageModel) styledMethod() string {
-	method := m.operation.Method
-	if m.operation.Deprecated {
+func styledMethod(op *topi.Path) string {
+	method := op.Method
+	if op.Deprecated {
 		return operationPageMethodDeprecatedStyle.Render(method)
 	}
 	switch method {
@@ -543,18 +991,46 @@ func (m operationPageModel) Update(msg tea.Msg) (operationPageModel, tea.Cmd) {
 		switch {
 		case key.Matches(msg, m.delegateKeys.back):
 			return m, goBack
+		case key.Matches(msg, m.delegateKeys.bookmark):
+			if m.operation != nil {
+				cmd := m.bookmarks.toggle(m.operation)
+				m.updateContent()
+				return m, cmd
+			}
+		case key.Matches(msg, m.delegateKeys.edit):
+			if m.operation != nil {
+				return m, editSource(m.operation.Source)
+			}
+		case key.Matches(msg, m.delegateKeys.raw):
+			m.raw.enabled = !m.raw.enabled
+			m.viewport.GotoTop()
+			m.updateContent()
+			return m, nil
+		case key.Matches(msg, m.delegateKeys.toggle):
+			if m.raw.enabled {
+				m.raw.deref = !m.raw.deref
+				m.updateContent()
+				return m, nil
+			}
 		}
 	case selectOperationMsg:
 		m.reset()
 		m.updateOperation(msg.operationId)
 		m.updateContent()
-		return m, nil
+		if m.operation == nil {
+			return m, nil
+		}
+		return m, m.recent.add(m.operation)
 	}
 	var cmd tea.Cmd
 	m.viewport, cmd = m.viewport.Update(msg)
 	return m, cmd
 }
 
+func (m operationPageModel) statusMessageString() string {
+	return m.raw.statusMessageString()
+}
+
 func (m operationPageModel) View() string {
 	return m.viewport.View()
 }
@@ -9,17 +9,19 @@ import (
 
 type pathPageModel struct {
 	doc           *topi.Document
+	bookmarks     *specBookmarks
 	list          list.Model
 	delegateKeys  pathPageDelegateKeyMap
 	width, height int
 }
 
-func newPathPageModel(doc *topi.Document) pathPageModel {
+func newPathPageModel(doc *topi.Document, bookmarks *specBookmarks) pathPageModel {
 	m := pathPageModel{
-		doc: doc,
+		doc:       doc,
+		bookmarks: bookmarks,
 	}
 	m.delegateKeys = newPathPageDelegateKeyMap()
-	delegate := newPathPageListDelegate()
+	delegate := newPathPageListDelegate(bookmarks)
 	m.list = list.New(nil, delegate, 0, 0)
 	m.list.SetShowTitle(false)
 	m.list.SetShowHelp(false)
@@ -31,20 +33,16 @@ func newPathPageModel(doc *topi.Document) pathPageModel {
 }
 
 type pathPageDelegateKeyMap struct {
-	enter key.Binding
-	back  key.Binding
+	enter    key.Binding
+	back     key.Binding
+	bookmark key.Binding
 }
 
 func newPathPageDelegateKeyMap() pathPageDelegateKeyMap {
 	return pathPageDelegateKeyMap{
-		enter: key.NewBinding(
-			key.WithKeys("enter"),
-			key.WithHelp("enter", "select"),
-		),
-		back: key.NewBinding(
-			key.WithKeys("backspace", "ctrl+h"),
-			key.WithHelp("backspace", "back"),
-		),
+		enter:    keys.selectItem.binding("select"),
+		back:     keys.back.binding("back"),
+		bookmark: keys.bookmark.binding("bookmark"),
 	}
 }
 
@@ -57,7 +55,7 @@ func (m *pathPageModel) updateList() {
 	m.list.ResetSelected()
 	items := make([]list.Item, 0)
 	for _, tag := range m.doc.Tags {
-		paths := m.doc.TagPathMap[tag.Name]
+		paths := listedPaths(m.doc.TagPathMap[tag.Name])
 		for _, path := range paths {
 			item := pathPageListItem{path}
 			items = append(items, item)
@@ -66,6 +64,14 @@ func (m *pathPageModel) updateList() {
 	m.list.SetItems(items)
 }
 
+func (m *pathPageModel) toggleBookmark() tea.Cmd {
+	item, ok := m.list.SelectedItem().(pathPageListItem)
+	if !ok {
+		return nil
+	}
+	return m.bookmarks.toggle(item.path)
+}
+
 func (m *pathPageModel) reset() {
 	m.list.ResetSelected()
 	m.list.ResetFilter()
@@ -88,6 +94,10 @@ func (m pathPageModel) Update(msg tea.Msg) (pathPageModel, tea.Cmd) {
 				path := m.list.SelectedItem().(pathPageListItem).path
 				return m, selectOperation(path.OperationId)
 			}
+		case key.Matches(msg, m.delegateKeys.bookmark):
+			if m.list.FilterState() != list.Filtering {
+				return m, m.toggleBookmark()
+			}
 		}
 	case selectPathMenuMsg:
 		m.updateList()
@@ -13,58 +13,78 @@ import (
 )
 
 var (
+	pathPageMethodStyle                   lipgloss.Style
+	pathPageMethodSelectedGetStyle        lipgloss.Style
+	pathPageMethodNormalGetStyle          lipgloss.Style
+	pathPageMethodSelectedPostStyle       lipgloss.Style
+	pathPageMethodNormalPostStyle         lipgloss.Style
+	pathPageMethodSelectedDeleteStyle     lipgloss.Style
+	pathPageMethodNormalDeleteStyle       lipgloss.Style
+	pathPageMethodSelectedPutStyle        lipgloss.Style
+	pathPageMethodNormalPutStyle          lipgloss.Style
+	pathPageMethodSelectedPatchStyle      lipgloss.Style
+	pathPageMethodNormalPatchStyle        lipgloss.Style
+	pathPageMethodDeprecatedStyle         lipgloss.Style
+	pathPageMethodSelectedDeprecatedStyle lipgloss.Style
+	pathPageMethodNormalDeprecatedStyle   lipgloss.Style
+	pathPagePathDeprecatedStyle           lipgloss.Style
+	pathPagePathSelectedDeprecatedStyle   lipgloss.Style
+	pathPagePathNormalDeprecatedStyle     lipgloss.Style
+)
+
+func loadPathPageStyles(t *theme) {
 	pathPageMethodStyle = lipgloss.NewStyle().
-				Underline(true).
-				Bold(true)
+		Underline(true).
+		Bold(true)
 
 	pathPageMethodSelectedGetStyle = pathPageMethodStyle.Copy().
-					Foreground(httpMethodSelectedGetColor)
+		Foreground(httpMethodSelectedGetColor)
 
 	pathPageMethodNormalGetStyle = pathPageMethodStyle.Copy().
-					Foreground(httpMethodGetColor)
+		Foreground(httpMethodGetColor)
 
 	pathPageMethodSelectedPostStyle = pathPageMethodStyle.Copy().
-					Foreground(httpMethodSelectedPostColor)
+		Foreground(httpMethodSelectedPostColor)
 
 	pathPageMethodNormalPostStyle = pathPageMethodStyle.Copy().
-					Foreground(httpMethodPostColor)
+		Foreground(httpMethodPostColor)
 
 	pathPageMethodSelectedDeleteStyle = pathPageMethodStyle.Copy().
-						Foreground(httpMethodSelectedDeleteColor)
+		Foreground(httpMethodSelectedDeleteColor)
 
 	pathPageMethodNormalDeleteStyle = pathPageMethodStyle.Copy().
-					Foreground(httpMethodDeleteColor)
+		Foreground(httpMethodDeleteColor)
 
 	pathPageMethodSelectedPutStyle = pathPageMethodStyle.Copy().
-					Foreground(httpMethodSelectedPutColor)
+		Foreground(httpMethodSelectedPutColor)
 
 	pathPageMethodNormalPutStyle = pathPageMethodStyle.Copy().
-					Foreground(httpMethodPutColor)
+		Foreground(httpMethodPutColor)
 
 	pathPageMethodSelectedPatchStyle = pathPageMethodStyle.Copy().
-						Foreground(httpMethodSelectedPatchColor)
+		Foreground(httpMethodSelectedPatchColor)
 
 	pathPageMethodNormalPatchStyle = pathPageMethodStyle.Copy().
-					Foreground(httpMethodPatchColor)
+		Foreground(httpMethodPatchColor)
 
 	pathPageMethodDeprecatedStyle = pathPageMethodStyle.Copy().
-					Strikethrough(true)
+		Strikethrough(true)
 
 	pathPageMethodSelectedDeprecatedStyle = pathPageMethodDeprecatedStyle.Copy().
-						Foreground(httpMethodSelectedDeprecatedColor)
+		Foreground(httpMethodSelectedDeprecatedColor)
 
 	pathPageMethodNormalDeprecatedStyle = pathPageMethodDeprecatedStyle.Copy().
-						Foreground(httpMethodDeprecatedColor)
+		Foreground(httpMethodDeprecatedColor)
 
 	pathPagePathDeprecatedStyle = lipgloss.NewStyle().
-					Strikethrough(true)
+		Strikethrough(true)
 
 	pathPagePathSelectedDeprecatedStyle = pathPagePathDeprecatedStyle.Copy().
-						Foreground(selectedColor)
+		Foreground(selectedColor)
 
 	pathPagePathNormalDeprecatedStyle = pathPagePathDeprecatedStyle.Copy().
-						Foreground(lipgloss.Color("246"))
-)
+		Foreground(t.Muted)
+}
 
 type pathPageListItem struct {
 	path *topi.Path
@@ -140,12 +160,16 @@ func (i pathPageListItem) styledDesc(selected bool, width int) string {
 	return desc
 }
 
-type pathPageListDelegate struct{}
+type pathPageListDelegate struct {
+	bookmarks *specBookmarks // can be nil not to show bookmarks
+}
 
 var _ list.ItemDelegate = (*pathPageListDelegate)(nil)
 
-func newPathPageListDelegate() pathPageListDelegate {
-	return pathPageListDelegate{}
+func newPathPageListDelegate(bookmarks *specBookmarks) pathPageListDelegate {
+	return pathPageListDelegate{
+		bookmarks: bookmarks,
+	}
 }
 
 func (d pathPageListDelegate) Height() int {
@@ -166,7 +190,7 @@ func (d pathPageListDelegate) Render(w io.Writer, m list.Model, index int, item
 
 	width := m.Width() - listNormalTitleStyle.GetPaddingLeft() - listNormalTitleStyle.GetPaddingRight()
 
-	title := i.styledTitle(selected)
+	title := i.styledTitle(selected) + styledSecuredMarker(i.path) + styledBookmarkMarker(d.bookmarks.contains(i.path))
 	desc := i.styledDesc(selected, width)
 
 	if selected {
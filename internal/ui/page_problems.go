@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lusingander/topi/internal/lint"
+)
+
+type problemsPageModel struct {
+	problems      []*lint.Problem
+	list          list.Model
+	delegateKeys  problemsPageDelegateKeyMap
+	width, height int
+}
+
+func newProblemsPageModel(problems []*lint.Problem) problemsPageModel {
+	m := problemsPageModel{
+		problems: problems,
+	}
+	m.delegateKeys = newProblemsPageDelegateKeyMap()
+	delegate := newProblemsPageListDelegate()
+	m.list = list.New(nil, delegate, 0, 0)
+	m.list.SetShowTitle(false)
+	m.list.SetShowHelp(false)
+	m.list.SetShowStatusBar(false)
+	m.list.SetShowFilter(false)
+	m.list.SetShowPagination(false)
+	m.list.KeyMap.Quit.Unbind()
+	return m
+}
+
+type problemsPageDelegateKeyMap struct {
+	enter key.Binding
+	back  key.Binding
+}
+
+func newProblemsPageDelegateKeyMap() problemsPageDelegateKeyMap {
+	return problemsPageDelegateKeyMap{
+		enter: keys.selectItem.binding("jump to operation"),
+		back:  keys.back.binding("back"),
+	}
+}
+
+func (m *problemsPageModel) SetSize(w, h int) {
+	m.width, m.height = w, h
+	m.list.SetSize(w, h)
+}
+
+func (m *problemsPageModel) updateList() {
+	items := make([]list.Item, len(m.problems))
+	for i, p := range m.problems {
+		items[i] = problemsPageListItem{p}
+	}
+	m.list.SetItems(items)
+}
+
+func (m *problemsPageModel) reset() {
+	m.list.ResetSelected()
+	m.list.ResetFilter()
+}
+
+func (m problemsPageModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m problemsPageModel) Update(msg tea.Msg) (problemsPageModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.delegateKeys.back):
+			if m.list.FilterState() != list.Filtering {
+				return m, goBack
+			}
+		case key.Matches(msg, m.delegateKeys.enter):
+			if m.list.FilterState() != list.Filtering && len(m.list.VisibleItems()) > 0 {
+				problem := m.list.SelectedItem().(problemsPageListItem).problem
+				if problem.OperationId != "" {
+					return m, selectOperation(problem.OperationId)
+				}
+				if problem.Operation != "" {
+					return m, showStatusMessage("operationId is not defined")
+				}
+				return m, nil
+			}
+		}
+	case selectProblemsMenuMsg:
+		m.updateList()
+		m.reset()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m problemsPageModel) View() string {
+	if len(m.problems) == 0 {
+		return listNormalTitleStyle.Render("No problems")
+	}
+	return m.list.View()
+}
+
+func (m problemsPageModel) statusbarInfoString() string {
+	return listStatusbarInfoString(m.list)
+}
+
+func (m problemsPageModel) statusMessageString() string {
+	return listStatusMessageString(m.list)
+}
@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lusingander/topi/internal/lint"
+	"github.com/muesli/reflow/padding"
+)
+
+var (
+	problemsPageSeverityStyle        lipgloss.Style
+	problemsPageSeverityErrorStyle   lipgloss.Style
+	problemsPageSeverityWarningStyle lipgloss.Style
+)
+
+func loadProblemsPageStyles(t *theme) {
+	problemsPageSeverityStyle = lipgloss.NewStyle().
+		Bold(true)
+
+	problemsPageSeverityErrorStyle = problemsPageSeverityStyle.Copy().
+		Foreground(t.Error)
+
+	problemsPageSeverityWarningStyle = problemsPageSeverityStyle.Copy().
+		Foreground(t.Warning)
+}
+
+type problemsPageListItem struct {
+	problem *lint.Problem
+}
+
+var _ list.Item = (*problemsPageListItem)(nil)
+
+func (i problemsPageListItem) FilterValue() string {
+	return fmt.Sprintf("%s %s %s", i.problem.Rule, i.problem.Operation, i.problem.Message)
+}
+
+func (i problemsPageListItem) styledTitle(selected bool) string {
+	var severity, rule string
+	switch i.problem.Severity {
+	case lint.SeverityError:
+		severity = problemsPageSeverityErrorStyle.Render("error")
+	default:
+		severity = problemsPageSeverityWarningStyle.Render(string(i.problem.Severity))
+	}
+	if selected {
+		rule = listSelectedTitleColorStyle.Render(i.problem.Message)
+	} else {
+		rule = listNormalTitleColorStyle.Render(i.problem.Message)
+	}
+	return fmt.Sprintf("%s %s", padding.String(severity, 8), rule)
+}
+
+func (i problemsPageListItem) styledDesc(selected bool, width int) string {
+	desc := fmt.Sprintf("%s (%s)", i.problem.Operation, i.problem.Rule)
+	if i.problem.Operation == "" {
+		desc = fmt.Sprintf("%s (%s)", i.problem.Location, i.problem.Rule)
+	}
+	if i.problem.Line > 0 {
+		desc = fmt.Sprintf("%s [line %d]", desc, i.problem.Line)
+	}
+	desc = truncateWithTail(desc, uint(width))
+	if selected {
+		desc = listSelectedDescColorStyle.Render(desc)
+	} else {
+		desc = listNormalDescColorStyle.Render(desc)
+	}
+	return desc
+}
+
+type problemsPageListDelegate struct{}
+
+var _ list.ItemDelegate = (*problemsPageListDelegate)(nil)
+
+func newProblemsPageListDelegate() problemsPageListDelegate {
+	return problemsPageListDelegate{}
+}
+
+func (d problemsPageListDelegate) Height() int {
+	return 2
+}
+
+func (d problemsPageListDelegate) Spacing() int {
+	return 1
+}
+
+func (d problemsPageListDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
+	return nil
+}
+
+func (d problemsPageListDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	i := item.(problemsPageListItem)
+	selected := index == m.Index()
+
+	width := m.Width() - listNormalTitleStyle.GetPaddingLeft() - listNormalTitleStyle.GetPaddingRight()
+
+	title := i.styledTitle(selected)
+	desc := i.styledDesc(selected, width)
+
+	if selected {
+		title = listSelectedItemStyle.Render(title)
+		desc = listSelectedItemStyle.Render(desc)
+	} else {
+		title = listNormalItemStyle.Render(title)
+		desc = listNormalItemStyle.Render(desc)
+	}
+
+	fmt.Fprintf(w, "%s\n%s", title, desc)
+}
@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lusingander/topi/internal/recent"
+	"github.com/lusingander/topi/internal/topi"
+)
+
+type recentPageModel struct {
+	doc           *topi.Document
+	recent        *specRecent
+	bookmarks     *specBookmarks
+	list          list.Model
+	delegateKeys  recentPageDelegateKeyMap
+	notFound      int
+	width, height int
+}
+
+func newRecentPageModel(doc *topi.Document, recent *specRecent, bookmarks *specBookmarks) recentPageModel {
+	m := recentPageModel{
+		doc:       doc,
+		recent:    recent,
+		bookmarks: bookmarks,
+	}
+	m.delegateKeys = newRecentPageDelegateKeyMap()
+	delegate := newPathPageListDelegate(bookmarks)
+	m.list = list.New(nil, delegate, 0, 0)
+	m.list.SetShowTitle(false)
+	m.list.SetShowHelp(false)
+	m.list.SetShowStatusBar(false)
+	m.list.SetShowFilter(false)
+	m.list.SetShowPagination(false)
+	m.list.KeyMap.Quit.Unbind()
+	return m
+}
+
+type recentPageDelegateKeyMap struct {
+	enter    key.Binding
+	back     key.Binding
+	bookmark key.Binding
+}
+
+func newRecentPageDelegateKeyMap() recentPageDelegateKeyMap {
+	return recentPageDelegateKeyMap{
+		enter:    keys.selectItem.binding("select"),
+		back:     keys.back.binding("back"),
+		bookmark: keys.bookmark.binding("bookmark"),
+	}
+}
+
+func (m *recentPageModel) SetSize(w, h int) {
+	m.width, m.height = w, h
+	m.list.SetSize(w, h)
+}
+
+func (m *recentPageModel) updateList() {
+	paths, notFound := recent.Resolve(m.doc, m.recent.list())
+	items := make([]list.Item, len(paths))
+	for i, path := range paths {
+		items[i] = pathPageListItem{path}
+	}
+	m.notFound = notFound
+	m.list.SetItems(items)
+}
+
+func (m *recentPageModel) toggleBookmark() tea.Cmd {
+	item, ok := m.list.SelectedItem().(pathPageListItem)
+	if !ok {
+		return nil
+	}
+	return m.bookmarks.toggle(item.path)
+}
+
+func (m *recentPageModel) reset() {
+	m.list.ResetSelected()
+	m.list.ResetFilter()
+}
+
+func (m recentPageModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m recentPageModel) Update(msg tea.Msg) (recentPageModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.delegateKeys.back):
+			if m.list.FilterState() != list.Filtering {
+				return m, goBack
+			}
+		case key.Matches(msg, m.delegateKeys.enter):
+			if m.list.FilterState() != list.Filtering {
+				if item, ok := m.list.SelectedItem().(pathPageListItem); ok {
+					return m, selectOperation(item.path.OperationId)
+				}
+				return m, nil
+			}
+		case key.Matches(msg, m.delegateKeys.bookmark):
+			if m.list.FilterState() != list.Filtering {
+				return m, m.toggleBookmark()
+			}
+		}
+	case selectRecentMenuMsg:
+		m.updateList()
+		m.reset()
+		return m, nil
+	case goBackMsg:
+		// the operation opened from this page is moved to the top
+		m.updateList()
+		m.list.ResetSelected()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m recentPageModel) View() string {
+	return m.list.View()
+}
+
+func (m recentPageModel) statusbarInfoString() string {
+	return listStatusbarInfoString(m.list)
+}
+
+func (m recentPageModel) statusMessageString() string {
+	if s := listStatusMessageString(m.list); s != "" {
+		return s
+	}
+	if len(m.list.Items()) == 0 && m.notFound == 0 {
+		return "No recently viewed operations"
+	}
+	if m.notFound > 0 {
+		return fmt.Sprintf("%d recent operations not found in this spec", m.notFound)
+	}
+	return ""
+}
@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lusingander/topi/internal/topi"
+)
+
+type searchPageModel struct {
+	docs          []*topi.Document
+	list          list.Model
+	delegateKeys  searchPageDelegateKeyMap
+	width, height int
+}
+
+func newSearchPageModel(docs []*topi.Document) searchPageModel {
+	m := searchPageModel{
+		docs: docs,
+	}
+	m.delegateKeys = newSearchPageDelegateKeyMap()
+	delegate := newSearchPageListDelegate()
+	m.list = list.New(nil, delegate, 0, 0)
+	m.list.SetShowTitle(false)
+	m.list.SetShowHelp(false)
+	m.list.SetShowStatusBar(false)
+	m.list.SetShowFilter(false)
+	m.list.SetShowPagination(false)
+	m.list.KeyMap.Quit.Unbind()
+	return m
+}
+
+type searchPageDelegateKeyMap struct {
+	enter key.Binding
+	back  key.Binding
+}
+
+func newSearchPageDelegateKeyMap() searchPageDelegateKeyMap {
+	return searchPageDelegateKeyMap{
+		enter: keys.selectItem.binding("select"),
+		back:  keys.back.binding("back"),
+	}
+}
+
+func (m *searchPageModel) SetSize(w, h int) {
+	m.width, m.height = w, h
+	m.list.SetSize(w, h)
+}
+
+func (m *searchPageModel) updateList() {
+	items := make([]list.Item, 0)
+	for i, doc := range m.docs {
+		for _, tag := range doc.Tags {
+			for _, path := range listedPaths(doc.TagPathMap[tag.Name]) {
+				item := searchPageListItem{
+					path:  path,
+					spec:  doc.Meta.FileName,
+					index: i,
+				}
+				items = append(items, item)
+			}
+		}
+	}
+	m.list.SetItems(items)
+}
+
+func (m *searchPageModel) reset() {
+	m.list.ResetSelected()
+	m.list.ResetFilter()
+}
+
+func (m searchPageModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m searchPageModel) Update(msg tea.Msg) (searchPageModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.delegateKeys.back):
+			if m.list.FilterState() != list.Filtering {
+				return m, goBack
+			}
+		case key.Matches(msg, m.delegateKeys.enter):
+			if m.list.FilterState() != list.Filtering && len(m.list.VisibleItems()) > 0 {
+				item := m.list.SelectedItem().(searchPageListItem)
+				return m, selectSearchResult(item.index, item.path.OperationId)
+			}
+		}
+	case selectSearchMenuMsg:
+		m.updateList()
+		m.reset()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m searchPageModel) View() string {
+	return m.list.View()
+}
+
+func (m searchPageModel) statusbarInfoString() string {
+	return listStatusbarInfoString(m.list)
+}
+
+func (m searchPageModel) statusMessageString() string {
+	return listStatusMessageString(m.list)
+}
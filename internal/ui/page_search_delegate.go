@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lusingander/topi/internal/topi"
+)
+
+type searchPageListItem struct {
+	path  *topi.Path
+	spec  string
+	index int
+}
+
+var _ list.Item = (*searchPageListItem)(nil)
+
+func (i searchPageListItem) FilterValue() string {
+	return fmt.Sprintf("%s %s %s %s", i.path.Method, i.path.UriPath, i.path.Summary, i.spec)
+}
+
+func (i searchPageListItem) styledTitle(selected bool) string {
+	return pathPageListItem{i.path}.styledTitle(selected)
+}
+
+func (i searchPageListItem) styledDesc(selected bool, width int) string {
+	desc := fmt.Sprintf("[%s] %s", i.spec, i.path.Summary)
+	desc = truncateWithTail(desc, uint(width))
+	if selected {
+		desc = listSelectedDescColorStyle.Render(desc)
+	} else {
+		desc = listNormalDescColorStyle.Render(desc)
+	}
+	return desc
+}
+
+type searchPageListDelegate struct{}
+
+var _ list.ItemDelegate = (*searchPageListDelegate)(nil)
+
+func newSearchPageListDelegate() searchPageListDelegate {
+	return searchPageListDelegate{}
+}
+
+func (d searchPageListDelegate) Height() int {
+	return 2
+}
+
+func (d searchPageListDelegate) Spacing() int {
+	return 1
+}
+
+func (d searchPageListDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
+	return nil
+}
+
+func (d searchPageListDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	i := item.(searchPageListItem)
+	selected := index == m.Index()
+
+	width := m.Width() - listNormalTitleStyle.GetPaddingLeft() - listNormalTitleStyle.GetPaddingRight()
+
+	title := i.styledTitle(selected) + styledSecuredMarker(i.path)
+	desc := i.styledDesc(selected, width)
+
+	if selected {
+		title = listSelectedItemStyle.Render(title)
+		desc = listSelectedItemStyle.Render(desc)
+	} else {
+		title = listNormalItemStyle.Render(title)
+		desc = listNormalItemStyle.Render(desc)
+	}
+
+	fmt.Fprintf(w, "%s\n%s", title, desc)
+}
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lusingander/topi/internal/openapi"
+	"github.com/lusingander/topi/internal/topi"
+)
+
+func TestSearchPageFilter(t *testing.T) {
+	doc, err := openapi.Load(filepath.Join("testdata", "show.yaml"))
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+	// the same spec opened twice is searched as different specs
+	m := newSearchPageModel([]*topi.Document{doc, doc})
+	m.updateList()
+
+	items := m.list.Items()
+	targets := make([]string, len(items))
+	for i, item := range items {
+		targets[i] = item.FilterValue()
+	}
+	if len(items) != 6 {
+		t.Fatalf("got=%v, want=%v", len(items), 6)
+	}
+
+	tests := []struct {
+		term string
+		want []string // operationIds of the matched items in all specs
+	}{
+		{"delete", []string{"deletePet", "deletePet"}},
+		{"POST /stores", []string{"createPet", "createPet"}},
+		{"no such operation", []string{}},
+	}
+	for _, test := range tests {
+		got := make([]string, 0)
+		for _, r := range list.DefaultFilter(test.term, targets) {
+			got = append(got, items[r.Index].(searchPageListItem).path.OperationId)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got=%v, want=%v", test.term, got, test.want)
+		}
+	}
+}
+
+func TestSearchPageSelectEmpty(t *testing.T) {
+	m := newSearchPageModel(nil)
+	m.updateList()
+	// must not panic without items
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Errorf("no command is expected")
+	}
+}
@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lusingander/topi/internal/topi"
+)
+
+type securityPageModel struct {
+	doc           *topi.Document
+	list          list.Model
+	delegateKeys  securityPageDelegateKeyMap
+	width, height int
+}
+
+func newSecurityPageModel(doc *topi.Document) securityPageModel {
+	m := securityPageModel{
+		doc: doc,
+	}
+	m.delegateKeys = newSecurityPageDelegateKeyMap()
+	delegate := newSecurityPageListDelegate()
+	m.list = list.New(nil, delegate, 0, 0)
+	m.list.SetShowTitle(false)
+	m.list.SetShowHelp(false)
+	m.list.SetShowStatusBar(false)
+	m.list.SetShowFilter(false)
+	m.list.SetShowPagination(false)
+	m.list.KeyMap.Quit.Unbind()
+	return m
+}
+
+type securityPageDelegateKeyMap struct {
+	back  key.Binding
+	enter key.Binding
+}
+
+func newSecurityPageDelegateKeyMap() securityPageDelegateKeyMap {
+	return securityPageDelegateKeyMap{
+		back:  keys.back.binding("back"),
+		enter: keys.selectItem.binding("select"),
+	}
+}
+
+func (m *securityPageModel) updateItems() {
+	items := make([]list.Item, 0)
+	if m.doc.Components != nil {
+		for _, scheme := range m.doc.Components.SecuritySchemes {
+			item := securityPageListItem{
+				scheme:     scheme,
+				operations: countOperations(m.doc.ScopeOperations(scheme.Key)),
+			}
+			items = append(items, item)
+		}
+	}
+	m.list.SetItems(items)
+}
+
+func countOperations(scopeOperations map[string][]*topi.Path) int {
+	ops := make(map[*topi.Path]bool)
+	for _, paths := range scopeOperations {
+		for _, p := range paths {
+			ops[p] = true
+		}
+	}
+	return len(ops)
+}
+
+func (m *securityPageModel) reset() {
+	m.list.ResetSelected()
+	m.list.ResetFilter()
+}
+
+func (m *securityPageModel) SetSize(w, h int) {
+	m.width, m.height = w, h
+	m.list.SetSize(w, h)
+}
+
+func (m securityPageModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m securityPageModel) Update(msg tea.Msg) (securityPageModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.delegateKeys.back):
+			if m.list.FilterState() != list.Filtering {
+				return m, goBack
+			}
+		case key.Matches(msg, m.delegateKeys.enter):
+			if m.list.FilterState() != list.Filtering && len(m.list.VisibleItems()) > 0 {
+				scheme := m.list.SelectedItem().(securityPageListItem).scheme
+				return m, selectSecurityScheme(scheme.Key)
+			}
+		}
+	case selectSecurityMenuMsg:
+		m.updateItems()
+		m.reset()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m securityPageModel) View() string {
+	if len(m.list.Items()) == 0 {
+		return listNormalTitleStyle.Render("No security schemes")
+	}
+	return m.list.View()
+}
+
+func (m securityPageModel) statusbarInfoString() string {
+	return listStatusbarInfoString(m.list)
+}
+
+func (m securityPageModel) statusMessageString() string {
+	return listStatusMessageString(m.list)
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lusingander/topi/internal/topi"
+)
+
+type securityPageListItem struct {
+	scheme     *topi.SecurityScheme
+	operations int // number of the operations requiring the scheme
+}
+
+var _ list.Item = (*securityPageListItem)(nil)
+
+func (i securityPageListItem) FilterValue() string {
+	return i.scheme.Key
+}
+
+func (i securityPageListItem) desc(width int) string {
+	desc := fmt.Sprintf("%s, %d operations", i.scheme.TypeStr(), i.operations)
+	if i.operations == 1 {
+		desc = fmt.Sprintf("%s, 1 operation", i.scheme.TypeStr())
+	}
+	return truncateWithTail(desc, uint(width))
+}
+
+type securityPageListDelegate struct{}
+
+var _ list.ItemDelegate = (*securityPageListDelegate)(nil)
+
+func newSecurityPageListDelegate() securityPageListDelegate {
+	return securityPageListDelegate{}
+}
+
+func (d securityPageListDelegate) Height() int {
+	return 2
+}
+
+func (d securityPageListDelegate) Spacing() int {
+	return 1
+}
+
+func (d securityPageListDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
+	return nil
+}
+
+func (d securityPageListDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	i := item.(securityPageListItem)
+	selected := index == m.Index()
+
+	width := m.Width() - listNormalTitleStyle.GetPaddingLeft() - listNormalTitleStyle.GetPaddingRight()
+
+	title := i.scheme.Key
+	desc := i.desc(width)
+
+	if selected {
+		title = listSelectedTitleStyle.Render(title)
+		desc = listSelectedDescStyle.Render(desc)
+	} else {
+		title = listNormalTitleStyle.Render(title)
+		desc = listNormalDescStyle.Render(desc)
+	}
+
+	fmt.Fprintf(w, "%s\n%s", title, desc)
+}
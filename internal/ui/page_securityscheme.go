@@ -0,0 +1,316 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lusingander/topi/internal/oauth"
+	"github.com/lusingander/topi/internal/topi"
+)
+
+var (
+	securitySchemePageSelectedPathStyle lipgloss.Style
+	securitySchemePageSummaryStyle      lipgloss.Style
+)
+
+func loadSecuritySchemePageStyles(t *theme) {
+	securitySchemePageSelectedPathStyle = lipgloss.NewStyle().
+		Background(t.SelectedLinkBg).
+		Foreground(t.SelectedLinkFg)
+
+	securitySchemePageSummaryStyle = lipgloss.NewStyle().
+		Foreground(t.Muted)
+}
+
+// securitySchemeScope is the scope of the security scheme and the operations requiring it.
+type securitySchemeScope struct {
+	name       string // empty for the operations requiring no scopes
+	detail     string
+	operations []*topi.Path
+}
+
+type securitySchemePageModel struct {
+	doc           *topi.Document
+	tokens        *oauth.Store
+	oauthConfigs  map[string]oauth.Config // by the key of the security scheme
+	oauthClient   *http.Client
+	scheme        *topi.SecurityScheme
+	scopes        []*securitySchemeScope
+	viewport      viewport.Model
+	delegateKeys  securitySchemePageDelegateKeyMap
+	width, height int
+
+	selected int // index of the selected operation in all scopes, -1 if not selected
+}
+
+func newSecuritySchemePageModel(doc *topi.Document, tokens *oauth.Store, oauthConfigs map[string]oauth.Config, oauthClient *http.Client) securitySchemePageModel {
+	m := securitySchemePageModel{
+		doc:          doc,
+		tokens:       tokens,
+		oauthConfigs: oauthConfigs,
+		oauthClient:  oauthClient,
+		selected:     -1,
+	}
+	m.delegateKeys = newSecuritySchemePageDelegateKeyMap()
+	m.viewport = viewport.New(0, 0)
+	return m
+}
+
+type securitySchemePageDelegateKeyMap struct {
+	back     key.Binding
+	tab      key.Binding
+	shiftTab key.Binding
+	enter    key.Binding
+	token    key.Binding
+}
+
+func newSecuritySchemePageDelegateKeyMap() securitySchemePageDelegateKeyMap {
+	return securitySchemePageDelegateKeyMap{
+		back:     keys.back.binding("back"),
+		tab:      keys.nextItem.binding("select next operation"),
+		shiftTab: keys.prevItem.binding("select prev operation"),
+		enter:    keys.selectItem.binding("jump to operation"),
+		token:    keys.token.binding("acquire token"),
+	}
+}
+
+func (m *securitySchemePageModel) SetSize(w, h int) {
+	m.width, m.height = w, h
+	m.viewport.Width, m.viewport.Height = w, h
+	m.updateContent()
+}
+
+func (m *securitySchemePageModel) updateScheme(key string) {
+	m.scheme = m.doc.FindSecurityScheme(key)
+	m.scopes = nil
+	if m.scheme == nil {
+		return
+	}
+	m.scopes = securitySchemeScopes(m.scheme, m.doc.ScopeOperations(key))
+}
+
+// securitySchemeScopes returns the scopes defined in the flows, the scopes only used by the operations,
+// and the operations requiring no scopes in this order.
+func securitySchemeScopes(scheme *topi.SecurityScheme, scopeOperations map[string][]*topi.Path) []*securitySchemeScope {
+	ret := make([]*securitySchemeScope, 0)
+	defined := make(map[string]bool)
+	if scheme.OAuthFlows != nil {
+		for _, s := range scheme.OAuthFlows.Scopes() {
+			defined[s.Name] = true
+			ret = append(ret, &securitySchemeScope{name: s.Name, detail: s.Detail, operations: scopeOperations[s.Name]})
+		}
+	}
+	undefined := make([]string, 0)
+	for name := range scopeOperations {
+		if name != "" && !defined[name] {
+			undefined = append(undefined, name)
+		}
+	}
+	sort.Strings(undefined)
+	for _, name := range undefined {
+		ret = append(ret, &securitySchemeScope{name: name, operations: scopeOperations[name]})
+	}
+	if ops := scopeOperations[""]; len(ops) > 0 {
+		ret = append(ret, &securitySchemeScope{operations: ops})
+	}
+	return ret
+}
+
+func (m *securitySchemePageModel) operations() []*topi.Path {
+	ret := make([]*topi.Path, 0)
+	for _, s := range m.scopes {
+		ret = append(ret, s.operations...)
+	}
+	return ret
+}
+
+func (m *securitySchemePageModel) reset() {
+	m.selected = -1
+	if len(m.operations()) > 0 {
+		m.selected = 0
+	}
+	m.viewport.GotoTop()
+}
+
+func (m *securitySchemePageModel) updateContent() {
+	if m.scheme == nil {
+		return
+	}
+	r, _ := markdownRenderer(m.width - 10)
+
+	var content strings.Builder
+
+	title := infoPageTitleStyle.Render(m.scheme.Key)
+	typ := infoPageVersionStyle.Render(fmt.Sprintf("(%s)", m.scheme.TypeStr()))
+	titleBar := infoPageTitleBarStyle.Render(fmt.Sprintf("%s  %s", title, typ))
+	content.WriteString(titleBar)
+
+	content.WriteString(styledSecuritySchemeDetails(m.scheme, r))
+
+	if acquirable(m.scheme) {
+		h := infoPageSectionHeaderStyle.Render("Token")
+		content.WriteString(infoPageItemStyle.Render(h))
+		content.WriteString(infoPageAuthenticationItemStyle.Render(m.styledToken()))
+	}
+
+	h := infoPageSectionHeaderStyle.Render("Operations")
+	content.WriteString(infoPageItemStyle.Render(h))
+
+	selectedLine := -1
+	i := 0
+	for _, scope := range m.scopes {
+		var h string
+		switch {
+		case scope.name == "":
+			h = infoPageSectionSubHeaderStyle.Render("(no scopes)")
+		case scope.detail != "":
+			h = fmt.Sprintf("%s - %s", infoPageSectionSubHeaderStyle.Render(scope.name), infoPageAuthenticationOAuthScopeDescColorStyle.Render(scope.detail))
+		default:
+			h = infoPageSectionSubHeaderStyle.Render(scope.name)
+		}
+		content.WriteString(infoPageItemStyle.Render(h))
+
+		if len(scope.operations) == 0 {
+			content.WriteString(infoPageAuthenticationItemStyle.Render(securitySchemePageSummaryStyle.Render("No operations")))
+			continue
+		}
+		ss := make([]string, len(scope.operations))
+		for j, op := range scope.operations {
+			path := op.UriPath
+			if i == m.selected {
+				path = securitySchemePageSelectedPathStyle.Render(path)
+				// the block starts at the last line of the content with the top padding
+				selectedLine = strings.Count(content.String(), "\n") + 1 + j
+			}
+			ss[j] = fmt.Sprintf("%s %s", styledMethod(op), path)
+			if op.Summary != "" {
+				ss[j] += "  " + securitySchemePageSummaryStyle.Render(op.Summary)
+			}
+			i++
+		}
+		content.WriteString(infoPageAuthenticationItemStyle.Render(strings.Join(ss, "\n")))
+	}
+
+	m.viewport.SetContent(content.String())
+	m.scrollTo(selectedLine)
+}
+
+func (m *securitySchemePageModel) styledToken() string {
+	t := m.tokens.Get(m.scheme.Key)
+	if t == nil {
+		if _, ok := m.oauthConfigs[m.scheme.Key]; !ok {
+			return securitySchemePageSummaryStyle.Render(fmt.Sprintf("Set oauth.%s in the config file to acquire the token", m.scheme.Key))
+		}
+		return securitySchemePageSummaryStyle.Render(fmt.Sprintf("Not acquired (press %s to acquire)", keys.token[0]))
+	}
+	headerKey := infoPageAuthenticationItemKeyColorStyle.Render("Authorization:")
+	headerValue := infoPageAuthenticationItemValueColorStyle.Render(t.AuthorizationHeader())
+	values := fmt.Sprintf("%s %s", headerKey, headerValue)
+	if !t.Expiry.IsZero() {
+		expiryKey := infoPageAuthenticationItemKeyColorStyle.Render("Expires:")
+		expiryValue := infoPageAuthenticationItemValueColorStyle.Render(t.Expiry.Format("2006-01-02 15:04:05"))
+		values += fmt.Sprintf("\n%s %s", expiryKey, expiryValue)
+	}
+	if len(t.Scopes) > 0 {
+		scopesKey := infoPageAuthenticationItemKeyColorStyle.Render("Scopes:")
+		scopesValue := infoPageAuthenticationItemValueColorStyle.Render(strings.Join(t.Scopes, " "))
+		values += fmt.Sprintf("\n%s %s", scopesKey, scopesValue)
+	}
+	return values
+}
+
+func (m *securitySchemePageModel) acquireToken() tea.Cmd {
+	if !acquirable(m.scheme) {
+		return showStatusMessage(fmt.Sprintf("token can not be acquired for %s scheme", m.scheme.TypeStr()))
+	}
+	cfg, ok := m.oauthConfigs[m.scheme.Key]
+	if !ok {
+		return showStatusMessage(fmt.Sprintf("oauth.%s is not set in the config file", m.scheme.Key))
+	}
+	return tea.Batch(
+		showStatusMessage(fmt.Sprintf("acquiring the token of %s...", m.scheme.Key)),
+		acquireToken(m.scheme, cfg, m.oauthClient, m.tokens),
+	)
+}
+
+// scrollTo scrolls the viewport to show the line if it is out of the view.
+func (m *securitySchemePageModel) scrollTo(line int) {
+	if line < 0 {
+		return
+	}
+	if line < m.viewport.YOffset {
+		m.viewport.SetYOffset(line)
+	} else if line >= m.viewport.YOffset+m.viewport.Height {
+		m.viewport.SetYOffset(line - m.viewport.Height + 1)
+	}
+}
+
+func (m *securitySchemePageModel) selectOperation(reverse bool) {
+	n := len(m.operations())
+	if n == 0 {
+		return
+	}
+	if reverse {
+		m.selected = ((m.selected-1)%n + n) % n
+	} else {
+		m.selected = (m.selected + 1) % n
+	}
+}
+
+func (m securitySchemePageModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m securitySchemePageModel) Update(msg tea.Msg) (securitySchemePageModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.delegateKeys.back):
+			return m, goBack
+		case key.Matches(msg, m.delegateKeys.tab):
+			m.selectOperation(false)
+			m.updateContent()
+			return m, nil
+		case key.Matches(msg, m.delegateKeys.shiftTab):
+			m.selectOperation(true)
+			m.updateContent()
+			return m, nil
+		case key.Matches(msg, m.delegateKeys.enter):
+			ops := m.operations()
+			if m.selected < 0 || m.selected >= len(ops) {
+				return m, nil
+			}
+			op := ops[m.selected]
+			if op.OperationId == "" {
+				return m, showStatusMessage("operationId is not defined")
+			}
+			return m, selectOperation(op.OperationId)
+		case key.Matches(msg, m.delegateKeys.token):
+			if m.scheme == nil {
+				return m, nil
+			}
+			return m, m.acquireToken()
+		}
+	case selectSecuritySchemeMsg:
+		m.updateScheme(msg.key)
+		m.reset()
+		m.updateContent()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+func (m securitySchemePageModel) View() string {
+	if m.scheme == nil {
+		return listNormalTitleStyle.Render("Security scheme not found")
+	}
+	return m.viewport.View()
+}
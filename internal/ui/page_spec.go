@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lusingander/topi/internal/topi"
+)
+
+type specPageModel struct {
+	list          list.Model
+	delegateKeys  specPageDelegateKeyMap
+	width, height int
+}
+
+func newSpecPageModel(docs []*topi.Document) specPageModel {
+	m := specPageModel{}
+	m.delegateKeys = newSpecPageDelegateKeyMap()
+	delegate := newSpecPageListDelegate()
+	items := make([]list.Item, len(docs))
+	for i, doc := range docs {
+		items[i] = specPageListItem{doc: doc, index: i}
+	}
+	m.list = list.New(items, delegate, 0, 0)
+	m.list.SetShowTitle(false)
+	m.list.SetShowHelp(false)
+	m.list.SetShowStatusBar(false)
+	m.list.SetShowFilter(false)
+	m.list.SetShowPagination(false)
+	m.list.KeyMap.Quit.Unbind()
+	return m
+}
+
+type specPageDelegateKeyMap struct {
+	enter key.Binding
+}
+
+func newSpecPageDelegateKeyMap() specPageDelegateKeyMap {
+	return specPageDelegateKeyMap{
+		enter: keys.selectItem.binding("select"),
+	}
+}
+
+func (m *specPageModel) SetSize(w, h int) {
+	m.width, m.height = w, h
+	m.list.SetSize(w, h)
+}
+
+func (m specPageModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m specPageModel) Update(msg tea.Msg) (specPageModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.delegateKeys.enter):
+			if m.list.FilterState() != list.Filtering && len(m.list.VisibleItems()) > 0 {
+				item := m.list.SelectedItem().(specPageListItem)
+				return m, selectSpec(item.index)
+			}
+		}
+	}
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m specPageModel) View() string {
+	return m.list.View()
+}
+
+func (m specPageModel) statusbarInfoString() string {
+	return listStatusbarInfoString(m.list)
+}
+
+func (m specPageModel) statusMessageString() string {
+	return listStatusMessageString(m.list)
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lusingander/topi/internal/topi"
+)
+
+type specPageListItem struct {
+	doc   *topi.Document
+	index int
+}
+
+var _ list.Item = (*specPageListItem)(nil)
+
+func (i specPageListItem) FilterValue() string {
+	return i.doc.Meta.FileName + " " + i.doc.Info.Title
+}
+
+func (i specPageListItem) title() string {
+	if i.doc.Info.Title == "" {
+		return i.doc.Meta.FileName
+	}
+	return fmt.Sprintf("%s (%s)", i.doc.Info.Title, i.doc.Info.Version)
+}
+
+func (i specPageListItem) desc(width int) string {
+	return truncateWithTail(i.doc.Meta.FullPath, uint(width))
+}
+
+type specPageListDelegate struct{}
+
+var _ list.ItemDelegate = (*specPageListDelegate)(nil)
+
+func newSpecPageListDelegate() specPageListDelegate {
+	return specPageListDelegate{}
+}
+
+func (d specPageListDelegate) Height() int {
+	return 2
+}
+
+func (d specPageListDelegate) Spacing() int {
+	return 1
+}
+
+func (d specPageListDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
+	return nil
+}
+
+func (d specPageListDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	i := item.(specPageListItem)
+	selected := index == m.Index()
+
+	width := m.Width() - listNormalTitleStyle.GetPaddingLeft() - listNormalTitleStyle.GetPaddingRight()
+
+	title := i.title()
+	desc := i.desc(width)
+
+	if selected {
+		title = listSelectedTitleStyle.Render(title)
+		desc = listSelectedDescStyle.Render(desc)
+	} else {
+		title = listNormalTitleStyle.Render(title)
+		desc = listNormalDescStyle.Render(desc)
+	}
+
+	fmt.Fprintf(w, "%s\n%s", title, desc)
+}
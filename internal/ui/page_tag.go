@@ -37,14 +37,8 @@ type tagPageDelegateKeyMap struct {
 
 func newTagPageDelegateKeyMap() tagPageDelegateKeyMap {
 	return tagPageDelegateKeyMap{
-		back: key.NewBinding(
-			key.WithKeys("backspace", "ctrl+h"),
-			key.WithHelp("backspace", "back"),
-		),
-		enter: key.NewBinding(
-			key.WithKeys("enter"),
-			key.WithHelp("enter", "select"),
-		),
+		back:  keys.back.binding("back"),
+		enter: keys.selectItem.binding("select"),
 	}
 }
 
@@ -52,7 +46,7 @@ func (m *tagPageModel) updateItems() {
 	tags := m.doc.Tags
 	items := make([]list.Item, 0)
 	for _, tag := range tags {
-		if len(m.doc.TagPathMap[tag.Name]) > 0 {
+		if len(listedPaths(m.doc.TagPathMap[tag.Name])) > 0 {
 			item := tagPageListItem{tag}
 			items = append(items, item)
 		}
@@ -9,17 +9,19 @@ import (
 
 type tagPathsPageModel struct {
 	doc           *topi.Document
+	bookmarks     *specBookmarks
 	list          list.Model
 	delegateKeys  tagPathsPageDelegateKeyMap
 	width, height int
 }
 
-func newTagPathsPageModel(doc *topi.Document) tagPathsPageModel {
+func newTagPathsPageModel(doc *topi.Document, bookmarks *specBookmarks) tagPathsPageModel {
 	m := tagPathsPageModel{
-		doc: doc,
+		doc:       doc,
+		bookmarks: bookmarks,
 	}
 	m.delegateKeys = newTagPathsPageDelegateKeyMap()
-	delegate := newTagPathsPageListDelegate()
+	delegate := newTagPathsPageListDelegate(bookmarks)
 	m.list = list.New(nil, delegate, 0, 0)
 	m.list.SetShowTitle(false)
 	m.list.SetShowHelp(false)
@@ -31,20 +33,16 @@ func newTagPathsPageModel(doc *topi.Document) tagPathsPageModel {
 }
 
 type tagPathsPageDelegateKeyMap struct {
-	enter key.Binding
-	back  key.Binding
+	enter    key.Binding
+	back     key.Binding
+	bookmark key.Binding
 }
 
 func newTagPathsPageDelegateKeyMap() tagPathsPageDelegateKeyMap {
 	return tagPathsPageDelegateKeyMap{
-		enter: key.NewBinding(
-			key.WithKeys("enter"),
-			key.WithHelp("enter", "select"),
-		),
-		back: key.NewBinding(
-			key.WithKeys("backspace", "ctrl+h"),
-			key.WithHelp("backspace", "back"),
-		),
+		enter:    keys.selectItem.binding("select"),
+		back:     keys.back.binding("back"),
+		bookmark: keys.bookmark.binding("bookmark"),
 	}
 }
 
@@ -55,7 +53,7 @@ func (m *tagPathsPageModel) SetSize(w, h int) {
 
 func (m *tagPathsPageModel) updateList(tag string) {
 	m.list.ResetSelected()
-	paths := m.doc.TagPathMap[tag]
+	paths := listedPaths(m.doc.TagPathMap[tag])
 	items := make([]list.Item, len(paths))
 	for i, path := range paths {
 		item := tagPathsPageListItem{path}
@@ -64,6 +62,14 @@ func (m *tagPathsPageModel) updateList(tag string) {
 	m.list.SetItems(items)
 }
 
+func (m *tagPathsPageModel) toggleBookmark() tea.Cmd {
+	item, ok := m.list.SelectedItem().(tagPathsPageListItem)
+	if !ok {
+		return nil
+	}
+	return m.bookmarks.toggle(item.path)
+}
+
 func (m *tagPathsPageModel) reset() {
 	m.list.ResetSelected()
 	m.list.ResetFilter()
@@ -86,6 +92,10 @@ func (m tagPathsPageModel) Update(msg tea.Msg) (tagPathsPageModel, tea.Cmd) {
 				path := m.list.SelectedItem().(tagPathsPageListItem).path
 				return m, selectOperation(path.OperationId)
 			}
+		case key.Matches(msg, m.delegateKeys.bookmark):
+			if m.list.FilterState() != list.Filtering {
+				return m, m.toggleBookmark()
+			}
 		}
 	case selectTagMsg:
 		m.updateList(msg.tag)
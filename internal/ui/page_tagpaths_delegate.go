@@ -13,58 +13,78 @@ import (
 )
 
 var (
+	tagPathsPageMethodStyle                   lipgloss.Style
+	tagPathsPageMethodSelectedGetStyle        lipgloss.Style
+	tagPathsPageMethodNormalGetStyle          lipgloss.Style
+	tagPathsPageMethodSelectedPostStyle       lipgloss.Style
+	tagPathsPageMethodNormalPostStyle         lipgloss.Style
+	tagPathsPageMethodSelectedDeleteStyle     lipgloss.Style
+	tagPathsPageMethodNormalDeleteStyle       lipgloss.Style
+	tagPathsPageMethodSelectedPutStyle        lipgloss.Style
+	tagPathsPageMethodNormalPutStyle          lipgloss.Style
+	tagPathsPageMethodSelectedPatchStyle      lipgloss.Style
+	tagPathsPageMethodNormalPatchStyle        lipgloss.Style
+	tagPathsPageMethodDeprecatedStyle         lipgloss.Style
+	tagPathsPageMethodSelectedDeprecatedStyle lipgloss.Style
+	tagPathsPageMethodNormalDeprecatedStyle   lipgloss.Style
+	tagPathsPagePathDeprecatedStyle           lipgloss.Style
+	tagPathsPagePathSelectedDeprecatedStyle   lipgloss.Style
+	tagPathsPagePathNormalDeprecatedStyle     lipgloss.Style
+)
+
+func loadTagPathsPageStyles(t *theme) {
 	tagPathsPageMethodStyle = lipgloss.NewStyle().
-				Underline(true).
-				Bold(true)
+		Underline(true).
+		Bold(true)
 
 	tagPathsPageMethodSelectedGetStyle = tagPathsPageMethodStyle.Copy().
-						Foreground(httpMethodSelectedGetColor)
+		Foreground(httpMethodSelectedGetColor)
 
 	tagPathsPageMethodNormalGetStyle = tagPathsPageMethodStyle.Copy().
-						Foreground(httpMethodGetColor)
+		Foreground(httpMethodGetColor)
 
 	tagPathsPageMethodSelectedPostStyle = tagPathsPageMethodStyle.Copy().
-						Foreground(httpMethodSelectedPostColor)
+		Foreground(httpMethodSelectedPostColor)
 
 	tagPathsPageMethodNormalPostStyle = tagPathsPageMethodStyle.Copy().
-						Foreground(httpMethodPostColor)
+		Foreground(httpMethodPostColor)
 
 	tagPathsPageMethodSelectedDeleteStyle = tagPathsPageMethodStyle.Copy().
-						Foreground(httpMethodSelectedDeleteColor)
+		Foreground(httpMethodSelectedDeleteColor)
 
 	tagPathsPageMethodNormalDeleteStyle = tagPathsPageMethodStyle.Copy().
-						Foreground(httpMethodDeleteColor)
+		Foreground(httpMethodDeleteColor)
 
 	tagPathsPageMethodSelectedPutStyle = tagPathsPageMethodStyle.Copy().
-						Foreground(httpMethodSelectedPutColor)
+		Foreground(httpMethodSelectedPutColor)
 
 	tagPathsPageMethodNormalPutStyle = tagPathsPageMethodStyle.Copy().
-						Foreground(httpMethodPutColor)
+		Foreground(httpMethodPutColor)
 
 	tagPathsPageMethodSelectedPatchStyle = tagPathsPageMethodStyle.Copy().
-						Foreground(httpMethodSelectedPatchColor)
+		Foreground(httpMethodSelectedPatchColor)
 
 	tagPathsPageMethodNormalPatchStyle = tagPathsPageMethodStyle.Copy().
-						Foreground(httpMethodPatchColor)
+		Foreground(httpMethodPatchColor)
 
 	tagPathsPageMethodDeprecatedStyle = tagPathsPageMethodStyle.Copy().
-						Strikethrough(true)
+		Strikethrough(true)
 
 	tagPathsPageMethodSelectedDeprecatedStyle = tagPathsPageMethodDeprecatedStyle.Copy().
-							Foreground(httpMethodSelectedDeprecatedColor)
+		Foreground(httpMethodSelectedDeprecatedColor)
 
 	tagPathsPageMethodNormalDeprecatedStyle = tagPathsPageMethodDeprecatedStyle.Copy().
-						Foreground(httpMethodDeprecatedColor)
+		Foreground(httpMethodDeprecatedColor)
 
 	tagPathsPagePathDeprecatedStyle = lipgloss.NewStyle().
-					Strikethrough(true)
+		Strikethrough(true)
 
 	tagPathsPagePathSelectedDeprecatedStyle = tagPathsPagePathDeprecatedStyle.Copy().
-						Foreground(selectedColor)
+		Foreground(selectedColor)
 
 	tagPathsPagePathNormalDeprecatedStyle = tagPathsPagePathDeprecatedStyle.Copy().
-						Foreground(lipgloss.Color("246"))
-)
+		Foreground(t.Muted)
+}
 
 type tagPathsPageListItem struct {
 	path *topi.Path
@@ -140,12 +160,16 @@ func (i tagPathsPageListItem) styledDesc(selected bool, width int) string {
 	return desc
 }
 
-type tagPathsPageListDelegate struct{}
+type tagPathsPageListDelegate struct {
+	bookmarks *specBookmarks // can be nil not to show bookmarks
+}
 
 var _ list.ItemDelegate = (*tagPathsPageListDelegate)(nil)
 
-func newTagPathsPageListDelegate() tagPathsPageListDelegate {
-	return tagPathsPageListDelegate{}
+func newTagPathsPageListDelegate(bookmarks *specBookmarks) tagPathsPageListDelegate {
+	return tagPathsPageListDelegate{
+		bookmarks: bookmarks,
+	}
 }
 
 func (d tagPathsPageListDelegate) Height() int {
@@ -166,7 +190,7 @@ func (d tagPathsPageListDelegate) Render(w io.Writer, m list.Model, index int, i
 
 	width := m.Width() - listNormalTitleStyle.GetPaddingLeft() - listNormalTitleStyle.GetPaddingRight()
 
-	title := i.styledTitle(selected)
+	title := i.styledTitle(selected) + styledSecuredMarker(i.path) + styledBookmarkMarker(d.bookmarks.contains(i.path))
 	desc := i.styledDesc(selected, width)
 
 	if selected {
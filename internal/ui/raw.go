@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lusingander/topi/internal/topi"
+)
+
+// rawSourceView is the state of the raw source view shown instead of the rendered contents.
+type rawSourceView struct {
+	enabled bool
+	deref   bool // show with all $refs inlined
+}
+
+func (v rawSourceView) statusMessageString() string {
+	if !v.enabled {
+		return ""
+	}
+	if v.deref {
+		return fmt.Sprintf("Raw source (dereferenced), press %s to show as written", keys.toggle[0])
+	}
+	return fmt.Sprintf("Raw source (as written), press %s to show dereferenced", keys.toggle[0])
+}
+
+// styledRawSource returns the syntax-highlighted contents of the node at the source.
+func styledRawSource(doc *topi.Document, src *topi.Source, deref bool, width int) string {
+	if src == nil || doc.Sources == nil {
+		return operationPageItemStyle.Render("Source is not available")
+	}
+	raw, format, err := doc.Sources.Raw(src, deref)
+	if err != nil {
+		return operationPageItemStyle.Render(fmt.Sprintf("Failed to read the source: %s", err))
+	}
+
+	var content strings.Builder
+	header := operationPageSourceStyle.Render("Source: " + sourceString(src))
+	content.WriteString(operationPageItemStyle.Render(header))
+
+	r, _ := markdownRenderer(width - 10)
+	code, err := r.Render(fmt.Sprintf("```%s\n%s```\n", format, raw))
+	if err != nil {
+		code = raw
+	}
+	content.WriteString(operationPageItemStyle.Render(code))
+	return content.String()
+}
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lusingander/topi/internal/bookmark"
+	"github.com/lusingander/topi/internal/recent"
+	"github.com/lusingander/topi/internal/topi"
+)
+
+// specRecent is the recently viewed operations of the current spec shared by the pages.
+type specRecent struct {
+	store *recent.Store // nil if the history is not available
+	spec  string
+}
+
+func newSpecRecent(store *recent.Store, doc *topi.Document) *specRecent {
+	return &specRecent{
+		store: store,
+		spec:  bookmark.SpecKey(doc),
+	}
+}
+
+func (r *specRecent) list() []*recent.Entry {
+	if r == nil || r.store == nil {
+		return nil
+	}
+	return r.store.List(r.spec)
+}
+
+// add records the operation as viewed, and returns the command to show the error if it fails.
+func (r *specRecent) add(p *topi.Path) tea.Cmd {
+	if r == nil || r.store == nil {
+		return nil
+	}
+	if err := r.store.Add(r.spec, p); err != nil {
+		return showStatusMessage(fmt.Sprintf("failed to save recent operations: %s", err))
+	}
+	return nil
+}
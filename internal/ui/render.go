@@ -1,105 +1,63 @@
 package ui
 
 import (
-	"fmt"
+	"regexp"
 	"strings"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/lusingander/topi/internal/topi"
+	"github.com/muesli/reflow/wordwrap"
+	"github.com/muesli/reflow/wrap"
+	"github.com/muesli/termenv"
 )
 
-func schemaTypeString(sc *topi.Schema) string {
-	if len(sc.AllOf) > 0 {
-		return schemaTypeString(sc.MergedAllOf())
+// RenderOperation renders the operation in the same way as the operation page.
+// Lines longer than width are wrapped. If ansi is false, the result does not contain any escape sequences.
+func RenderOperation(op *topi.Path, width int, ansi bool) string {
+	if !ansi {
+		lipgloss.SetColorProfile(termenv.Ascii)
+		return plainText(wrapLines(styledOperation(op, operationStyleOptions{}, width), width))
 	}
-	if len(sc.OneOf) > 0 {
-		ss := make([]string, len(sc.OneOf))
-		for i, s := range sc.OneOf {
-			if s.Type == "object" {
-				ss[i] = fmt.Sprintf("%s[%d]", s.Type, i+1)
-			} else {
-				ss[i] = s.Type
-			}
-		}
-		return fmt.Sprintf("one of (%s)", strings.Join(ss, " | "))
-	}
-
-	var s strings.Builder
-	if sc.Type != "" {
-		if sc.Type == "array" {
-			itemType := sc.Items.Type // schema.items must be present if the type is array
-			s.WriteString(fmt.Sprintf("array of %s", itemType))
-		} else {
-			s.WriteString(sc.Type)
-			if sc.Format != "" {
-				s.WriteString(fmt.Sprintf("(%s)", sc.Format))
-			}
-		}
-	}
-	return s.String()
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	return wrapLines(styledOperation(op, operationStyleOptions{}, width), width)
 }
 
-func schemaConstraintStrings(sc *topi.Schema) []string {
-	ret := make([]string, 0)
-	switch sc.Type {
-	case "integer", "number":
-		if sc.Min != nil || sc.Max != nil {
-			s := "n"
-			if sc.Min != nil {
-				if sc.ExclusiveMin {
-					s = fmt.Sprintf("%g < %s", *sc.Min, s)
-				} else {
-					s = fmt.Sprintf("%g <= %s", *sc.Min, s)
-				}
-			}
-			if sc.Max != nil {
-				if sc.ExclusiveMax {
-					s = fmt.Sprintf("%s < %g", s, *sc.Max)
-				} else {
-					s = fmt.Sprintf("%s <= %g", s, *sc.Max)
-				}
-			}
-			ret = append(ret, s)
-		}
-		if sc.MultipleOf != nil {
-			s := fmt.Sprintf("multiple of %g", *sc.MultipleOf)
-			ret = append(ret, s)
+// wrapLines wraps the lines longer than width, keeping the indentation of the lines.
+func wrapLines(s string, width int) string {
+	lines := strings.Split(s, "\n")
+	ret := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if lipgloss.Width(line) <= width {
+			ret = append(ret, line)
+			continue
 		}
-	case "string":
-		if sc.MinLength > 0 || sc.MaxLength != nil {
-			s := "len"
-			if sc.MinLength > 0 {
-				s = fmt.Sprintf("%d <= %s", sc.MinLength, s)
-			}
-			if sc.MaxLength != nil {
-				s = fmt.Sprintf("%s <= %d", s, *sc.MaxLength)
-			}
-			ret = append(ret, s)
+		// padding may make the line longer than the contents
+		if trimmed := strings.TrimRight(line, " "); lipgloss.Width(trimmed) <= width {
+			ret = append(ret, trimmed)
+			continue
 		}
-		if sc.Pattern != "" {
-			s := sc.Pattern
-			ret = append(ret, s)
+		plain := ansiEscapeSequenceRegexp.ReplaceAllString(line, "")
+		indent := len(plain) - len(strings.TrimLeft(plain, " "))
+		if indent > width/2 {
+			indent = width / 2
 		}
-	case "array":
-		if sc.MinItems > 0 || sc.MaxItems != nil {
-			s := "items"
-			if sc.MinItems > 0 {
-				s = fmt.Sprintf("%d <= %s", sc.MinItems, s)
-			}
-			if sc.MaxItems != nil {
-				s = fmt.Sprintf("%s <= %d", s, *sc.MaxItems)
-			}
-			ret = append(ret, s)
+		body := strings.TrimRight(strings.TrimLeft(line, " "), " ")
+		w := width - indent
+		wrapped := wrap.String(wordwrap.String(body, w), w)
+		for _, l := range strings.Split(wrapped, "\n") {
+			ret = append(ret, strings.Repeat(" ", indent)+l)
 		}
-	case "boolean", "object":
-		// do nothing
 	}
-	return ret
+	return strings.Join(ret, "\n")
 }
 
-func sliceString(vs []interface{}) string {
-	ss := make([]string, len(vs))
-	for i, v := range vs {
-		ss[i] = fmt.Sprintf("%v", v)
+var ansiEscapeSequenceRegexp = regexp.MustCompile("\x1b\\[[0-9;]*[a-zA-Z]")
+
+func plainText(s string) string {
+	s = ansiEscapeSequenceRegexp.ReplaceAllString(s, "")
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
 	}
-	return fmt.Sprintf("[%s]", strings.Join(ss, ", "))
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n") + "\n"
 }
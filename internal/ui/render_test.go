@@ -1,198 +1,62 @@
 package ui
 
 import (
-	"reflect"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
-	"github.com/lusingander/topi/internal/topi"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lusingander/topi/internal/openapi"
 )
 
-func TestSchemaTypeString(t *testing.T) {
-	tests := []struct {
-		schema *topi.Schema
-		want   string
-	}{
-		{
-			schema: &topi.Schema{},
-			want:   "",
-		},
-		{
-			schema: &topi.Schema{
-				Type: "integer",
-			},
-			want: "integer",
-		},
-		{
-			schema: &topi.Schema{
-				Type:   "string",
-				Format: "password",
-			},
-			want: "string(password)",
-		},
-		{
-			schema: &topi.Schema{
-				Type: "array",
-				Items: &topi.Schema{
-					Type: "boolean",
-				},
-			},
-			want: "array of boolean",
-		},
-		{
-			schema: &topi.Schema{
-				Type: "object",
-				Properties: map[string]*topi.Schema{
-					"foo": {Type: "number"},
-				},
-			},
-			want: "object",
-		},
-		{
-			schema: &topi.Schema{
-				OneOf: []*topi.Schema{
-					{Type: "integer"},
-					{Type: "object", Properties: map[string]*topi.Schema{}},
-					{Type: "string"},
-					{Type: "object", Properties: map[string]*topi.Schema{}},
-				},
-			},
-			want: "one of (integer | object[2] | string | object[4])",
-		},
-		{
-			schema: &topi.Schema{
-				AllOf: []*topi.Schema{
-					{Type: "integer"},
-					{Type: "boolean"},
-					{Type: "string"},
-				},
-			},
-			want: "string",
-		},
-		{
-			schema: &topi.Schema{
-				AllOf: []*topi.Schema{
-					{Type: "object", Properties: map[string]*topi.Schema{}},
-					{Type: "object", Properties: map[string]*topi.Schema{}},
-				},
-			},
-			want: "object",
-		},
-	}
-	for _, test := range tests {
-		got := schemaTypeString(test.schema)
-		if got != test.want {
-			t.Errorf("got=%v, want=%v", got, test.want)
-		}
-	}
-}
+var update = flag.Bool("update", false, "update golden files")
 
-func TestSchemaConstraintStrings(t *testing.T) {
+func TestRenderOperation(t *testing.T) {
+	doc, err := openapi.Load(filepath.Join("testdata", "show.yaml"))
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
 	tests := []struct {
-		schema *topi.Schema
-		want   []string
+		operationId string
+		width       int
+		ansi        bool
+		golden      string
 	}{
-		{
-			schema: &topi.Schema{
-				Type: "number",
-				Min:  ptr[float64](1),
-				Max:  ptr[float64](10),
-			},
-			want: []string{
-				"1 <= n <= 10",
-			},
-		},
-		{
-			schema: &topi.Schema{
-				Type:         "number",
-				Min:          ptr(-123.45),
-				Max:          ptr[float64](0),
-				ExclusiveMin: true,
-				ExclusiveMax: true,
-			},
-			want: []string{
-				"-123.45 < n < 0",
-			},
-		},
-		{
-			schema: &topi.Schema{
-				Type:       "integer",
-				MultipleOf: ptr[float64](5),
-			},
-			want: []string{
-				"multiple of 5",
-			},
-		},
-		{
-			schema: &topi.Schema{
-				Type:       "number",
-				Max:        ptr[float64](20),
-				MultipleOf: ptr(0.5),
-			},
-			want: []string{
-				"n <= 20",
-				"multiple of 0.5",
-			},
-		},
-		{
-			schema: &topi.Schema{
-				Type:      "string",
-				MinLength: 1,
-				MaxLength: ptr[uint64](30),
-				Pattern:   "/(?=.*[a-z])(?=.*[A-Z])(?=.*[0-9])/",
-			},
-			want: []string{
-				"1 <= len <= 30",
-				"/(?=.*[a-z])(?=.*[A-Z])(?=.*[0-9])/",
-			},
-		},
-		{
-			schema: &topi.Schema{
-				Type:     "array",
-				MinItems: 2,
-				MaxItems: ptr[uint64](5),
-			},
-			want: []string{
-				"2 <= items <= 5",
-			},
-		},
+		{"listPets", 80, false, "list_pets.golden"},
+		{"listPets", 40, false, "list_pets_40.golden"},
+		{"createPet", 80, false, "create_pet.golden"},
+		{"deletePet", 80, false, "delete_pet.golden"},
+		{"listPets", 80, true, "list_pets_ansi.golden"},
 	}
-
 	for _, test := range tests {
-		got := schemaConstraintStrings(test.schema)
-
-		if !reflect.DeepEqual(got, test.want) {
-			t.Errorf("got=%v, want=%v", got, test.want)
+		op := doc.FindPathByOperationId(test.operationId)
+		if op == nil {
+			t.Fatalf("operation not found: %s", test.operationId)
+		}
+		got := RenderOperation(op, test.width, test.ansi)
+		for _, line := range strings.Split(plainText(got), "\n") {
+			if w := lipgloss.Width(line); w > test.width {
+				t.Errorf("%s: line is longer than %d: %q", test.golden, test.width, line)
+			}
 		}
-	}
-}
-
-func TestSliceString(t *testing.T) {
-	tests := []struct {
-		slice []interface{}
-		want  string
-	}{
-		{
-			slice: []interface{}{},
-			want:  "[]",
-		},
-		{
-			slice: []interface{}{"foo", "bar", "baz"},
-			want:  "[foo, bar, baz]",
-		},
-		{
-			slice: []interface{}{1},
-			want:  "[1]",
-		},
-		{
-			slice: []interface{}{0.25, "abc"},
-			want:  "[0.25, abc]",
-		},
-	}
 
-	for _, test := range tests {
-		got := sliceString(test.slice)
-		if !reflect.DeepEqual(got, test.want) {
-			t.Errorf("got=%v, want=%v", got, test.want)
+		golden := filepath.Join("testdata", "render", test.golden)
+		if *update {
+			if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(golden, []byte(got), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		want, err := os.ReadFile(golden)
+		if err != nil {
+			t.Fatalf("failed to read golden file: %v", err)
+		}
+		if got != string(want) {
+			t.Errorf("%s: got=\n%v\nwant=\n%v", test.golden, got, string(want))
 		}
 	}
 }
@@ -0,0 +1,270 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/glamour/ansi"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lusingander/topi/internal/color"
+	"github.com/lusingander/topi/internal/config"
+)
+
+const (
+	themeNameDark  = "dark"
+	themeNameLight = "light"
+	themeNameAuto  = "auto"
+)
+
+// themeNames is the order to cycle the themes.
+var themeNames = []string{themeNameDark, themeNameLight}
+
+type theme struct {
+	name string
+
+	Selected        lipgloss.TerminalColor
+	Primary         lipgloss.TerminalColor // titles, section headers
+	Link            lipgloss.TerminalColor
+	SelectedLinkFg  lipgloss.TerminalColor
+	SelectedLinkBg  lipgloss.TerminalColor
+	Muted           lipgloss.TerminalColor // descriptions, placeholders
+	Separator       lipgloss.TerminalColor
+	Indent          lipgloss.TerminalColor // indent guides of schema trees
+	Header          lipgloss.TerminalColor // app header, file name in the statusbar
+	StatusbarBg     lipgloss.TerminalColor
+	StatusbarInfoFg lipgloss.TerminalColor
+	StatusbarInfoBg lipgloss.TerminalColor
+	ListTitle       lipgloss.TerminalColor
+	ListDesc        lipgloss.TerminalColor
+	Key             lipgloss.TerminalColor
+	Value           lipgloss.TerminalColor
+	Success         lipgloss.TerminalColor
+	Error           lipgloss.TerminalColor
+	Warning         lipgloss.TerminalColor
+	Info            lipgloss.TerminalColor
+	Alert           lipgloss.TerminalColor // deprecated, breaking changes
+	CodeFg          lipgloss.TerminalColor
+	CodeBg          lipgloss.TerminalColor
+
+	MethodGet                lipgloss.TerminalColor
+	MethodPost               lipgloss.TerminalColor
+	MethodPut                lipgloss.TerminalColor
+	MethodPatch              lipgloss.TerminalColor
+	MethodDelete             lipgloss.TerminalColor
+	MethodSelectedGet        lipgloss.TerminalColor
+	MethodSelectedPost       lipgloss.TerminalColor
+	MethodSelectedPut        lipgloss.TerminalColor
+	MethodSelectedPatch      lipgloss.TerminalColor
+	MethodSelectedDelete     lipgloss.TerminalColor
+	MethodDeprecated         lipgloss.TerminalColor
+	MethodSelectedDeprecated lipgloss.TerminalColor
+
+	MarkdownLinkText  lipgloss.TerminalColor
+	MarkdownCodeBlock lipgloss.TerminalColor
+	MarkdownRule      lipgloss.TerminalColor
+
+	chroma *ansi.Chroma // syntax highlighting of code blocks
+}
+
+// newTheme returns the theme of the name. If the name is auto, the theme is selected by the background color of the terminal.
+func newTheme(name string) (*theme, error) {
+	switch name {
+	case themeNameDark:
+		return darkTheme(), nil
+	case themeNameLight:
+		return lightTheme(), nil
+	case themeNameAuto, "":
+		if lipgloss.HasDarkBackground() {
+			return darkTheme(), nil
+		}
+		return lightTheme(), nil
+	default:
+		return nil, fmt.Errorf("unknown theme: %s", name)
+	}
+}
+
+// nextThemeName returns the name of the theme next to the name in themeNames.
+func nextThemeName(name string) string {
+	for i, n := range themeNames {
+		if n == name {
+			return themeNames[(i+1)%len(themeNames)]
+		}
+	}
+	return themeNames[0]
+}
+
+func darkTheme() *theme {
+	return &theme{
+		name: themeNameDark,
+
+		Selected:        lipgloss.Color("70"),
+		Primary:         lipgloss.Color("70"),
+		Link:            lipgloss.Color("33"),
+		SelectedLinkFg:  lipgloss.Color("56"),
+		SelectedLinkBg:  lipgloss.Color("250"),
+		Muted:           lipgloss.Color("246"),
+		Separator:       lipgloss.Color("240"),
+		Indent:          lipgloss.Color("238"),
+		Header:          lipgloss.Color("65"),
+		StatusbarBg:     lipgloss.Color("237"),
+		StatusbarInfoFg: lipgloss.Color("238"),
+		StatusbarInfoBg: lipgloss.Color("247"),
+		ListTitle:       lipgloss.Color("#dddddd"),
+		ListDesc:        lipgloss.Color("#777777"),
+		Key:             lipgloss.Color("143"),
+		Value:           lipgloss.Color("167"),
+		Success:         lipgloss.Color("77"),
+		Error:           lipgloss.Color("168"),
+		Warning:         lipgloss.Color("179"),
+		Info:            lipgloss.Color("32"),
+		Alert:           lipgloss.Color("208"),
+		CodeFg:          lipgloss.Color("203"),
+		CodeBg:          lipgloss.Color("236"),
+
+		MethodGet:                lipgloss.Color(color.HttpMethodGet),
+		MethodPost:               lipgloss.Color(color.HttpMethodPost),
+		MethodPut:                lipgloss.Color(color.HttpMethodPut),
+		MethodPatch:              lipgloss.Color(color.HttpMethodPatch),
+		MethodDelete:             lipgloss.Color(color.HttpMethodDelete),
+		MethodSelectedGet:        lipgloss.Color(color.HttpMethodSelectedGet),
+		MethodSelectedPost:       lipgloss.Color(color.HttpMethodSelectedPost),
+		MethodSelectedPut:        lipgloss.Color(color.HttpMethodSelectedPut),
+		MethodSelectedPatch:      lipgloss.Color(color.HttpMethodSelectedPatch),
+		MethodSelectedDelete:     lipgloss.Color(color.HttpMethodSelectedDelete),
+		MethodDeprecated:         lipgloss.Color(color.HttpMethodDeprecated),
+		MethodSelectedDeprecated: lipgloss.Color(color.HttpMethodSelectedDeprecated),
+
+		MarkdownLinkText:  lipgloss.Color("195"),
+		MarkdownCodeBlock: lipgloss.Color("244"),
+		MarkdownRule:      lipgloss.Color("242"),
+
+		chroma: darkChroma(),
+	}
+}
+
+func lightTheme() *theme {
+	return &theme{
+		name: themeNameLight,
+
+		Selected:        lipgloss.Color("28"),
+		Primary:         lipgloss.Color("28"),
+		Link:            lipgloss.Color("26"),
+		SelectedLinkFg:  lipgloss.Color("255"),
+		SelectedLinkBg:  lipgloss.Color("26"),
+		Muted:           lipgloss.Color("243"),
+		Separator:       lipgloss.Color("248"),
+		Indent:          lipgloss.Color("250"),
+		Header:          lipgloss.Color("29"),
+		StatusbarBg:     lipgloss.Color("253"),
+		StatusbarInfoFg: lipgloss.Color("255"),
+		StatusbarInfoBg: lipgloss.Color("243"),
+		ListTitle:       lipgloss.Color("#1a1a1a"),
+		ListDesc:        lipgloss.Color("#A49FA5"),
+		Key:             lipgloss.Color("94"),
+		Value:           lipgloss.Color("124"),
+		Success:         lipgloss.Color("28"),
+		Error:           lipgloss.Color("161"),
+		Warning:         lipgloss.Color("130"),
+		Info:            lipgloss.Color("25"),
+		Alert:           lipgloss.Color("166"),
+		CodeFg:          lipgloss.Color("160"),
+		CodeBg:          lipgloss.Color("255"),
+
+		MethodGet:                lipgloss.Color("26"),
+		MethodPost:               lipgloss.Color("29"),
+		MethodPut:                lipgloss.Color("64"),
+		MethodPatch:              lipgloss.Color("163"),
+		MethodDelete:             lipgloss.Color("166"),
+		MethodSelectedGet:        lipgloss.Color("25"),
+		MethodSelectedPost:       lipgloss.Color("23"),
+		MethodSelectedPut:        lipgloss.Color("58"),
+		MethodSelectedPatch:      lipgloss.Color("127"),
+		MethodSelectedDelete:     lipgloss.Color("130"),
+		MethodDeprecated:         lipgloss.Color("245"),
+		MethodSelectedDeprecated: lipgloss.Color("242"),
+
+		MarkdownLinkText:  lipgloss.Color("24"),
+		MarkdownCodeBlock: lipgloss.Color("240"),
+		MarkdownRule:      lipgloss.Color("250"),
+
+		chroma: lightChroma(),
+	}
+}
+
+// override replaces the colors set in the config.
+func (t *theme) override(c config.ThemeConfig) {
+	overrideColor(&t.Selected, c.Selected)
+	overrideColor(&t.Primary, c.Primary)
+	overrideColor(&t.Link, c.Link)
+	overrideColor(&t.SelectedLinkFg, c.SelectedLinkFg)
+	overrideColor(&t.SelectedLinkBg, c.SelectedLinkBg)
+	overrideColor(&t.Muted, c.Muted)
+	overrideColor(&t.Separator, c.Separator)
+	overrideColor(&t.Indent, c.Indent)
+	overrideColor(&t.Header, c.Header)
+	overrideColor(&t.StatusbarBg, c.StatusbarBg)
+	overrideColor(&t.StatusbarInfoFg, c.StatusbarInfoFg)
+	overrideColor(&t.StatusbarInfoBg, c.StatusbarInfoBg)
+	overrideColor(&t.ListTitle, c.ListTitle)
+	overrideColor(&t.ListDesc, c.ListDesc)
+	overrideColor(&t.Key, c.Key)
+	overrideColor(&t.Value, c.Value)
+	overrideColor(&t.Success, c.Success)
+	overrideColor(&t.Error, c.Error)
+	overrideColor(&t.Warning, c.Warning)
+	overrideColor(&t.Info, c.Info)
+	overrideColor(&t.Alert, c.Alert)
+	overrideColor(&t.CodeFg, c.CodeFg)
+	overrideColor(&t.CodeBg, c.CodeBg)
+
+	overrideColor(&t.MethodGet, c.MethodGet)
+	overrideColor(&t.MethodPost, c.MethodPost)
+	overrideColor(&t.MethodPut, c.MethodPut)
+	overrideColor(&t.MethodPatch, c.MethodPatch)
+	overrideColor(&t.MethodDelete, c.MethodDelete)
+	overrideColor(&t.MethodSelectedGet, c.MethodSelectedGet)
+	overrideColor(&t.MethodSelectedPost, c.MethodSelectedPost)
+	overrideColor(&t.MethodSelectedPut, c.MethodSelectedPut)
+	overrideColor(&t.MethodSelectedPatch, c.MethodSelectedPatch)
+	overrideColor(&t.MethodSelectedDelete, c.MethodSelectedDelete)
+	overrideColor(&t.MethodDeprecated, c.MethodDeprecated)
+	overrideColor(&t.MethodSelectedDeprecated, c.MethodSelectedDeprecated)
+
+	overrideColor(&t.MarkdownLinkText, c.MarkdownLinkText)
+	overrideColor(&t.MarkdownCodeBlock, c.MarkdownCodeBlock)
+	overrideColor(&t.MarkdownRule, c.MarkdownRule)
+}
+
+func overrideColor(c *lipgloss.TerminalColor, s string) {
+	if s != "" {
+		*c = lipgloss.Color(s)
+	}
+}
+
+// applyTheme rebuilds all styles with the colors of the theme.
+func applyTheme(t *theme) {
+	loadCommonStyles(t)
+	loadAppStyles(t)
+	loadAboutPageStyles(t)
+	loadCreditsPageStyles(t)
+	loadInfoPageStyles(t)
+	loadOperationPageStyles(t)
+	loadDiffOperationPageStyles(t) // depends on operation page styles
+	loadDiffPathsPageStyles(t)
+	loadPathPageStyles(t)
+	loadProblemsPageStyles(t)
+	loadSecuritySchemePageStyles(t)
+	loadTagPathsPageStyles(t)
+	glamourStyleConfig = newGlamourStyleConfig(t)
+}
+
+func init() {
+	applyTheme(darkTheme())
+}
+
+// colorString returns the color as a string for the configurations that do not accept lipgloss.TerminalColor.
+func colorString(c lipgloss.TerminalColor) string {
+	if c, ok := c.(lipgloss.Color); ok {
+		return string(c)
+	}
+	return ""
+}
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lusingander/topi/internal/config"
+)
+
+func TestThemeOverride(t *testing.T) {
+	th := darkTheme()
+	th.override(config.ThemeConfig{Primary: "33", ListTitle: "#ffffff"})
+
+	tests := []struct {
+		got  lipgloss.TerminalColor
+		want lipgloss.TerminalColor
+	}{
+		{th.Primary, lipgloss.Color("33")},
+		{th.ListTitle, lipgloss.Color("#ffffff")},
+		{th.Muted, lipgloss.Color("246")},
+		{th.ListDesc, lipgloss.Color("#777777")},
+	}
+	for _, test := range tests {
+		if test.got != test.want {
+			t.Errorf("got=%v, want=%v", test.got, test.want)
+		}
+	}
+}
+
+func TestKeyMapOverride(t *testing.T) {
+	km := defaultKeyMap()
+	km.override(config.KeymapConfig{Quit: []string{"q", "ctrl+c"}})
+
+	if got, want := km.quit.binding("quit").Keys(), []string{"q", "ctrl+c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got=%v, want=%v", got, want)
+	}
+	if got, want := km.back.binding("back").Help().Key, "backspace"; got != want {
+		t.Errorf("got=%v, want=%v", got, want)
+	}
+}
+
+func TestNextThemeName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{themeNameDark, themeNameLight},
+		{themeNameLight, themeNameDark},
+		{"", themeNameDark},
+	}
+	for _, test := range tests {
+		got := nextThemeName(test.name)
+		if got != test.want {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+}
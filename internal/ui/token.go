@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lusingander/topi/internal/oauth"
+	"github.com/lusingander/topi/internal/topi"
+)
+
+// tokenTimeout is the time to wait for the token, including the authorization in the browser.
+const tokenTimeout = 5 * time.Minute
+
+// acquireToken acquires the token of the security scheme in the background and stores it.
+// The requests are sent by the client, http.DefaultClient if nil.
+func acquireToken(scheme *topi.SecurityScheme, cfg oauth.Config, client *http.Client, tokens *oauth.Store) tea.Cmd {
+	return func() tea.Msg {
+		grant, err := oauth.GrantOf(scheme, cfg)
+		if err != nil {
+			return tokenAcquiredMsg{scheme.Key, err}
+		}
+		c := oauth.NewClient(cfg)
+		c.HTTPClient = client
+		c.Open = openInBrowser
+		ctx, cancel := context.WithTimeout(context.Background(), tokenTimeout)
+		defer cancel()
+		t, err := c.Token(ctx, scheme, grant)
+		if err != nil {
+			return tokenAcquiredMsg{scheme.Key, err}
+		}
+		tokens.Set(scheme.Key, t)
+		return tokenAcquiredMsg{scheme.Key, nil}
+	}
+}
+
+func acquirable(scheme *topi.SecurityScheme) bool {
+	return scheme.Type == "oauth2" || scheme.Type == "openIdConnect"
+}
@@ -1,8 +1,10 @@
 package ui
 
 import (
+	"sort"
 	"strings"
 
+	"github.com/lusingander/topi/internal/topi"
 	"github.com/muesli/reflow/truncate"
 	"github.com/pkg/browser"
 )
@@ -26,6 +28,15 @@ func containsString(v string, ss []string) bool {
 	return false
 }
 
+func sortedPropertyNames(props map[string]*topi.Schema) []string {
+	ret := make([]string, 0, len(props))
+	for name := range props {
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
 func ptr[T any](v T) *T {
 	return &v
 }
@@ -41,3 +52,19 @@ func digit(n uint) uint {
 	}
 	return c
 }
+
+// hideDeprecated hides deprecated operations in the operation lists.
+var hideDeprecated bool
+
+func listedPaths(paths []*topi.Path) []*topi.Path {
+	if !hideDeprecated {
+		return paths
+	}
+	ret := make([]*topi.Path, 0, len(paths))
+	for _, p := range paths {
+		if !p.Deprecated {
+			ret = append(ret, p)
+		}
+	}
+	return ret
+}
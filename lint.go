@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/lusingander/topi/internal/lint"
+)
+
+func runLint(args []string) error {
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	configPath := fs.String("config", "", "lint rule configuration yaml filepath")
+	format := fs.String("format", "text", "output format (text, json)")
+	remoteFlags := addRemoteFlags(fs)
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return errors.New("usage: topi lint [--config <config>] [--format text|json] <path>")
+	}
+	cfg, err := loadLintConfig(*configPath)
+	if err != nil {
+		return err
+	}
+	loader, err := remoteFlags.defaultLoader(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	doc, err := loader.Load(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	ps := lint.Run(doc, cfg)
+	switch *format {
+	case "text":
+		err = lint.WriteText(os.Stdout, ps)
+	case "json":
+		err = lint.WriteJSON(os.Stdout, ps)
+	default:
+		err = fmt.Errorf("unknown format: %s", *format)
+	}
+	if err != nil {
+		return err
+	}
+	if lint.HasError(ps) {
+		return errCheckFailed
+	}
+	return nil
+}
+
+func loadLintConfig(path string) (*lint.Config, error) {
+	if path == "" {
+		return nil, nil
+	}
+	return lint.LoadConfig(path)
+}
@@ -2,33 +2,132 @@ package main
 
 import (
 	"errors"
+	"flag"
+	"fmt"
 	"os"
+	"path/filepath"
 
-	"github.com/lusingander/topi/internal/openapi"
+	"github.com/lusingander/topi/internal/config"
+	"github.com/lusingander/topi/internal/topi"
 	"github.com/lusingander/topi/internal/ui"
 )
 
-func pathFromArgs(args []string) (string, error) {
-	if len(args) <= 1 {
-		return "", errors.New("must set OpenAPI spec json/yaml filepath as argument")
+// errCheckFailed is returned if the check subcommands found problems, and exits with status 1.
+var errCheckFailed = errors.New("check failed")
+
+func pathsFromArgs(args []string) ([]string, error) {
+	if len(args) == 0 {
+		return nil, errors.New("must set OpenAPI spec json/yaml filepath as argument")
+	}
+	ret := make([]string, 0)
+	for _, arg := range args {
+		matches, err := filepath.Glob(arg)
+		if err != nil || len(matches) == 0 {
+			// not a glob pattern, or remote URL
+			ret = append(ret, arg)
+			continue
+		}
+		ret = append(ret, matches...)
+	}
+	return ret, nil
+}
+
+// parseFlags parses the flags set before or after the positional arguments (e.g. `topi spec.yaml --tag pets`),
+// and returns the positional arguments.
+func parseFlags(fs *flag.FlagSet, args []string) []string {
+	fs.Parse(args)
+	ret := make([]string, 0)
+	for fs.NArg() > 0 {
+		ret = append(ret, fs.Arg(0))
+		fs.Parse(fs.Args()[1:])
 	}
-	return args[1], nil
+	return ret
 }
 
 func run(args []string) error {
-	path, err := pathFromArgs(args)
+	if len(args) > 1 {
+		switch args[1] {
+		case "diff":
+			return runDiff(args[2:])
+		case "breaking":
+			return runBreaking(args[2:])
+		case "lint":
+			return runLint(args[2:])
+		case "export":
+			return runExport(args[2:])
+		case "show":
+			return runShow(args[2:])
+		case "cache":
+			return runCache(args[2:])
+		}
+	}
+	return runView(args)
+}
+
+func runView(args []string) error {
+	fs := flag.NewFlagSet("topi", flag.ExitOnError)
+	lintConfigPath := fs.String("lint-config", "", "lint rule configuration yaml filepath")
+	configPath := fs.String("config", "", "configuration yaml filepath (default: ~/.config/topi/config.yaml)")
+	theme := fs.String("theme", "auto", "color theme (dark, light, auto)")
+	operation := fs.String("operation", "", "operationId of the operation to open first")
+	methodPath := fs.String("path", "", "'METHOD /path' of the operation to open first")
+	tag := fs.String("tag", "", "tag to open first (with --operation or --path, the tag of the operation)")
+	info := fs.Bool("info", false, "open the info page first")
+	remoteFlags := addRemoteFlags(fs)
+	args = parseFlags(fs, args[1:])
+
+	paths, err := pathsFromArgs(args)
 	if err != nil {
 		return err
 	}
-	doc, err := openapi.Load(path)
+	if *operation != "" && *methodPath != "" {
+		return errors.New("only one of --operation and --path can be set")
+	}
+	if *info && (*operation != "" || *methodPath != "" || *tag != "") {
+		return errors.New("--info can not be set with --operation, --path or --tag")
+	}
+	target := &ui.Target{
+		Tag:         *tag,
+		OperationId: *operation,
+		Path:        *methodPath,
+		Info:        *info,
+	}
+	lintConfig, err := loadLintConfig(*lintConfigPath)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(*configPath)
 	if err != nil {
 		return err
 	}
-	return ui.Start(doc)
+	loader, err := remoteFlags.loader(cfg.Remote, paths...)
+	if err != nil {
+		return err
+	}
+	docs := make([]*topi.Document, len(paths))
+	for i, path := range paths {
+		doc, err := loader.Load(path)
+		if err != nil {
+			return err
+		}
+		for _, e := range doc.ValidationErrors {
+			fmt.Fprintf(os.Stderr, "warning: %s: %s\n", path, e)
+		}
+		docs[i] = doc
+	}
+	return ui.Start(docs, lintConfig, cfg, *theme, target, loader.Load)
 }
 
 func main() {
 	if err := run(os.Args); err != nil {
+		if errors.Is(err, errCheckFailed) {
+			os.Exit(1)
+		}
+		var notFound *ui.TargetNotFoundError
+		if errors.As(err, &notFound) {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
 		panic(err)
 	}
 }
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPathsFromArgs(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.yaml", "b.yaml", "c.json"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("openapi: 3.0.3"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		args    []string
+		want    []string
+		wantErr bool
+	}{
+		{[]string{filepath.Join(dir, "*.yaml")}, []string{filepath.Join(dir, "a.yaml"), filepath.Join(dir, "b.yaml")}, false},
+		{[]string{filepath.Join(dir, "c.json"), filepath.Join(dir, "?.yaml")}, []string{filepath.Join(dir, "c.json"), filepath.Join(dir, "a.yaml"), filepath.Join(dir, "b.yaml")}, false},
+		// not matched patterns and remote URLs are kept as they are
+		{[]string{filepath.Join(dir, "*.yml")}, []string{filepath.Join(dir, "*.yml")}, false},
+		{[]string{"https://example.com/openapi.yaml"}, []string{"https://example.com/openapi.yaml"}, false},
+		{[]string{"-"}, []string{"-"}, false},
+		{nil, nil, true},
+	}
+	for _, test := range tests {
+		got, err := pathsFromArgs(test.args)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("error is expected: %v", test.args)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("got=%v, want=%v", got, test.want)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"flag"
+	"strings"
+	"time"
+
+	"github.com/lusingander/topi/internal/cache"
+	"github.com/lusingander/topi/internal/config"
+	"github.com/lusingander/topi/internal/openapi"
+	"github.com/lusingander/topi/internal/remote"
+)
+
+type headerFlags []string
+
+func (h *headerFlags) String() string {
+	return strings.Join(*h, ", ")
+}
+
+func (h *headerFlags) Set(s string) error {
+	*h = append(*h, s)
+	return nil
+}
+
+// remoteFlags are the flags to load the remote specs and external refs, common to all subcommands.
+type remoteFlags struct {
+	headers     headerFlags
+	bearerToken *string
+	basicAuth   *string
+	caCert      *string
+	insecure    *bool
+	timeout     *time.Duration
+	proxy       *string
+	noCache     *bool
+}
+
+func addRemoteFlags(fs *flag.FlagSet) *remoteFlags {
+	f := &remoteFlags{}
+	fs.Var(&f.headers, "header", "request header 'Name: value' for remote specs (can be set multiple times)")
+	f.bearerToken = fs.String("bearer-token", "", "bearer token for remote specs")
+	f.basicAuth = fs.String("basic-auth", "", "'username:password' for remote specs")
+	f.caCert = fs.String("ca-cert", "", "PEM file of CA certificates for remote specs")
+	f.insecure = fs.Bool("insecure", false, "skip verifying server certificates of remote specs")
+	f.timeout = fs.Duration("timeout", 0, "timeout of each request for remote specs (e.g. 30s)")
+	f.proxy = fs.String("proxy", "", "proxy URL for remote specs (default: HTTP_PROXY/HTTPS_PROXY)")
+	f.noCache = fs.Bool("no-cache", false, "do not use the offline cache of remote specs")
+	return f
+}
+
+// loader returns the loader with the settings of the flags overriding the config.
+// The headers and the credentials are sent to the hosts of the remote specs in paths unless set in the config.
+func (f *remoteFlags) loader(cfg remote.Config, paths ...string) (*openapi.Loader, error) {
+	headers := make(map[string]string)
+	for _, h := range f.headers {
+		name, value, err := remote.ParseHeader(h)
+		if err != nil {
+			return nil, err
+		}
+		headers[name] = value
+	}
+	c := cfg.Merge(remote.Config{
+		Headers:     headers,
+		BearerToken: *f.bearerToken,
+		BasicAuth:   *f.basicAuth,
+		CACert:      *f.caCert,
+		Insecure:    *f.insecure,
+		Timeout:     *f.timeout,
+		Proxy:       *f.proxy,
+	})
+	client, err := remote.NewClient(c, paths)
+	if err != nil {
+		return nil, err
+	}
+	loader := &openapi.Loader{Client: client}
+	if !*f.noCache {
+		loader.Cache, err = openCache()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return loader, nil
+}
+
+// openCache returns nil if the cache directory is not available.
+func openCache() (*cache.Cache, error) {
+	dir, err := cache.DefaultDir()
+	if err != nil {
+		return nil, nil
+	}
+	return cache.Open(dir)
+}
+
+// defaultLoader returns the loader with the settings of the flags and the default config file,
+// used by the subcommands which do not read the config file set by --config.
+func (f *remoteFlags) defaultLoader(paths ...string) (*openapi.Loader, error) {
+	cfg, err := config.Load("")
+	if err != nil {
+		return nil, err
+	}
+	return f.loader(cfg.Remote, paths...)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/lusingander/topi/internal/export"
+	"github.com/lusingander/topi/internal/topi"
+	"github.com/lusingander/topi/internal/ui"
+)
+
+func runShow(args []string) error {
+	fs := flag.NewFlagSet("show", flag.ExitOnError)
+	plain := fs.Bool("plain", false, "print as plain text (default)")
+	ansi := fs.Bool("ansi", false, "print as text with ANSI colors")
+	markdown := fs.Bool("markdown", false, "print as Markdown")
+	width := fs.Int("width", 80, "width of the output (plain, ansi)")
+	remoteFlags := addRemoteFlags(fs)
+	fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		return errors.New("usage: topi show [--plain|--ansi|--markdown] [--width <n>] <path> <operationId|'METHOD /path'>")
+	}
+	n := 0
+	for _, b := range []bool{*plain, *ansi, *markdown} {
+		if b {
+			n++
+		}
+	}
+	if n > 1 {
+		return errors.New("only one of --plain, --ansi and --markdown can be set")
+	}
+	loader, err := remoteFlags.defaultLoader(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	doc, err := loader.Load(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	op := findOperation(doc, fs.Arg(1))
+	if op == nil {
+		return fmt.Errorf("operation not found: %s", fs.Arg(1))
+	}
+
+	var out string
+	switch {
+	case *markdown:
+		out = string(export.MarkdownOperation(op))
+	case *ansi:
+		out = ui.RenderOperation(op, *width, true)
+	default:
+		out = ui.RenderOperation(op, *width, false)
+	}
+	_, err = fmt.Fprint(os.Stdout, out)
+	return err
+}
+
+// findOperation finds the operation by operationId or "METHOD /path".
+func findOperation(doc *topi.Document, s string) *topi.Path {
+	if op := doc.FindPathByOperationId(s); op != nil {
+		return op
+	}
+	method, path, ok := strings.Cut(strings.TrimSpace(s), " ")
+	if !ok {
+		return nil
+	}
+	return doc.FindPath(strings.ToUpper(method), strings.TrimSpace(path))
+}